@@ -20,13 +20,94 @@ var _ = math.Inf
 func (this *Category) Validate() error {
 	return nil
 }
+func (this *Coordinates) Validate() error {
+	if !(this.Latitude >= -90) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Latitude", fmt.Errorf(`value '%v' must be greater than or equal to '-90'`, this.Latitude))
+	}
+	if !(this.Latitude <= 90) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Latitude", fmt.Errorf(`value '%v' must be lower than or equal to '90'`, this.Latitude))
+	}
+	if !(this.Longitude >= -180) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Longitude", fmt.Errorf(`value '%v' must be greater than or equal to '-180'`, this.Longitude))
+	}
+	if !(this.Longitude <= 180) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Longitude", fmt.Errorf(`value '%v' must be lower than or equal to '180'`, this.Longitude))
+	}
+	return nil
+}
+
+var _regex_AddressComponents_CountryCode = regexp.MustCompile(`^([A-Z]{2})?$`)
+
+func (this *AddressComponents) Validate() error {
+	if !_regex_AddressComponents_CountryCode.MatchString(this.CountryCode) {
+		return github_com_mwitkow_go_proto_validators.FieldError("CountryCode", fmt.Errorf(`value '%v' must be a string conforming to regex "^([A-Z]{2})?$"`, this.CountryCode))
+	}
+	return nil
+}
 func (this *Location) Validate() error {
+	if this.Coordinates != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Coordinates); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Coordinates", err)
+		}
+	}
+	if this.AddressComponents != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.AddressComponents); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("AddressComponents", err)
+		}
+	}
+	return nil
+}
+func (this *Tag) Validate() error {
+	return nil
+}
+
+var _regex_Share_UserId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_Share_Role = regexp.MustCompile(`^(owner|editor|viewer)$`)
+
+func (this *Share) Validate() error {
+	if !_regex_Share_UserId.MatchString(this.UserId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.UserId))
+	}
+	if this.UserId == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must not be an empty string`, this.UserId))
+	}
+	if !_regex_Share_Role.MatchString(this.Role) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Role", fmt.Errorf(`value '%v' must be a string conforming to regex "^(owner|editor|viewer)$"`, this.Role))
+	}
 	return nil
 }
+func (this *Group) Validate() error {
+	return nil
+}
+
+var _regex_GroupMember_UserId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_GroupMember_Role = regexp.MustCompile(`^(owner|member)$`)
+
+func (this *GroupMember) Validate() error {
+	if !_regex_GroupMember_UserId.MatchString(this.UserId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.UserId))
+	}
+	if this.UserId == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must not be an empty string`, this.UserId))
+	}
+	if !_regex_GroupMember_Role.MatchString(this.Role) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Role", fmt.Errorf(`value '%v' must be a string conforming to regex "^(owner|member)$"`, this.Role))
+	}
+	return nil
+}
+func (this *BulkFailure) Validate() error {
+	return nil
+}
+
+var _regex_CreateCategoryRequest_ParentId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
 func (this *CreateCategoryRequest) Validate() error {
 	if this.Name == "" {
 		return github_com_mwitkow_go_proto_validators.FieldError("Name", fmt.Errorf(`value '%v' must not be an empty string`, this.Name))
 	}
+	if !_regex_CreateCategoryRequest_ParentId.MatchString(this.ParentId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("ParentId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.ParentId))
+	}
 	return nil
 }
 func (this *CreateCategoryResponse) Validate() error {
@@ -100,21 +181,154 @@ func (this *DeleteCategoryResponse) Validate() error {
 	return nil
 }
 
+var _regex_MergeCategoriesRequest_TargetId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_MergeCategoriesRequest_SourceIds = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *MergeCategoriesRequest) Validate() error {
+	if !_regex_MergeCategoriesRequest_TargetId.MatchString(this.TargetId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("TargetId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.TargetId))
+	}
+	if this.TargetId == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("TargetId", fmt.Errorf(`value '%v' must not be an empty string`, this.TargetId))
+	}
+	if len(this.SourceIds) < 1 {
+		return github_com_mwitkow_go_proto_validators.FieldError("SourceIds", fmt.Errorf(`value '%v' must contain at least 1 elements`, this.SourceIds))
+	}
+	if len(this.SourceIds) > 50 {
+		return github_com_mwitkow_go_proto_validators.FieldError("SourceIds", fmt.Errorf(`value '%v' must contain at most 50 elements`, this.SourceIds))
+	}
+	for _, item := range this.SourceIds {
+		if !_regex_MergeCategoriesRequest_SourceIds.MatchString(item) {
+			return github_com_mwitkow_go_proto_validators.FieldError("SourceIds", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, item))
+		}
+		if item == "" {
+			return github_com_mwitkow_go_proto_validators.FieldError("SourceIds", fmt.Errorf(`value '%v' must not be an empty string`, item))
+		}
+	}
+	return nil
+}
+func (this *MergeCategoriesResponse) Validate() error {
+	for _, item := range this.Failed {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Failed", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_GetCategorySharesRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *GetCategorySharesRequest) Validate() error {
+	if !_regex_GetCategorySharesRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	return nil
+}
+func (this *GetCategorySharesResponse) Validate() error {
+	for _, item := range this.Shares {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Shares", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_ShareCategoryRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *ShareCategoryRequest) Validate() error {
+	if !_regex_ShareCategoryRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	if nil == this.Share {
+		return github_com_mwitkow_go_proto_validators.FieldError("Share", fmt.Errorf("message must exist"))
+	}
+	if this.Share != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Share); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Share", err)
+		}
+	}
+	return nil
+}
+func (this *ShareCategoryResponse) Validate() error {
+	if this.Share != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Share); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Share", err)
+		}
+	}
+	return nil
+}
+
+var _regex_UnshareCategoryRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_UnshareCategoryRequest_UserId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *UnshareCategoryRequest) Validate() error {
+	if !_regex_UnshareCategoryRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	if !_regex_UnshareCategoryRequest_UserId.MatchString(this.UserId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.UserId))
+	}
+	if this.UserId == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must not be an empty string`, this.UserId))
+	}
+	return nil
+}
+func (this *UnshareCategoryResponse) Validate() error {
+	return nil
+}
+
 var _regex_CreateLocationRequest_CategoryId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_CreateLocationRequest_PlusCodeReferenceId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_CreateLocationRequest_Tags = regexp.MustCompile(`^[^,]+$`)
+var _regex_CreateLocationRequest_GroupId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
 
 func (this *CreateLocationRequest) Validate() error {
 	if this.Name == "" {
 		return github_com_mwitkow_go_proto_validators.FieldError("Name", fmt.Errorf(`value '%v' must not be an empty string`, this.Name))
 	}
-	if this.Address == "" {
-		return github_com_mwitkow_go_proto_validators.FieldError("Address", fmt.Errorf(`value '%v' must not be an empty string`, this.Address))
-	}
 	if !_regex_CreateLocationRequest_CategoryId.MatchString(this.CategoryId) {
 		return github_com_mwitkow_go_proto_validators.FieldError("CategoryId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.CategoryId))
 	}
 	if this.CategoryId == "" {
 		return github_com_mwitkow_go_proto_validators.FieldError("CategoryId", fmt.Errorf(`value '%v' must not be an empty string`, this.CategoryId))
 	}
+	if this.Coordinates != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Coordinates); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Coordinates", err)
+		}
+	}
+	if this.AddressComponents != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.AddressComponents); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("AddressComponents", err)
+		}
+	}
+	if !_regex_CreateLocationRequest_PlusCodeReferenceId.MatchString(this.PlusCodeReferenceId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("PlusCodeReferenceId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.PlusCodeReferenceId))
+	}
+	if len(this.Tags) > 20 {
+		return github_com_mwitkow_go_proto_validators.FieldError("Tags", fmt.Errorf(`value '%v' must contain at most 20 elements`, this.Tags))
+	}
+	for _, item := range this.Tags {
+		if !_regex_CreateLocationRequest_Tags.MatchString(item) {
+			return github_com_mwitkow_go_proto_validators.FieldError("Tags", fmt.Errorf(`value '%v' must be a string conforming to regex "^[^,]+$"`, item))
+		}
+	}
+	if !_regex_CreateLocationRequest_GroupId.MatchString(this.GroupId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("GroupId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.GroupId))
+	}
 	return nil
 }
 func (this *CreateLocationResponse) Validate() error {
@@ -160,11 +374,30 @@ func (this *GetLocationResponse) Validate() error {
 }
 
 var _regex_UpdateLocationRequest_CategoryId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_UpdateLocationRequest_Tags = regexp.MustCompile(`^[^,]+$`)
 
 func (this *UpdateLocationRequest) Validate() error {
 	if !_regex_UpdateLocationRequest_CategoryId.MatchString(this.CategoryId) {
 		return github_com_mwitkow_go_proto_validators.FieldError("CategoryId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.CategoryId))
 	}
+	if this.Coordinates != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Coordinates); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Coordinates", err)
+		}
+	}
+	if this.AddressComponents != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.AddressComponents); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("AddressComponents", err)
+		}
+	}
+	if len(this.Tags) > 20 {
+		return github_com_mwitkow_go_proto_validators.FieldError("Tags", fmt.Errorf(`value '%v' must contain at most 20 elements`, this.Tags))
+	}
+	for _, item := range this.Tags {
+		if !_regex_UpdateLocationRequest_Tags.MatchString(item) {
+			return github_com_mwitkow_go_proto_validators.FieldError("Tags", fmt.Errorf(`value '%v' must be a string conforming to regex "^[^,]+$"`, item))
+		}
+	}
 	return nil
 }
 func (this *UpdateLocationResponse) Validate() error {
@@ -190,3 +423,427 @@ func (this *DeleteLocationRequest) Validate() error {
 func (this *DeleteLocationResponse) Validate() error {
 	return nil
 }
+
+var _regex_MoveLocationsRequest_Ids = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_MoveLocationsRequest_CategoryId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *MoveLocationsRequest) Validate() error {
+	if len(this.Ids) < 1 {
+		return github_com_mwitkow_go_proto_validators.FieldError("Ids", fmt.Errorf(`value '%v' must contain at least 1 elements`, this.Ids))
+	}
+	if len(this.Ids) > 100 {
+		return github_com_mwitkow_go_proto_validators.FieldError("Ids", fmt.Errorf(`value '%v' must contain at most 100 elements`, this.Ids))
+	}
+	for _, item := range this.Ids {
+		if !_regex_MoveLocationsRequest_Ids.MatchString(item) {
+			return github_com_mwitkow_go_proto_validators.FieldError("Ids", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, item))
+		}
+		if item == "" {
+			return github_com_mwitkow_go_proto_validators.FieldError("Ids", fmt.Errorf(`value '%v' must not be an empty string`, item))
+		}
+	}
+	if !_regex_MoveLocationsRequest_CategoryId.MatchString(this.CategoryId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("CategoryId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.CategoryId))
+	}
+	if this.CategoryId == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("CategoryId", fmt.Errorf(`value '%v' must not be an empty string`, this.CategoryId))
+	}
+	return nil
+}
+func (this *MoveLocationsResponse) Validate() error {
+	for _, item := range this.Failed {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Failed", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_FindLocationsNearRequest_CategoryId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *FindLocationsNearRequest) Validate() error {
+	if nil == this.Center {
+		return github_com_mwitkow_go_proto_validators.FieldError("Center", fmt.Errorf("message must exist"))
+	}
+	if this.Center != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Center); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Center", err)
+		}
+	}
+	if !(this.Radius > 0) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Radius", fmt.Errorf(`value '%v' must be strictly greater than '0'`, this.Radius))
+	}
+	if !(this.Radius <= 200000) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Radius", fmt.Errorf(`value '%v' must be lower than or equal to '200000'`, this.Radius))
+	}
+	if !_regex_FindLocationsNearRequest_CategoryId.MatchString(this.CategoryId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("CategoryId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.CategoryId))
+	}
+	return nil
+}
+func (this *NearLocation) Validate() error {
+	if this.Location != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Location); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Location", err)
+		}
+	}
+	return nil
+}
+func (this *FindLocationsNearResponse) Validate() error {
+	for _, item := range this.Locations {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Locations", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_FindLocationsByTagsRequest_CategoryId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *FindLocationsByTagsRequest) Validate() error {
+	if len(this.Tags) < 1 {
+		return github_com_mwitkow_go_proto_validators.FieldError("Tags", fmt.Errorf(`value '%v' must contain at least 1 elements`, this.Tags))
+	}
+	if !_regex_FindLocationsByTagsRequest_CategoryId.MatchString(this.CategoryId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("CategoryId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.CategoryId))
+	}
+	return nil
+}
+func (this *FindLocationsByTagsResponse) Validate() error {
+	for _, item := range this.Locations {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Locations", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_GetLocationSharesRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *GetLocationSharesRequest) Validate() error {
+	if !_regex_GetLocationSharesRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	return nil
+}
+func (this *GetLocationSharesResponse) Validate() error {
+	for _, item := range this.Shares {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Shares", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_ShareLocationRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *ShareLocationRequest) Validate() error {
+	if !_regex_ShareLocationRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	if nil == this.Share {
+		return github_com_mwitkow_go_proto_validators.FieldError("Share", fmt.Errorf("message must exist"))
+	}
+	if this.Share != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Share); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Share", err)
+		}
+	}
+	return nil
+}
+func (this *ShareLocationResponse) Validate() error {
+	if this.Share != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Share); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Share", err)
+		}
+	}
+	return nil
+}
+
+var _regex_UnshareLocationRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_UnshareLocationRequest_UserId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *UnshareLocationRequest) Validate() error {
+	if !_regex_UnshareLocationRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	if !_regex_UnshareLocationRequest_UserId.MatchString(this.UserId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.UserId))
+	}
+	if this.UserId == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must not be an empty string`, this.UserId))
+	}
+	return nil
+}
+func (this *UnshareLocationResponse) Validate() error {
+	return nil
+}
+
+var _regex_CreateTagRequest_Name = regexp.MustCompile(`^[^,]*$`)
+
+func (this *CreateTagRequest) Validate() error {
+	if !_regex_CreateTagRequest_Name.MatchString(this.Name) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Name", fmt.Errorf(`value '%v' must be a string conforming to regex "^[^,]*$"`, this.Name))
+	}
+	if this.Name == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Name", fmt.Errorf(`value '%v' must not be an empty string`, this.Name))
+	}
+	return nil
+}
+func (this *CreateTagResponse) Validate() error {
+	if this.Tag != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Tag); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Tag", err)
+		}
+	}
+	return nil
+}
+func (this *GetTagsRequest) Validate() error {
+	return nil
+}
+func (this *GetTagsResponse) Validate() error {
+	for _, item := range this.Tags {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Tags", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_GetTagRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *GetTagRequest) Validate() error {
+	if !_regex_GetTagRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	return nil
+}
+func (this *GetTagResponse) Validate() error {
+	if this.Tag != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Tag); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Tag", err)
+		}
+	}
+	return nil
+}
+
+var _regex_UpdateTagRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_UpdateTagRequest_Name = regexp.MustCompile(`^[^,]*$`)
+
+func (this *UpdateTagRequest) Validate() error {
+	if !_regex_UpdateTagRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	if !_regex_UpdateTagRequest_Name.MatchString(this.Name) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Name", fmt.Errorf(`value '%v' must be a string conforming to regex "^[^,]*$"`, this.Name))
+	}
+	if this.Name == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Name", fmt.Errorf(`value '%v' must not be an empty string`, this.Name))
+	}
+	return nil
+}
+func (this *UpdateTagResponse) Validate() error {
+	if this.Tag != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Tag); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Tag", err)
+		}
+	}
+	return nil
+}
+
+var _regex_DeleteTagRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *DeleteTagRequest) Validate() error {
+	if !_regex_DeleteTagRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	return nil
+}
+func (this *DeleteTagResponse) Validate() error {
+	return nil
+}
+func (this *CreateGroupRequest) Validate() error {
+	if this.Name == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Name", fmt.Errorf(`value '%v' must not be an empty string`, this.Name))
+	}
+	return nil
+}
+func (this *CreateGroupResponse) Validate() error {
+	if this.Group != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Group); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Group", err)
+		}
+	}
+	return nil
+}
+func (this *GetGroupsRequest) Validate() error {
+	return nil
+}
+func (this *GetGroupsResponse) Validate() error {
+	for _, item := range this.Groups {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Groups", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_GetGroupRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *GetGroupRequest) Validate() error {
+	if !_regex_GetGroupRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	return nil
+}
+func (this *GetGroupResponse) Validate() error {
+	if this.Group != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Group); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Group", err)
+		}
+	}
+	return nil
+}
+
+var _regex_UpdateGroupRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *UpdateGroupRequest) Validate() error {
+	if !_regex_UpdateGroupRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	if this.Name == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Name", fmt.Errorf(`value '%v' must not be an empty string`, this.Name))
+	}
+	return nil
+}
+func (this *UpdateGroupResponse) Validate() error {
+	if this.Group != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Group); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Group", err)
+		}
+	}
+	return nil
+}
+
+var _regex_DeleteGroupRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *DeleteGroupRequest) Validate() error {
+	if !_regex_DeleteGroupRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	return nil
+}
+func (this *DeleteGroupResponse) Validate() error {
+	return nil
+}
+
+var _regex_GetGroupMembersRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *GetGroupMembersRequest) Validate() error {
+	if !_regex_GetGroupMembersRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	return nil
+}
+func (this *GetGroupMembersResponse) Validate() error {
+	for _, item := range this.Members {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Members", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_SetGroupMemberRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *SetGroupMemberRequest) Validate() error {
+	if !_regex_SetGroupMemberRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	if nil == this.Member {
+		return github_com_mwitkow_go_proto_validators.FieldError("Member", fmt.Errorf("message must exist"))
+	}
+	if this.Member != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Member); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Member", err)
+		}
+	}
+	return nil
+}
+func (this *SetGroupMemberResponse) Validate() error {
+	if this.Member != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Member); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Member", err)
+		}
+	}
+	return nil
+}
+
+var _regex_RemoveGroupMemberRequest_Id = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_RemoveGroupMemberRequest_UserId = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *RemoveGroupMemberRequest) Validate() error {
+	if !_regex_RemoveGroupMemberRequest_Id.MatchString(this.Id) {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.Id))
+	}
+	if this.Id == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Id", fmt.Errorf(`value '%v' must not be an empty string`, this.Id))
+	}
+	if !_regex_RemoveGroupMemberRequest_UserId.MatchString(this.UserId) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must be a string conforming to regex "^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$"`, this.UserId))
+	}
+	if this.UserId == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UserId", fmt.Errorf(`value '%v' must not be an empty string`, this.UserId))
+	}
+	return nil
+}
+func (this *RemoveGroupMemberResponse) Validate() error {
+	return nil
+}
@@ -28,6 +28,14 @@ type LocationServiceClient interface {
 	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error)
 	// Delete one category.
 	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
+	// Merge categories into another one, moving all their locations.
+	MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*MergeCategoriesResponse, error)
+	// Retrieve the roles granted on one user category to other users.
+	GetCategoryShares(ctx context.Context, in *GetCategorySharesRequest, opts ...grpc.CallOption) (*GetCategorySharesResponse, error)
+	// Grant a role on all locations of one user category to another user.
+	ShareCategory(ctx context.Context, in *ShareCategoryRequest, opts ...grpc.CallOption) (*ShareCategoryResponse, error)
+	// Revoke the role granted on one user category to another user.
+	UnshareCategory(ctx context.Context, in *UnshareCategoryRequest, opts ...grpc.CallOption) (*UnshareCategoryResponse, error)
 	// Creates a new user location.
 	CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*CreateLocationResponse, error)
 	// Retrieve all user locations.
@@ -38,6 +46,44 @@ type LocationServiceClient interface {
 	UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*UpdateLocationResponse, error)
 	// Delete one user location.
 	DeleteLocation(ctx context.Context, in *DeleteLocationRequest, opts ...grpc.CallOption) (*DeleteLocationResponse, error)
+	// Move many user locations to another category at once.
+	MoveLocations(ctx context.Context, in *MoveLocationsRequest, opts ...grpc.CallOption) (*MoveLocationsResponse, error)
+	// Find user locations within a radius, nearest first.
+	FindLocationsNear(ctx context.Context, in *FindLocationsNearRequest, opts ...grpc.CallOption) (*FindLocationsNearResponse, error)
+	// Find user locations having all or any of some tags.
+	FindLocationsByTags(ctx context.Context, in *FindLocationsByTagsRequest, opts ...grpc.CallOption) (*FindLocationsByTagsResponse, error)
+	// Retrieve the roles granted on one location to other users.
+	GetLocationShares(ctx context.Context, in *GetLocationSharesRequest, opts ...grpc.CallOption) (*GetLocationSharesResponse, error)
+	// Grant a role on one location to another user.
+	ShareLocation(ctx context.Context, in *ShareLocationRequest, opts ...grpc.CallOption) (*ShareLocationResponse, error)
+	// Revoke the role granted on one location to another user.
+	UnshareLocation(ctx context.Context, in *UnshareLocationRequest, opts ...grpc.CallOption) (*UnshareLocationResponse, error)
+	// Creates a new user tag.
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error)
+	// Retrieve all user tags.
+	GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error)
+	// Retrieve one specific user tag.
+	GetTag(ctx context.Context, in *GetTagRequest, opts ...grpc.CallOption) (*GetTagResponse, error)
+	// Rename one user tag.
+	UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error)
+	// Delete one user tag, removing it from all locations.
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+	// Creates a new group of users, owned by the user.
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	// Retrieve all groups the user is a member of.
+	GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error)
+	// Retrieve one specific group of the user.
+	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error)
+	// Rename one group.
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error)
+	// Delete one group, giving its locations back to the users who created them.
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	// Retrieve the members of one group.
+	GetGroupMembers(ctx context.Context, in *GetGroupMembersRequest, opts ...grpc.CallOption) (*GetGroupMembersResponse, error)
+	// Add a member to one group, or change the role of a member.
+	SetGroupMember(ctx context.Context, in *SetGroupMemberRequest, opts ...grpc.CallOption) (*SetGroupMemberResponse, error)
+	// Remove a member from one group.
+	RemoveGroupMember(ctx context.Context, in *RemoveGroupMemberRequest, opts ...grpc.CallOption) (*RemoveGroupMemberResponse, error)
 }
 
 type locationServiceClient struct {
@@ -93,6 +139,42 @@ func (c *locationServiceClient) DeleteCategory(ctx context.Context, in *DeleteCa
 	return out, nil
 }
 
+func (c *locationServiceClient) MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*MergeCategoriesResponse, error) {
+	out := new(MergeCategoriesResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/MergeCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) GetCategoryShares(ctx context.Context, in *GetCategorySharesRequest, opts ...grpc.CallOption) (*GetCategorySharesResponse, error) {
+	out := new(GetCategorySharesResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/GetCategoryShares", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) ShareCategory(ctx context.Context, in *ShareCategoryRequest, opts ...grpc.CallOption) (*ShareCategoryResponse, error) {
+	out := new(ShareCategoryResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/ShareCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) UnshareCategory(ctx context.Context, in *UnshareCategoryRequest, opts ...grpc.CallOption) (*UnshareCategoryResponse, error) {
+	out := new(UnshareCategoryResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/UnshareCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *locationServiceClient) CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*CreateLocationResponse, error) {
 	out := new(CreateLocationResponse)
 	err := c.cc.Invoke(ctx, "/location.v1.LocationService/CreateLocation", in, out, opts...)
@@ -138,6 +220,177 @@ func (c *locationServiceClient) DeleteLocation(ctx context.Context, in *DeleteLo
 	return out, nil
 }
 
+func (c *locationServiceClient) MoveLocations(ctx context.Context, in *MoveLocationsRequest, opts ...grpc.CallOption) (*MoveLocationsResponse, error) {
+	out := new(MoveLocationsResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/MoveLocations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) FindLocationsNear(ctx context.Context, in *FindLocationsNearRequest, opts ...grpc.CallOption) (*FindLocationsNearResponse, error) {
+	out := new(FindLocationsNearResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/FindLocationsNear", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) FindLocationsByTags(ctx context.Context, in *FindLocationsByTagsRequest, opts ...grpc.CallOption) (*FindLocationsByTagsResponse, error) {
+	out := new(FindLocationsByTagsResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/FindLocationsByTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) GetLocationShares(ctx context.Context, in *GetLocationSharesRequest, opts ...grpc.CallOption) (*GetLocationSharesResponse, error) {
+	out := new(GetLocationSharesResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/GetLocationShares", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) ShareLocation(ctx context.Context, in *ShareLocationRequest, opts ...grpc.CallOption) (*ShareLocationResponse, error) {
+	out := new(ShareLocationResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/ShareLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) UnshareLocation(ctx context.Context, in *UnshareLocationRequest, opts ...grpc.CallOption) (*UnshareLocationResponse, error) {
+	out := new(UnshareLocationResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/UnshareLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error) {
+	out := new(CreateTagResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/CreateTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error) {
+	out := new(GetTagsResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/GetTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) GetTag(ctx context.Context, in *GetTagRequest, opts ...grpc.CallOption) (*GetTagResponse, error) {
+	out := new(GetTagResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/GetTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error) {
+	out := new(UpdateTagResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/UpdateTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/DeleteTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/CreateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error) {
+	out := new(GetGroupsResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/GetGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error) {
+	out := new(GetGroupResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/GetGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error) {
+	out := new(UpdateGroupResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/UpdateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	out := new(DeleteGroupResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/DeleteGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) GetGroupMembers(ctx context.Context, in *GetGroupMembersRequest, opts ...grpc.CallOption) (*GetGroupMembersResponse, error) {
+	out := new(GetGroupMembersResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/GetGroupMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) SetGroupMember(ctx context.Context, in *SetGroupMemberRequest, opts ...grpc.CallOption) (*SetGroupMemberResponse, error) {
+	out := new(SetGroupMemberResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/SetGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) RemoveGroupMember(ctx context.Context, in *RemoveGroupMemberRequest, opts ...grpc.CallOption) (*RemoveGroupMemberResponse, error) {
+	out := new(RemoveGroupMemberResponse)
+	err := c.cc.Invoke(ctx, "/location.v1.LocationService/RemoveGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LocationServiceServer is the server API for LocationService service.
 // All implementations must embed UnimplementedLocationServiceServer
 // for forward compatibility
@@ -152,6 +405,14 @@ type LocationServiceServer interface {
 	UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error)
 	// Delete one category.
 	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
+	// Merge categories into another one, moving all their locations.
+	MergeCategories(context.Context, *MergeCategoriesRequest) (*MergeCategoriesResponse, error)
+	// Retrieve the roles granted on one user category to other users.
+	GetCategoryShares(context.Context, *GetCategorySharesRequest) (*GetCategorySharesResponse, error)
+	// Grant a role on all locations of one user category to another user.
+	ShareCategory(context.Context, *ShareCategoryRequest) (*ShareCategoryResponse, error)
+	// Revoke the role granted on one user category to another user.
+	UnshareCategory(context.Context, *UnshareCategoryRequest) (*UnshareCategoryResponse, error)
 	// Creates a new user location.
 	CreateLocation(context.Context, *CreateLocationRequest) (*CreateLocationResponse, error)
 	// Retrieve all user locations.
@@ -162,6 +423,44 @@ type LocationServiceServer interface {
 	UpdateLocation(context.Context, *UpdateLocationRequest) (*UpdateLocationResponse, error)
 	// Delete one user location.
 	DeleteLocation(context.Context, *DeleteLocationRequest) (*DeleteLocationResponse, error)
+	// Move many user locations to another category at once.
+	MoveLocations(context.Context, *MoveLocationsRequest) (*MoveLocationsResponse, error)
+	// Find user locations within a radius, nearest first.
+	FindLocationsNear(context.Context, *FindLocationsNearRequest) (*FindLocationsNearResponse, error)
+	// Find user locations having all or any of some tags.
+	FindLocationsByTags(context.Context, *FindLocationsByTagsRequest) (*FindLocationsByTagsResponse, error)
+	// Retrieve the roles granted on one location to other users.
+	GetLocationShares(context.Context, *GetLocationSharesRequest) (*GetLocationSharesResponse, error)
+	// Grant a role on one location to another user.
+	ShareLocation(context.Context, *ShareLocationRequest) (*ShareLocationResponse, error)
+	// Revoke the role granted on one location to another user.
+	UnshareLocation(context.Context, *UnshareLocationRequest) (*UnshareLocationResponse, error)
+	// Creates a new user tag.
+	CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error)
+	// Retrieve all user tags.
+	GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error)
+	// Retrieve one specific user tag.
+	GetTag(context.Context, *GetTagRequest) (*GetTagResponse, error)
+	// Rename one user tag.
+	UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error)
+	// Delete one user tag, removing it from all locations.
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
+	// Creates a new group of users, owned by the user.
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	// Retrieve all groups the user is a member of.
+	GetGroups(context.Context, *GetGroupsRequest) (*GetGroupsResponse, error)
+	// Retrieve one specific group of the user.
+	GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error)
+	// Rename one group.
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error)
+	// Delete one group, giving its locations back to the users who created them.
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	// Retrieve the members of one group.
+	GetGroupMembers(context.Context, *GetGroupMembersRequest) (*GetGroupMembersResponse, error)
+	// Add a member to one group, or change the role of a member.
+	SetGroupMember(context.Context, *SetGroupMemberRequest) (*SetGroupMemberResponse, error)
+	// Remove a member from one group.
+	RemoveGroupMember(context.Context, *RemoveGroupMemberRequest) (*RemoveGroupMemberResponse, error)
 	mustEmbedUnimplementedLocationServiceServer()
 }
 
@@ -184,6 +483,18 @@ func (UnimplementedLocationServiceServer) UpdateCategory(context.Context, *Updat
 func (UnimplementedLocationServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
 }
+func (UnimplementedLocationServiceServer) MergeCategories(context.Context, *MergeCategoriesRequest) (*MergeCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCategories not implemented")
+}
+func (UnimplementedLocationServiceServer) GetCategoryShares(context.Context, *GetCategorySharesRequest) (*GetCategorySharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryShares not implemented")
+}
+func (UnimplementedLocationServiceServer) ShareCategory(context.Context, *ShareCategoryRequest) (*ShareCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareCategory not implemented")
+}
+func (UnimplementedLocationServiceServer) UnshareCategory(context.Context, *UnshareCategoryRequest) (*UnshareCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareCategory not implemented")
+}
 func (UnimplementedLocationServiceServer) CreateLocation(context.Context, *CreateLocationRequest) (*CreateLocationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateLocation not implemented")
 }
@@ -199,6 +510,63 @@ func (UnimplementedLocationServiceServer) UpdateLocation(context.Context, *Updat
 func (UnimplementedLocationServiceServer) DeleteLocation(context.Context, *DeleteLocationRequest) (*DeleteLocationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLocation not implemented")
 }
+func (UnimplementedLocationServiceServer) MoveLocations(context.Context, *MoveLocationsRequest) (*MoveLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveLocations not implemented")
+}
+func (UnimplementedLocationServiceServer) FindLocationsNear(context.Context, *FindLocationsNearRequest) (*FindLocationsNearResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindLocationsNear not implemented")
+}
+func (UnimplementedLocationServiceServer) FindLocationsByTags(context.Context, *FindLocationsByTagsRequest) (*FindLocationsByTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindLocationsByTags not implemented")
+}
+func (UnimplementedLocationServiceServer) GetLocationShares(context.Context, *GetLocationSharesRequest) (*GetLocationSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLocationShares not implemented")
+}
+func (UnimplementedLocationServiceServer) ShareLocation(context.Context, *ShareLocationRequest) (*ShareLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareLocation not implemented")
+}
+func (UnimplementedLocationServiceServer) UnshareLocation(context.Context, *UnshareLocationRequest) (*UnshareLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareLocation not implemented")
+}
+func (UnimplementedLocationServiceServer) CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedLocationServiceServer) GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTags not implemented")
+}
+func (UnimplementedLocationServiceServer) GetTag(context.Context, *GetTagRequest) (*GetTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTag not implemented")
+}
+func (UnimplementedLocationServiceServer) UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTag not implemented")
+}
+func (UnimplementedLocationServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedLocationServiceServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedLocationServiceServer) GetGroups(context.Context, *GetGroupsRequest) (*GetGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroups not implemented")
+}
+func (UnimplementedLocationServiceServer) GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedLocationServiceServer) UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroup not implemented")
+}
+func (UnimplementedLocationServiceServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedLocationServiceServer) GetGroupMembers(context.Context, *GetGroupMembersRequest) (*GetGroupMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupMembers not implemented")
+}
+func (UnimplementedLocationServiceServer) SetGroupMember(context.Context, *SetGroupMemberRequest) (*SetGroupMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGroupMember not implemented")
+}
+func (UnimplementedLocationServiceServer) RemoveGroupMember(context.Context, *RemoveGroupMemberRequest) (*RemoveGroupMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupMember not implemented")
+}
 func (UnimplementedLocationServiceServer) mustEmbedUnimplementedLocationServiceServer() {}
 
 // UnsafeLocationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -302,6 +670,78 @@ func _LocationService_DeleteCategory_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LocationService_MergeCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).MergeCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/MergeCategories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).MergeCategories(ctx, req.(*MergeCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_GetCategoryShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategorySharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).GetCategoryShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/GetCategoryShares",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).GetCategoryShares(ctx, req.(*GetCategorySharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_ShareCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).ShareCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/ShareCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).ShareCategory(ctx, req.(*ShareCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_UnshareCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).UnshareCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/UnshareCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).UnshareCategory(ctx, req.(*UnshareCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LocationService_CreateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateLocationRequest)
 	if err := dec(in); err != nil {
@@ -392,6 +832,348 @@ func _LocationService_DeleteLocation_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LocationService_MoveLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).MoveLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/MoveLocations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).MoveLocations(ctx, req.(*MoveLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_FindLocationsNear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindLocationsNearRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).FindLocationsNear(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/FindLocationsNear",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).FindLocationsNear(ctx, req.(*FindLocationsNearRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_FindLocationsByTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindLocationsByTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).FindLocationsByTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/FindLocationsByTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).FindLocationsByTags(ctx, req.(*FindLocationsByTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_GetLocationShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLocationSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).GetLocationShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/GetLocationShares",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).GetLocationShares(ctx, req.(*GetLocationSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_ShareLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).ShareLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/ShareLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).ShareLocation(ctx, req.(*ShareLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_UnshareLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).UnshareLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/UnshareLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).UnshareLocation(ctx, req.(*UnshareLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/CreateTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_GetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).GetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/GetTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).GetTags(ctx, req.(*GetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_GetTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).GetTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/GetTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).GetTag(ctx, req.(*GetTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).UpdateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/UpdateTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).UpdateTag(ctx, req.(*UpdateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/DeleteTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/CreateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_GetGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).GetGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/GetGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).GetGroups(ctx, req.(*GetGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/GetGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).GetGroup(ctx, req.(*GetGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/UpdateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/DeleteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_GetGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).GetGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/GetGroupMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).GetGroupMembers(ctx, req.(*GetGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_SetGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).SetGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/SetGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).SetGroupMember(ctx, req.(*SetGroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_RemoveGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveGroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).RemoveGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/location.v1.LocationService/RemoveGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).RemoveGroupMember(ctx, req.(*RemoveGroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LocationService_ServiceDesc is the grpc.ServiceDesc for LocationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -419,6 +1201,22 @@ var LocationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCategory",
 			Handler:    _LocationService_DeleteCategory_Handler,
 		},
+		{
+			MethodName: "MergeCategories",
+			Handler:    _LocationService_MergeCategories_Handler,
+		},
+		{
+			MethodName: "GetCategoryShares",
+			Handler:    _LocationService_GetCategoryShares_Handler,
+		},
+		{
+			MethodName: "ShareCategory",
+			Handler:    _LocationService_ShareCategory_Handler,
+		},
+		{
+			MethodName: "UnshareCategory",
+			Handler:    _LocationService_UnshareCategory_Handler,
+		},
 		{
 			MethodName: "CreateLocation",
 			Handler:    _LocationService_CreateLocation_Handler,
@@ -439,6 +1237,82 @@ var LocationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteLocation",
 			Handler:    _LocationService_DeleteLocation_Handler,
 		},
+		{
+			MethodName: "MoveLocations",
+			Handler:    _LocationService_MoveLocations_Handler,
+		},
+		{
+			MethodName: "FindLocationsNear",
+			Handler:    _LocationService_FindLocationsNear_Handler,
+		},
+		{
+			MethodName: "FindLocationsByTags",
+			Handler:    _LocationService_FindLocationsByTags_Handler,
+		},
+		{
+			MethodName: "GetLocationShares",
+			Handler:    _LocationService_GetLocationShares_Handler,
+		},
+		{
+			MethodName: "ShareLocation",
+			Handler:    _LocationService_ShareLocation_Handler,
+		},
+		{
+			MethodName: "UnshareLocation",
+			Handler:    _LocationService_UnshareLocation_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _LocationService_CreateTag_Handler,
+		},
+		{
+			MethodName: "GetTags",
+			Handler:    _LocationService_GetTags_Handler,
+		},
+		{
+			MethodName: "GetTag",
+			Handler:    _LocationService_GetTag_Handler,
+		},
+		{
+			MethodName: "UpdateTag",
+			Handler:    _LocationService_UpdateTag_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _LocationService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _LocationService_CreateGroup_Handler,
+		},
+		{
+			MethodName: "GetGroups",
+			Handler:    _LocationService_GetGroups_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _LocationService_GetGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _LocationService_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _LocationService_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "GetGroupMembers",
+			Handler:    _LocationService_GetGroupMembers_Handler,
+		},
+		{
+			MethodName: "SetGroupMember",
+			Handler:    _LocationService_SetGroupMember_Handler,
+		},
+		{
+			MethodName: "RemoveGroupMember",
+			Handler:    _LocationService_RemoveGroupMember_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/grpc/v1/location.proto",
@@ -29,7 +29,7 @@ var doc = `{
     "paths": {
         "/categories": {
             "get": {
-                "description": "Get all categories.",
+                "description": "Get all user categories, along with default categories shared by all users.\nCategories are returned as trees, nested in their parent children, when requested.",
                 "produces": [
                     "application/json"
                 ],
@@ -37,14 +37,24 @@ var doc = `{
                     "categories"
                 ],
                 "summary": "Get categories",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Return categories as trees",
+                        "name": "tree",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "The returned categories",
                         "schema": {
+                            "description": "Child categories. Only returned with category trees.",
                             "type": "array",
                             "items": {
                                 "$ref": "#/definitions/models.Category"
-                            }
+                            },
+                            "x-order": "5"
                         }
                     },
                     "400": {
@@ -53,11 +63,23 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
             },
@@ -97,18 +119,36 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
             }
         },
         "/categories/{id}": {
             "get": {
-                "description": "Get one specific category using provided ID.",
+                "description": "Get one specific category using provided ID. Categories shared with the user, or holding locations visible to them, are found too.",
                 "produces": [
                     "application/json"
                 ],
@@ -138,6 +178,12 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not found",
                         "schema": {
@@ -149,11 +195,17 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
             },
             "put": {
-                "description": "Update specified category using provided values.",
+                "description": "Update specified category using provided values. Default categories are read-only, except for admins.",
                 "produces": [
                     "application/json"
                 ],
@@ -170,8 +222,8 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "description": "Category name",
-                        "name": "name",
+                        "description": "Category name and parent",
+                        "name": "category",
                         "in": "body",
                         "schema": {
                             "$ref": "#/definitions/models.UpdateCategory"
@@ -191,6 +243,12 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -202,11 +260,17 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete one specific category using provided ID.",
+                "description": "Delete one specific category using provided ID. Default categories are read-only, except for admins.\nCategories that still have locations are only deleted when their locations are reassigned or deleted along with them.\nChild categories move to top level.",
                 "tags": [
                     "categories"
                 ],
@@ -218,6 +282,18 @@ var doc = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the category to move locations to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete locations along with the category",
+                        "name": "cascade",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -230,71 +306,42 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
-                    }
-                }
-            }
-        },
-        "/locations": {
-            "get": {
-                "description": "Get all user locations.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "locations"
-                ],
-                "summary": "Get locations",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Category ID",
-                        "name": "category_id",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "The returned locations",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Location"
-                            }
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/categories/{id}/merge": {
             "post": {
-                "description": "Create new user locations.",
+                "description": "Merge source categories into specified category. Locations and child categories of sources move to it, then sources are deleted.\nEach source is merged on its own, in a single transaction. Sources that cannot be merged are reported along with the reason.",
                 "consumes": [
                     "application/json"
                 ],
@@ -302,25 +349,32 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "categories"
                 ],
-                "summary": "Create locations",
+                "summary": "Merge categories",
                 "parameters": [
                     {
-                        "description": "New location",
-                        "name": "location",
+                        "type": "string",
+                        "description": "Target category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Categories to merge",
+                        "name": "sources",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateLocation"
+                            "$ref": "#/definitions/models.MergeCategories"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The created location",
+                        "description": "Merged and failed categories",
                         "schema": {
-                            "$ref": "#/definitions/models.Location"
+                            "$ref": "#/definitions/models.BulkReport"
                         }
                     },
                     "400": {
@@ -329,6 +383,12 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -340,24 +400,30 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
             }
         },
-        "/locations/{id}": {
+        "/categories/{id}/shares": {
             "get": {
-                "description": "Get one specific location using provided ID.",
+                "description": "Get the roles granted on specified user category to other users.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "shares"
                 ],
-                "summary": "Get location with specified ID",
+                "summary": "Get category shares",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Location ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -365,9 +431,12 @@ var doc = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "The returned location",
+                        "description": "List of shares",
                         "schema": {
-                            "$ref": "#/definitions/models.Location"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Share"
+                            }
                         }
                     },
                     "400": {
@@ -376,8 +445,14 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
                     "404": {
-                        "description": "Not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
@@ -387,41 +462,59 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/categories/{id}/shares/{user_id}": {
             "put": {
-                "description": "Update specified location using provided values.",
+                "description": "Grant a role on all locations of specified user category to another user, replacing the role already granted to them if any.\nDefault categories cannot be shared.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "shares"
                 ],
-                "summary": "Update location",
+                "summary": "Share category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Location ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated location",
-                        "name": "location",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Granted role",
+                        "name": "share",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateLocation"
+                            "$ref": "#/definitions/models.GrantShare"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The updated location",
+                        "description": "The granted share",
                         "schema": {
-                            "$ref": "#/definitions/models.Location"
+                            "$ref": "#/definitions/models.Share"
                         }
                     },
                     "400": {
@@ -430,6 +523,12 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -441,22 +540,35 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete one specific location using provided ID.",
+                "description": "Revoke the role granted on specified user category to a user.",
                 "tags": [
-                    "locations"
+                    "shares"
                 ],
-                "summary": "Delete location",
+                "summary": "Unshare category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Location ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -469,6 +581,12 @@ var doc = `{
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -480,81 +598,2127 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/httpapi.HTTPError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
             }
         },
-        "/ping": {
+        "/geocode/reverse": {
             "get": {
-                "description": "Basic check of HTTP API health. Ensure that HTTP service is working correctly.",
+                "description": "Find the address at specified coordinates, like a pin dropped on a map.\nCoordinates are returned as address when none can be found or when geocoding is disabled.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "healthchecks"
+                    "geocoding"
+                ],
+                "summary": "Reverse geocode coordinates",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude in decimal degrees, like 48.8606111",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude in decimal degrees, like 2.3376",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Ping API",
                 "responses": {
                     "200": {
-                        "description": ""
+                        "description": "The place found at these coordinates",
+                        "schema": {
+                            "$ref": "#/definitions/models.Place"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "httpapi.HTTPError": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "description": "HTTP status code.",
-                    "type": "integer",
-                    "example": 400
-                },
-                "message": {
-                    "description": "String describing the error that occurred.",
-                    "type": "string",
-                    "example": "Bad Request"
-                }
-            }
-        },
-        "models.Category": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "description": "Category ID. Must be unique.",
-                    "type": "string",
-                    "x-order": "1",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "name": {
-                    "description": "Short descriptive name of the category. Like \"Homes\" or \"Tennis Center\".",
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "Homes"
-                }
-            }
         },
-        "models.CreateCategory": {
+        "/groups": {
+            "get": {
+                "description": "Get all groups the user is a member of, sorted by name, along with the role of the user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Get groups",
+                "responses": {
+                    "200": {
+                        "description": "The returned groups",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Group"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new group of users, like a household, owning locations together.\nThe user becomes the owner of the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Create group",
+                "parameters": [
+                    {
+                        "description": "New group",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The created group",
+                        "schema": {
+                            "$ref": "#/definitions/models.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}": {
+            "get": {
+                "description": "Get one specific group of the user using provided ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Get group with specified ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The returned group",
+                        "schema": {
+                            "$ref": "#/definitions/models.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename specified group. Only owners of the group may rename it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Update group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group name",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated group",
+                        "schema": {
+                            "$ref": "#/definitions/models.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete one specific group using provided ID. Only owners of the group may delete it.\nLocations of the group are given back to the users who created them.",
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Delete group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}/members": {
+            "get": {
+                "description": "Get the members of specified group of the user, along with their role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Get group members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of members",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GroupMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}/members/{user_id}": {
+            "put": {
+                "description": "Add a user to specified group, or change the role of a member.\nOwners manage the group, its members and its locations, and members may update the locations of the group.\nOnly owners of the group may manage its members, and the group must keep at least one owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Set group member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role of the member",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetGroupMember"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The group member",
+                        "schema": {
+                            "$ref": "#/definitions/models.GroupMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a member from specified group.\nOwners of the group may remove any member, and members may leave the group, as long as it keeps at least one owner.",
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Remove group member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "Get all user locations, along with locations of their groups and locations shared with them, with the role of the user.\nLocations are streamed one JSON document per line when requesting application/x-ndjson.\nStreams cut short by an error end with a line holding only an error object.\nLocations within radius meters of near point are returned nearest first, along with their distance.\nLocations may be filtered by tags, along with category only.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return locations of descendant categories, along with category_id only",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search point formatted as latitude,longitude, like 48.8606111,2.3376",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius in meters, required along with near. Up to 200000.",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Area formatted as west,south,east,north, like 2.25,48.81,2.42,48.9",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Open Location Code of the area, like 8FW4V800+. Short codes may be followed by a locality, like V86Q+62 Paris.",
+                        "name": "plus_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of a location used as reference to recover short plus code",
+                        "name": "plus_code_reference",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated list of tag names, like Favorite,Vegan",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "any"
+                        ],
+                        "type": "string",
+                        "description": "Whether locations must have all requested tags, or any of them. Defaults to all.",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated list of fields to return, like id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "category"
+                        ],
+                        "type": "string",
+                        "description": "Embed related object",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The returned locations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Location"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create new user locations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Create locations",
+                "parameters": [
+                    {
+                        "description": "New location",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The created location",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/bulk-move": {
+            "post": {
+                "description": "Move many user locations to specified category at once. Locations that cannot be found are reported as failed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Move locations",
+                "parameters": [
+                    {
+                        "description": "Locations to move",
+                        "name": "locations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MoveLocations"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Moved and failed locations",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/clusters": {
+            "get": {
+                "description": "Group user locations inside a map viewport into clusters, with their count and centroid.\nClusters are geohash cells sized for the zoom level, so that overlapping markers are merged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get clusters of locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Area formatted as west,south,east,north, like 2.25,48.81,2.42,48.9",
+                        "name": "bbox",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Map zoom level, from 0 to 22",
+                        "name": "zoom",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The returned clusters",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Cluster"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}": {
+            "get": {
+                "description": "Get one specific location using provided ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get location with specified ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated list of fields to return, like id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "category"
+                        ],
+                        "type": "string",
+                        "description": "Embed related object",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The returned location",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update specified location using provided values.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Update location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated location",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated location",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete one specific location using provided ID.",
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Delete location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/share-links": {
+            "get": {
+                "description": "Get the public links to specified location along with their token and the number of times they were used, expired ones included.\nOnly users managing the location may list them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share-links"
+                ],
+                "summary": "Get location share links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of share links",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ShareLink"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a public link to specified location, for guests who are not users, like people invited to an event.\nThe returned token gives access to the name, address and coordinates of the location through the public API, until it expires or is revoked.\nOnly users managing the location, its owner or users it is shared with as owner, may create links.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share-links"
+                ],
+                "summary": "Create location share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Validity of the link",
+                        "name": "link",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateShareLink"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The created share link",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShareLink"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/share-links/{link_id}": {
+            "delete": {
+                "description": "Revoke a public link to specified location, so that its token cannot be used anymore.",
+                "tags": [
+                    "share-links"
+                ],
+                "summary": "Revoke location share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share link ID",
+                        "name": "link_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/shares": {
+            "get": {
+                "description": "Get the roles granted on specified location to other users.\nOnly the owner of the location, and users it is shared with as owner, may list them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shares"
+                ],
+                "summary": "Get location shares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of shares",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Share"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/shares/{user_id}": {
+            "put": {
+                "description": "Grant a role on specified location to another user, replacing the role already granted to them if any.\nOwners may update, delete and share the location, editors may update it, and viewers may only read it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shares"
+                ],
+                "summary": "Share location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Granted role",
+                        "name": "share",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GrantShare"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The granted share",
+                        "schema": {
+                            "$ref": "#/definitions/models.Share"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revoke the role granted on specified location to a user.",
+                "tags": [
+                    "shares"
+                ],
+                "summary": "Unshare location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/openapi.json": {
+            "get": {
+                "description": "Get the OpenAPI 3 specification of this API, in JSON format.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docs"
+                ],
+                "summary": "Get OpenAPI specification",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/public/locations/{token}": {
+            "get": {
+                "description": "Get the name, address and coordinates of the location shared by a public link. No authentication is needed, as the token of the link gives access to the location.\nInvalid, expired and revoked tokens are all answered with 404 Not Found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Get location with share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The shared location",
+                        "schema": {
+                            "$ref": "#/definitions/models.PublicLocation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Get all user tags, sorted by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get tags",
+                "responses": {
+                    "200": {
+                        "description": "The returned tags",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tag"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create new user tags. Tags given to locations are created too when missing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create tags",
+                "parameters": [
+                    {
+                        "description": "New tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The created tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "get": {
+                "description": "Get one specific user tag using provided ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get tag with specified ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The returned tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename specified tag. Locations keep the renamed tag.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag name",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete one specific tag using provided ID. Tag is removed from all locations.",
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tiles/{z}/{x}/{y}.mvt": {
+            "get": {
+                "description": "Render user locations covered by a map tile as a Mapbox Vector Tile, in a single \"locations\" layer.\nFeatures are points with the location ID and name and the category ID and name as properties.\nTiles are revalidated using weak ETags: a matching If-None-Match header returns 304.",
+                "produces": [
+                    "application/vnd.mapbox-vector-tile"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get a vector tile of locations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Zoom level of the tile, from 0 to 22",
+                        "name": "z",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Column of the tile",
+                        "name": "x",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Row of the tile",
+                        "name": "y",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched tile",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The vector tile",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Tile not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "httpapi.HTTPError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code.",
+                    "type": "integer",
+                    "example": 400
+                },
+                "field": {
+                    "description": "Request field the error is about, if any.",
+                    "type": "string",
+                    "example": "category_id"
+                },
+                "message": {
+                    "description": "String describing the error that occurred.",
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "retryable": {
+                    "description": "Whether the same request may succeed later.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "description": "Problem type, identifying the kind of error. Set for domain errors only.",
+                    "type": "string",
+                    "example": "not-found"
+                }
+            }
+        },
+        "models.AddressComponents": {
+            "type": "object",
+            "properties": {
+                "street": {
+                    "description": "Street name, without house number.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Rue de la Poste"
+                },
+                "house_number": {
+                    "description": "House number, including suffixes like \"bis\" or \"B\".",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "1"
+                },
+                "postal_code": {
+                    "description": "Postal code, formatted as in the country it belongs to.",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "75001"
+                },
+                "locality": {
+                    "description": "City, town or village.",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Paris"
+                },
+                "region": {
+                    "description": "State, province or administrative region.",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "Île-de-France"
+                },
+                "country_code": {
+                    "description": "ISO 3166-1 alpha-2 country code.",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "FR"
+                }
+            }
+        },
+        "models.BulkFailure": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the item.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "error": {
+                    "description": "Reason of the failure.",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "location not found"
+                }
+            }
+        },
+        "models.BulkReport": {
+            "type": "object",
+            "properties": {
+                "succeeded": {
+                    "description": "IDs of the items the operation succeeded for.",
+                    "type": "array",
+                    "items": {
+                        "description": "User ID. Owner of the tag.",
+                        "type": "string",
+                        "x-order": "3",
+                        "example": "550e8400-e29b-41d4-a716-446655440000"
+                    },
+                    "x-order": "1",
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                },
+                "failed": {
+                    "description": "Items the operation failed for, along with the reason.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkFailure"
+                    },
+                    "x-order": "2"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Category ID. Must be unique.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "description": "Short descriptive name of the category. Like \"Homes\" or \"Tennis Center\".",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Homes"
+                },
+                "parent_id": {
+                    "description": "Parent category, like \"Sport\" for \"Tennis\". Empty for top level categories.",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "user_id": {
+                    "description": "Owner of the category. Empty for default categories, which are shared by all users and managed by admins.",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "children": {
+                    "description": "Child categories. Only returned with category trees.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    },
+                    "x-order": "5"
+                }
+            }
+        },
+        "models.Cluster": {
+            "type": "object",
+            "properties": {
+                "geohash": {
+                    "description": "Geohash of the area covered by the cluster.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "u09tv"
+                },
+                "count": {
+                    "description": "Number of locations in the cluster.",
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 12
+                },
+                "latitude": {
+                    "description": "Latitude of the cluster centroid in decimal degrees (WGS 84).",
+                    "type": "number",
+                    "x-order": "3",
+                    "example": 48.8606111
+                },
+                "longitude": {
+                    "description": "Longitude of the cluster centroid in decimal degrees (WGS 84).",
+                    "type": "number",
+                    "x-order": "4",
+                    "example": 2.3376
+                },
+                "location": {
+                    "description": "Only location of the cluster, set when count is 1.",
+                    "x-order": "5",
+                    "$ref": "#/definitions/models.Location"
+                }
+            }
+        },
+        "models.CreateCategory": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Short descriptive name of the category. Like \"Homes\" or \"Tennis Center\".",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Homes"
+                },
+                "parent_id": {
+                    "description": "ID of the parent category, like \"Sport\" for \"Tennis\".",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "models.CreateGroup": {
             "type": "object",
             "required": [
                 "name"
             ],
             "properties": {
                 "name": {
-                    "description": "Short descriptive name of the category. Like \"Homes\" or \"Tennis Center\".",
+                    "description": "Short descriptive name of the group, like \"Family\".",
                     "type": "string",
                     "x-order": "1",
-                    "example": "Homes"
+                    "example": "Family"
                 }
             }
         },
         "models.CreateLocation": {
             "type": "object",
             "required": [
-                "address",
                 "category_id",
-                "name"
+                "name",
+                "tags"
             ],
             "properties": {
                 "name": {
@@ -563,17 +2727,143 @@ var doc = `{
                     "x-order": "1",
                     "example": "Home"
                 },
+                "group_id": {
+                    "description": "ID of the group owning the location, which the user must be a member of. Location is owned by the user when omitted.",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
                 "address": {
-                    "description": "Full address of the location. Should contains at least street, postal code and city.",
+                    "description": "Full address of the location. Should contains at least street, postal code and city.\nMay be omitted when address components, coordinates or plus code are set, in which case it is derived from them.",
                     "type": "string",
                     "x-order": "2",
                     "example": "1 rue de la Poste, 75001 Paris"
                 },
+                "address_components": {
+                    "description": "Components of the address. Parsed from address when omitted.",
+                    "x-order": "3",
+                    "$ref": "#/definitions/models.AddressComponents"
+                },
+                "latitude": {
+                    "description": "Latitude of the location in decimal degrees (WGS 84). Must be set along with longitude.",
+                    "type": "number",
+                    "x-order": "4",
+                    "example": 48.8606111
+                },
+                "longitude": {
+                    "description": "Longitude of the location in decimal degrees (WGS 84). Must be set along with latitude.",
+                    "type": "number",
+                    "x-order": "5",
+                    "example": 2.3376
+                },
+                "plus_code": {
+                    "description": "Open Location Code of the location, like \"8FW4V86Q+62\", used instead of coordinates. Short codes,\nlike \"V86Q+62\", must be followed by a locality, like \"V86Q+62 Paris\", or come with a reference location.",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "8FW4V86Q+62"
+                },
+                "plus_code_reference": {
+                    "description": "ID of a location near the place, used to recover short plus code.",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
                 "category_id": {
                     "description": "Location category foreign key.",
                     "type": "string",
+                    "x-order": "8",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "tags": {
+                    "description": "Names of location tags. Tags that do not exist yet are created.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "9",
+                    "example": [
+                        "Favorite",
+                        "Vegan"
+                    ]
+                }
+            }
+        },
+        "models.CreateShareLink": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "Validity of the link in seconds, 7 days by default and 30 days at most.",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 86400
+                }
+            }
+        },
+        "models.CreateTag": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Name of the tag, like \"Favorite\". Commas are not allowed.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Favorite"
+                }
+            }
+        },
+        "models.GrantShare": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "Role granted to the user: \"owner\", \"editor\" or \"viewer\".",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "editor"
+                }
+            }
+        },
+        "models.Group": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Group ID. Must be unique.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "description": "Short descriptive name of the group, like \"Family\".",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Family"
+                },
+                "role": {
+                    "description": "Role of the user in the group: \"owner\" or \"member\".",
+                    "type": "string",
                     "x-order": "3",
+                    "example": "owner"
+                }
+            }
+        },
+        "models.GroupMember": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "description": "ID of the member.",
+                    "type": "string",
+                    "x-order": "1",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "role": {
+                    "description": "Role of the member: \"owner\" or \"member\".",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "member"
                 }
             }
         },
@@ -586,6 +2876,35 @@ var doc = `{
                     "x-order": "1",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
+                "user_id": {
+                    "description": "User ID. Owner of the location.",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "distance": {
+                    "description": "Distance in meters from the searched point, only set by proximity searches.",
+                    "type": "number",
+                    "x-order": "11",
+                    "example": 1250.5
+                },
+                "category": {
+                    "description": "Location category, only embedded on request.",
+                    "x-order": "12",
+                    "$ref": "#/definitions/models.Category"
+                },
+                "role": {
+                    "description": "Role of the user on the location: \"owner\" of their own locations, or the role granted on shared ones.\nOnly set when listing locations or getting a location by ID.",
+                    "type": "string",
+                    "x-order": "13",
+                    "example": "owner"
+                },
+                "group_id": {
+                    "description": "Group owning the location instead of the user who created it. Empty for user locations.",
+                    "type": "string",
+                    "x-order": "14",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
                 "name": {
                     "description": "Short descriptive name of the location, like \"Home\" or \"Work\".",
                     "type": "string",
@@ -598,16 +2917,231 @@ var doc = `{
                     "x-order": "3",
                     "example": "1 rue de la Poste, 75001 Paris"
                 },
+                "address_components": {
+                    "description": "Components of the address, parsed from it unless explicitly set.",
+                    "x-order": "4",
+                    "$ref": "#/definitions/models.AddressComponents"
+                },
+                "latitude": {
+                    "description": "Latitude of the location in decimal degrees (WGS 84). Empty until resolved.",
+                    "type": "number",
+                    "x-order": "5",
+                    "example": 48.8606111
+                },
+                "longitude": {
+                    "description": "Longitude of the location in decimal degrees (WGS 84). Empty until resolved.",
+                    "type": "number",
+                    "x-order": "6",
+                    "example": 2.3376
+                },
+                "plus_code": {
+                    "description": "Open Location Code of the location, derived from its coordinates. Empty until resolved.",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "8FW4V86Q+62"
+                },
                 "category_id": {
                     "description": "Location category foreign key.",
                     "type": "string",
-                    "x-order": "4",
+                    "x-order": "8",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "tags": {
+                    "description": "Names of location tags, sorted.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "9",
+                    "example": [
+                        "Favorite",
+                        "Vegan"
+                    ]
+                }
+            }
+        },
+        "models.MergeCategories": {
+            "type": "object",
+            "required": [
+                "sources"
+            ],
+            "properties": {
+                "sources": {
+                    "description": "IDs of the categories to merge. Their locations and child categories move to the target category, then they are deleted.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "1",
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                }
+            }
+        },
+        "models.MoveLocations": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "description": "IDs of the locations to move.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "1",
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                },
+                "category_id": {
+                    "description": "ID of the category to move locations to.",
+                    "type": "string",
+                    "x-order": "2",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "models.Place": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Full formatted address of the place.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "1 Rue de la Poste, 75001 Paris, France"
+                },
+                "latitude": {
+                    "description": "Latitude of the place in decimal degrees (WGS 84).",
+                    "type": "number",
+                    "x-order": "2",
+                    "example": 48.8606111
+                },
+                "longitude": {
+                    "description": "Longitude of the place in decimal degrees (WGS 84).",
+                    "type": "number",
+                    "x-order": "3",
+                    "example": 2.3376
+                }
+            }
+        },
+        "models.PublicLocation": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Short descriptive name of the location, like \"Home\" or \"Work\".",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Home"
+                },
+                "address": {
+                    "description": "Full address of the location.",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "1 rue de la Poste, 75001 Paris"
+                },
+                "latitude": {
+                    "description": "Latitude of the location in decimal degrees (WGS 84). Empty until resolved.",
+                    "type": "number",
+                    "x-order": "3",
+                    "example": 48.8606111
                 },
+                "longitude": {
+                    "description": "Longitude of the location in decimal degrees (WGS 84). Empty until resolved.",
+                    "type": "number",
+                    "x-order": "4",
+                    "example": 2.3376
+                }
+            }
+        },
+        "models.SetGroupMember": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "Role of the member: \"owner\" or \"member\".",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "member"
+                }
+            }
+        },
+        "models.Share": {
+            "type": "object",
+            "properties": {
                 "user_id": {
-                    "description": "User ID. Owner of the location.",
+                    "description": "ID of the user the location or the category is shared with.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "role": {
+                    "description": "Role granted to the user: \"owner\", \"editor\" or \"viewer\".",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "editor"
+                }
+            }
+        },
+        "models.ShareLink": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Share link ID. Must be unique.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "location_id": {
+                    "description": "ID of the shared location.",
                     "type": "string",
+                    "x-order": "2",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "token": {
+                    "description": "Signed token giving access to the location through the public API.",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "VQ6EAOKbQdSnFkRmVUQAAAAAAABgPNDg.2JtK0hqHbQ6Hn2r7bS0x8y3Bq8Yx6o9m0kDq3vR6fJc"
+                },
+                "expires_at": {
+                    "description": "Date after which the link cannot be used anymore.",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "2021-03-08T12:00:00Z"
+                },
+                "accesses": {
+                    "description": "Number of times the location has been accessed through the link.",
+                    "type": "integer",
                     "x-order": "5",
+                    "example": 3
+                }
+            }
+        },
+        "models.Tag": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Tag ID. Must be unique.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "description": "Name of the tag, unique for each user.",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Favorite"
+                },
+                "user_id": {
+                    "description": "User ID. Owner of the tag.",
+                    "type": "string",
+                    "x-order": "3",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 }
             }
@@ -620,11 +3154,34 @@ var doc = `{
                     "type": "string",
                     "x-order": "1",
                     "example": "Homes"
+                },
+                "parent_id": {
+                    "description": "ID of the parent category. Category is moved to top level when empty.",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "models.UpdateGroup": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "New name of the group.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Family"
                 }
             }
         },
         "models.UpdateLocation": {
             "type": "object",
+            "required": [
+                "tags"
+            ],
             "properties": {
                 "name": {
                     "description": "Short descriptive name of the location, like \"Home\" or \"Work\".",
@@ -638,11 +3195,54 @@ var doc = `{
                     "x-order": "2",
                     "example": "1 rue de la Poste, 75001 Paris"
                 },
+                "address_components": {
+                    "description": "Components of the address. Parsed from address when omitted while address changes.",
+                    "x-order": "3",
+                    "$ref": "#/definitions/models.AddressComponents"
+                },
+                "latitude": {
+                    "description": "Latitude of the location in decimal degrees (WGS 84). Must be set along with longitude.",
+                    "type": "number",
+                    "x-order": "4",
+                    "example": 48.8606111
+                },
+                "longitude": {
+                    "description": "Longitude of the location in decimal degrees (WGS 84). Must be set along with latitude.",
+                    "type": "number",
+                    "x-order": "5",
+                    "example": 2.3376
+                },
                 "category_id": {
                     "description": "Location category foreign key.",
                     "type": "string",
-                    "x-order": "3",
+                    "x-order": "6",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "tags": {
+                    "description": "Names of location tags, replacing current ones. Tags are left unchanged when omitted, and removed when empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "7",
+                    "example": [
+                        "Favorite",
+                        "Vegan"
+                    ]
+                }
+            }
+        },
+        "models.UpdateTag": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "New name of the tag. Commas are not allowed.",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Favorite"
                 }
             }
         }
@@ -0,0 +1,14 @@
+package v1
+
+import "embed"
+
+// OpenAPISpec is the OpenAPI 3 specification of the HTTP API, in JSON format.
+// It is generated by scripts/openapi from the Swagger 2.0 spec built by swag.
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
+
+// SwaggerUI holds the swagger-ui-dist assets used by the interactive documentation page.
+//
+//go:embed swagger-ui/*.css swagger-ui/*.js
+var SwaggerUI embed.FS
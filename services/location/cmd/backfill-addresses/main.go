@@ -0,0 +1,100 @@
+// Command backfill-addresses sets address components of locations created before they were added,
+// by parsing their address the same way the service does. It is run once, after migration 6.
+// Locations already having components are left untouched, so it may safely be run again.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/edebernis/social-life-manager/services/location/cmd/location/config"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	sqlrepo "github.com/edebernis/social-life-manager/services/location/internal/repositories/sql"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// Number of locations read at once
+const batchSize = 500
+
+var (
+	logger = logrus.WithField("package", "main")
+)
+
+func setupSQLRepository() (*sqlrepo.SQLRepository, error) {
+	repo := sqlrepo.NewSQLRepository(&sqlrepo.Config{
+		Host:            config.Config.SQL.Host,
+		Port:            config.Config.SQL.Port,
+		User:            config.Config.SQL.User,
+		Password:        config.Config.SQL.Password,
+		DBName:          config.Config.SQL.DB,
+		ConnMaxIdleTime: config.Config.SQL.ConnMaxIdleTime,
+		ConnMaxLifetime: config.Config.SQL.ConnMaxLifeTime,
+		MaxIdleConns:    config.Config.SQL.MaxIdleConns,
+		MaxOpenConns:    config.Config.SQL.MaxOpenConns,
+		QueryTimeout:    config.Config.SQL.QueryTimeout,
+	}, prometheus.NewRegistry())
+
+	if err := repo.Open(); err != nil {
+		return nil, fmt.Errorf("Failed to open SQL repository. %w", err)
+	}
+
+	if err := repo.Ping(context.Background()); err != nil {
+		return nil, fmt.Errorf("Failed to ping SQL repository. %w", err)
+	}
+
+	return repo, nil
+}
+
+// backfill parses addresses of locations without address components, batch by batch in ID order,
+// and returns how many locations were updated
+func backfill(ctx context.Context, repo *sqlrepo.SQLRepository) (int, error) {
+	count := 0
+	after := models.NilID
+	for {
+		locations, err := repo.FindLocationsWithoutAddressComponents(ctx, after, batchSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, loc := range *locations {
+			loc.Components = usecases.ParseAddressComponents(loc.Address)
+			if loc.Components.IsZero() {
+				continue
+			}
+			if err := repo.UpdateAddressComponents(ctx, loc); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(*locations) < batchSize {
+			return count, nil
+		}
+		after = (*locations)[len(*locations)-1].ID
+	}
+}
+
+func main() {
+	logrus.SetFormatter(&logrus.JSONFormatter{})
+	logrus.SetOutput(os.Stdout)
+
+	if err := config.LoadConfig(); err != nil {
+		logger.Fatalf("Failed to load configuration. %v", err)
+	}
+
+	repo, err := setupSQLRepository()
+	if err != nil {
+		logger.Fatalf("Failed to setup SQL repository. %v", err)
+	}
+	defer repo.Close()
+
+	count, err := backfill(context.Background(), repo)
+	if err != nil {
+		logger.Fatalf("Failed to backfill address components after %d locations. %v", count, err)
+	}
+	logger.Infof("Address components set on %d locations", count)
+}
@@ -20,6 +20,13 @@ type appConfig struct {
 			ReadHeaderTimeout time.Duration
 			ReadTimeout       time.Duration
 			WriteTimeout      time.Duration
+			CORS              struct {
+				AllowedOrigins   []string
+				AllowedMethods   []string
+				AllowedHeaders   []string
+				AllowCredentials bool
+				MaxAge           time.Duration
+			}
 		}
 		GRPC struct {
 			BindAddr          string
@@ -30,6 +37,33 @@ type appConfig struct {
 			Algorithm string
 			Secret    string
 		}
+		ShareLinks struct {
+			// Secret signing share link tokens. Required, and distinct from the JWT secret.
+			Secret string
+		}
+		Limiter struct {
+			Enabled          bool
+			InitialLimit     int
+			MinLimit         int
+			MaxLimit         int
+			LatencyThreshold time.Duration
+			BackoffRatio     float64
+			WriteRatio       float64
+		}
+	}
+	Geocoding struct {
+		// Geocoding provider, either "none" or "nominatim"
+		Provider          string
+		RequestsPerSecond float64
+		CacheSize         int
+		CacheTTL          time.Duration
+		Nominatim         struct {
+			BaseURL   string
+			UserAgent string
+			Email     string
+			Language  string
+			Timeout   time.Duration
+		}
 	}
 	Metrics struct {
 		BindAddr string
@@ -76,6 +110,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("api.http.ReadHeaderTimeout", 20*time.Second)
 	v.SetDefault("api.http.ReadTimeout", 1*time.Minute)
 	v.SetDefault("api.http.WriteTimeout", 2*time.Minute)
+	v.SetDefault("api.http.cors.allowedOrigins", []string{})
+	v.SetDefault("api.http.cors.allowedMethods", []string{"GET", "POST", "PUT", "DELETE"})
+	v.SetDefault("api.http.cors.allowedHeaders", []string{"Authorization", "Content-Type"})
+	v.SetDefault("api.http.cors.allowCredentials", false)
+	v.SetDefault("api.http.cors.maxAge", 12*time.Hour)
 
 	v.SetDefault("api.grpc.BindAddr", ":9090")
 	v.SetDefault("api.grpc.ConnectionTimeout", 2*time.Minute)
@@ -84,6 +123,26 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("api.jwt.algorithm", "HS256")
 	v.SetDefault("api.jwt.secret", "")
 
+	v.SetDefault("api.shareLinks.secret", "")
+
+	v.SetDefault("api.limiter.enabled", true)
+	v.SetDefault("api.limiter.initialLimit", 20)
+	v.SetDefault("api.limiter.minLimit", 5)
+	v.SetDefault("api.limiter.maxLimit", 200)
+	v.SetDefault("api.limiter.latencyThreshold", 1*time.Second)
+	v.SetDefault("api.limiter.backoffRatio", 0.9)
+	v.SetDefault("api.limiter.writeRatio", 0.8)
+
+	v.SetDefault("geocoding.provider", "none")
+	v.SetDefault("geocoding.requestsPerSecond", 1)
+	v.SetDefault("geocoding.cacheSize", 10000)
+	v.SetDefault("geocoding.cacheTTL", 24*time.Hour)
+	v.SetDefault("geocoding.nominatim.baseURL", "https://nominatim.openstreetmap.org")
+	v.SetDefault("geocoding.nominatim.userAgent", "social-life-manager-location")
+	v.SetDefault("geocoding.nominatim.email", "")
+	v.SetDefault("geocoding.nominatim.language", "")
+	v.SetDefault("geocoding.nominatim.timeout", 5*time.Second)
+
 	v.SetDefault("metrics.bindAddr", ":2112")
 	v.SetDefault("metrics.path", "/metrics")
 
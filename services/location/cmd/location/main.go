@@ -14,7 +14,9 @@ import (
 	"github.com/edebernis/social-life-manager/services/location/internal/api"
 	grpcapi "github.com/edebernis/social-life-manager/services/location/internal/api/grpc/v1"
 	httpapi "github.com/edebernis/social-life-manager/services/location/internal/api/http/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/geocoding"
 	sqlrepo "github.com/edebernis/social-life-manager/services/location/internal/repositories/sql"
+	"github.com/edebernis/social-life-manager/services/location/internal/sharelinks"
 	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
 	_ "github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus"
@@ -62,17 +64,87 @@ func setupSQLRepository(registry *prometheus.Registry) (*sqlrepo.SQLRepository,
 	return repo, nil
 }
 
-func setupHTTPAPI(api *api.API, registry *prometheus.Registry) *httpapi.HTTPServer {
+func setupGeocoder(registry *prometheus.Registry) (usecases.Geocoder, error) {
+	var provider geocoding.Geocoder
+	switch config.Config.Geocoding.Provider {
+	case "", "none":
+		return geocoding.NewNoopGeocoder(), nil
+	case "nominatim":
+		provider = geocoding.NewNominatimGeocoder(&geocoding.NominatimConfig{
+			BaseURL:   config.Config.Geocoding.Nominatim.BaseURL,
+			UserAgent: config.Config.Geocoding.Nominatim.UserAgent,
+			Email:     config.Config.Geocoding.Nominatim.Email,
+			Language:  config.Config.Geocoding.Nominatim.Language,
+			Timeout:   config.Config.Geocoding.Nominatim.Timeout,
+		}, registry)
+	default:
+		return nil, fmt.Errorf("Unknown geocoding provider : %s", config.Config.Geocoding.Provider)
+	}
+
+	// Addresses that cannot be geocoded in time are resolved later, so requests do not queue for longer than a lookup
+	provider = geocoding.NewRateLimitedGeocoder(provider, config.Config.Geocoding.RequestsPerSecond, config.Config.Geocoding.Nominatim.Timeout)
+	return geocoding.NewCachedGeocoder(
+		provider,
+		config.Config.Geocoding.CacheSize,
+		config.Config.Geocoding.CacheTTL,
+		registry,
+	), nil
+}
+
+func setupShareLinkSigner() (usecases.ShareLinkSigner, error) {
+	secret := config.Config.API.ShareLinks.Secret
+	if secret == "" {
+		return nil, errors.New("Share link secret is not set")
+	}
+	// Tokens signed with the JWT secret would be accepted as JWTs, and the other way around
+	if secret == config.Config.API.JWT.Secret {
+		return nil, errors.New("Share link secret must differ from the JWT secret")
+	}
+
+	return sharelinks.NewSigner(secret), nil
+}
+
+func setupLocationUsecase(repo usecases.LocationRepository, geocoder usecases.Geocoder, signer usecases.ShareLinkSigner, registry *prometheus.Registry) api.ILocationUsecase {
+	usecase := usecases.NewLocationUsecase(repo, geocoder, signer)
+	if !config.Config.API.Limiter.Enabled {
+		return usecase
+	}
+
+	limiter := api.NewAdaptiveLimiter(&api.LimiterConfig{
+		InitialLimit:     config.Config.API.Limiter.InitialLimit,
+		MinLimit:         config.Config.API.Limiter.MinLimit,
+		MaxLimit:         config.Config.API.Limiter.MaxLimit,
+		LatencyThreshold: config.Config.API.Limiter.LatencyThreshold,
+		BackoffRatio:     config.Config.API.Limiter.BackoffRatio,
+		WriteRatio:       config.Config.API.Limiter.WriteRatio,
+	}, registry)
+
+	return api.NewLimitedLocationUsecase(usecase, limiter)
+}
+
+func setupHTTPAPI(api *api.API, registry *prometheus.Registry) (*httpapi.HTTPServer, error) {
 	auth := httpapi.NewJWTAuthenticator(
 		config.Config.API.JWT.Algorithm,
 		config.Config.API.JWT.Secret,
 	)
 
+	cors := httpapi.CORSConfig{
+		AllowedOrigins:   config.Config.API.HTTP.CORS.AllowedOrigins,
+		AllowedMethods:   config.Config.API.HTTP.CORS.AllowedMethods,
+		AllowedHeaders:   config.Config.API.HTTP.CORS.AllowedHeaders,
+		AllowCredentials: config.Config.API.HTTP.CORS.AllowCredentials,
+		MaxAge:           config.Config.API.HTTP.CORS.MaxAge,
+	}
+	if err := cors.Validate(); err != nil {
+		return nil, fmt.Errorf("Invalid CORS configuration. %w", err)
+	}
+
 	return httpapi.NewHTTPServer(api, auth, registry, &httpapi.Config{
 		ReadHeaderTimeout: config.Config.API.HTTP.ReadHeaderTimeout,
 		ReadTimeout:       config.Config.API.HTTP.ReadTimeout,
 		WriteTimeout:      config.Config.API.HTTP.WriteTimeout,
-	})
+		CORS:              cors,
+	}), nil
 }
 
 func setupGRPCAPI(api *api.API, registry *prometheus.Registry) *grpcapi.GRPCServer {
@@ -100,10 +172,26 @@ func setup() (*sqlrepo.SQLRepository, *httpapi.HTTPServer, *grpcapi.GRPCServer,
 		return nil, nil, nil, nil, fmt.Errorf("Failed to setup SQL repository. %w", err)
 	}
 
-	usecase := usecases.NewLocationUsecase(repo)
+	geocoder, err := setupGeocoder(metricsServer.Registry)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("Failed to setup geocoder. %w", err)
+	}
+
+	signer, err := setupShareLinkSigner()
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("Failed to setup share link signer. %w", err)
+	}
+
+	usecase := setupLocationUsecase(repo, geocoder, signer, metricsServer.Registry)
 	api := api.NewAPI(usecase)
 
-	httpServer := setupHTTPAPI(api, metricsServer.Registry)
+	httpServer, err := setupHTTPAPI(api, metricsServer.Registry)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("Failed to setup HTTP API. %w", err)
+	}
+	if err := httpServer.CheckDocumentation(); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("Failed to check HTTP API documentation. %w", err)
+	}
 	grpcServer := setupGRPCAPI(api, metricsServer.Registry)
 
 	return repo, httpServer, grpcServer, metricsServer, nil
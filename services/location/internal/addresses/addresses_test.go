@@ -0,0 +1,105 @@
+package addresses
+
+import (
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for address, expected := range map[string]models.AddressComponents{
+		"1 rue de la Poste, 75001 Paris": {
+			Street: "rue de la Poste", HouseNumber: "1", PostalCode: "75001", Locality: "Paris",
+		},
+		"12 bis Rue de la Paix, 75002 Paris, Île-de-France, France": {
+			Street: "Rue de la Paix", HouseNumber: "12 bis", PostalCode: "75002", Locality: "Paris", Region: "Île-de-France", CountryCode: "FR",
+		},
+		"1, Rue de la Poste, 75001 Paris, France": {
+			Street: "Rue de la Poste", HouseNumber: "1", PostalCode: "75001", Locality: "Paris", CountryCode: "FR",
+		},
+		"Tour Eiffel, 5 Avenue Anatole France, 75007 Paris": {
+			Street: "Avenue Anatole France", HouseNumber: "5", PostalCode: "75007", Locality: "Paris",
+		},
+		"Hauptstraße 5, 10115 Berlin, Deutschland": {
+			Street: "Hauptstraße", HouseNumber: "5", PostalCode: "10115", Locality: "Berlin", CountryCode: "DE",
+		},
+		"Damrak 1, 1012 LG Amsterdam": {
+			Street: "Damrak", HouseNumber: "1", PostalCode: "1012 LG", Locality: "Amsterdam", CountryCode: "NL",
+		},
+		"Avenida da Liberdade 10, 1250-096 Lisboa": {
+			Street: "Avenida da Liberdade", HouseNumber: "10", PostalCode: "1250-096", Locality: "Lisboa", CountryCode: "PT",
+		},
+		"1600 Pennsylvania Avenue NW, Washington, DC 20500": {
+			Street: "Pennsylvania Avenue NW", HouseNumber: "1600", PostalCode: "20500", Locality: "Washington", Region: "DC", CountryCode: "US",
+		},
+		"290 Bremner Blvd, Toronto, ON M5V 3L9, Canada": {
+			Street: "Bremner Blvd", HouseNumber: "290", PostalCode: "M5V 3L9", Locality: "Toronto", Region: "ON", CountryCode: "CA",
+		},
+		"10 Downing Street, London, SW1A 2AA": {
+			Street: "Downing Street", HouseNumber: "10", PostalCode: "SW1A 2AA", Locality: "London", CountryCode: "GB",
+		},
+		"221B Baker Street, London NW1 6XE, UK": {
+			Street: "Baker Street", HouseNumber: "221B", PostalCode: "NW1 6XE", Locality: "London", CountryCode: "GB",
+		},
+		"Rue de Rivoli, Paris, Île-de-France": {
+			Street: "Rue de Rivoli", Locality: "Paris", Region: "Île-de-France",
+		},
+		"1 rue de la Poste": {
+			Street: "rue de la Poste", HouseNumber: "1",
+		},
+		"75001 Paris": {
+			PostalCode: "75001", Locality: "Paris",
+		},
+		"Paris": {
+			Locality: "Paris",
+		},
+		"France": {
+			CountryCode: "FR",
+		},
+		"48.8606111, 2.3376": {},
+		" , ":                {},
+	} {
+		assert.Equal(t, expected, Parse(address), address)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for expected, components := range map[string]models.AddressComponents{
+		"1 Rue de la Poste, 75001 Paris, France": {
+			Street: "Rue de la Poste", HouseNumber: "1", PostalCode: "75001", Locality: "Paris", CountryCode: "FR",
+		},
+		"Hauptstraße 5, 10115 Berlin, Germany": {
+			Street: "Hauptstraße", HouseNumber: "5", PostalCode: "10115", Locality: "Berlin", CountryCode: "DE",
+		},
+		"1600 Pennsylvania Avenue NW, Washington, DC 20500, United States": {
+			Street: "Pennsylvania Avenue NW", HouseNumber: "1600", PostalCode: "20500", Locality: "Washington", Region: "DC", CountryCode: "US",
+		},
+		"10 Downing Street, London, SW1A 2AA, United Kingdom": {
+			Street: "Downing Street", HouseNumber: "10", PostalCode: "SW1A 2AA", Locality: "London", CountryCode: "GB",
+		},
+		"32-16 Carrera 7, Bogotá, CO": {
+			Street: "Carrera 7", HouseNumber: "32-16", Locality: "Bogotá", CountryCode: "CO",
+		},
+		"1 rue de la Poste, 75001 Paris": {
+			Street: "rue de la Poste", HouseNumber: "1", PostalCode: "75001", Locality: "Paris",
+		},
+		"Paris": {
+			Locality: "Paris",
+		},
+		"": {},
+	} {
+		assert.Equal(t, expected, Format(components))
+	}
+}
+
+func TestFormatThenParse(t *testing.T) {
+	for _, components := range []models.AddressComponents{
+		{Street: "Rue de la Paix", HouseNumber: "12", PostalCode: "75002", Locality: "Paris", Region: "Île-de-France", CountryCode: "FR"},
+		{Street: "Hauptstraße", HouseNumber: "5", PostalCode: "10115", Locality: "Berlin", CountryCode: "DE"},
+		{Street: "Pennsylvania Avenue NW", HouseNumber: "1600", PostalCode: "20500", Locality: "Washington", Region: "DC", CountryCode: "US"},
+		{Street: "Downing Street", HouseNumber: "10", PostalCode: "SW1A 2AA", Locality: "London", CountryCode: "GB"},
+	} {
+		assert.Equal(t, components, Parse(Format(components)))
+	}
+}
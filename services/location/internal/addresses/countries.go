@@ -0,0 +1,62 @@
+package addresses
+
+import "strings"
+
+// style tells how a country lays out address components
+type style int
+
+const (
+	// "1 Rue de la Poste, 75001 Paris"
+	styleNumberFirst style = iota
+	// "Hauptstraße 5, 10115 Berlin"
+	styleNumberLast
+	// "1600 Pennsylvania Avenue NW, Washington, DC 20500"
+	styleNorthAmerican
+	// "10 Downing Street, London, SW1A 2AA"
+	styleBritish
+)
+
+type country struct {
+	name    string
+	style   style
+	aliases []string
+}
+
+// Countries whose address layout is known. Others are formatted with styleNumberFirst.
+var countries = map[string]country{
+	"AT": {"Austria", styleNumberLast, []string{"österreich", "autriche"}},
+	"AU": {"Australia", styleNorthAmerican, []string{"australie"}},
+	"BE": {"Belgium", styleNumberLast, []string{"belgique", "belgië", "belgien"}},
+	"CA": {"Canada", styleNorthAmerican, nil},
+	"CH": {"Switzerland", styleNumberLast, []string{"suisse", "schweiz", "svizzera"}},
+	"DE": {"Germany", styleNumberLast, []string{"deutschland", "allemagne"}},
+	"DK": {"Denmark", styleNumberLast, []string{"danmark", "danemark"}},
+	"ES": {"Spain", styleNumberLast, []string{"españa", "espana", "espagne"}},
+	"FR": {"France", styleNumberFirst, []string{"france métropolitaine"}},
+	"GB": {"United Kingdom", styleBritish, []string{"uk", "great britain", "england", "scotland", "wales", "royaume-uni"}},
+	"IE": {"Ireland", styleBritish, []string{"éire", "irlande"}},
+	"IT": {"Italy", styleNumberLast, []string{"italia", "italie"}},
+	"LU": {"Luxembourg", styleNumberFirst, nil},
+	"NL": {"Netherlands", styleNumberLast, []string{"nederland", "the netherlands", "pays-bas"}},
+	"PT": {"Portugal", styleNumberLast, nil},
+	"SE": {"Sweden", styleNumberLast, []string{"sverige", "suède"}},
+	"US": {"United States", styleNorthAmerican, []string{"usa", "united states of america", "états-unis"}},
+}
+
+// countryByName indexes country codes by lower case names, aliases and codes
+var countryByName = func() map[string]string {
+	index := make(map[string]string)
+	for code, c := range countries {
+		index[strings.ToLower(code)] = code
+		index[strings.ToLower(c.name)] = code
+		for _, alias := range c.aliases {
+			index[alias] = code
+		}
+	}
+	return index
+}()
+
+// lookupCountry returns the ISO 3166-1 alpha-2 code of the country named name, or an empty string
+func lookupCountry(name string) string {
+	return countryByName[strings.ToLower(strings.TrimSpace(name))]
+}
@@ -0,0 +1,47 @@
+package addresses
+
+import (
+	"strings"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// Format renders address components as a single line, laid out following the
+// conventions of their country, like "1 Rue de la Poste, 75001 Paris, France".
+// Empty components are skipped.
+func Format(c models.AddressComponents) string {
+	var parts []string
+
+	country, known := countries[c.CountryCode]
+	switch country.style {
+	case styleNumberLast:
+		parts = append(parts, join(" ", c.Street, c.HouseNumber), join(" ", c.PostalCode, c.Locality), c.Region)
+	case styleNorthAmerican:
+		parts = append(parts, join(" ", c.HouseNumber, c.Street), c.Locality, join(" ", c.Region, c.PostalCode))
+	case styleBritish:
+		parts = append(parts, join(" ", c.HouseNumber, c.Street), c.Locality, c.Region, c.PostalCode)
+	default:
+		parts = append(parts, join(" ", c.HouseNumber, c.Street), join(" ", c.PostalCode, c.Locality), c.Region)
+	}
+
+	switch {
+	case known:
+		parts = append(parts, country.name)
+	case c.CountryCode != "":
+		parts = append(parts, c.CountryCode)
+	}
+
+	return join(", ", parts...)
+}
+
+// join concatenates non empty elements with sep
+func join(sep string, elems ...string) string {
+	nonEmpty := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		if elem != "" {
+			nonEmpty = append(nonEmpty, elem)
+		}
+	}
+
+	return strings.Join(nonEmpty, sep)
+}
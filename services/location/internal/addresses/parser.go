@@ -0,0 +1,170 @@
+// Package addresses splits free text addresses into their components and formats them back
+// following the conventions of their country.
+package addresses
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// postalPattern matches an address part holding a postal code. Named groups
+// "postal", "locality" and "region" are copied to address components.
+type postalPattern struct {
+	re *regexp.Regexp
+	// Country implied by the postal code format, if unambiguous
+	country string
+}
+
+// Patterns are tried in order, most specific first
+var postalPatterns = []postalPattern{
+	{regexp.MustCompile(`^(?P<postal>\d{4}\s?[A-Z]{2})\s+(?P<locality>\D.*)$`), "NL"},
+	{regexp.MustCompile(`^(?P<postal>\d{4}-\d{3})\s+(?P<locality>\D.*)$`), "PT"},
+	{regexp.MustCompile(`^(?P<postal>\d{4,5})\s+(?P<locality>\D.*)$`), ""},
+	{regexp.MustCompile(`^(?P<region>[A-Z]{2})\s+(?P<postal>\d{5}(?:-\d{4})?)$`), "US"},
+	{regexp.MustCompile(`^(?P<region>[A-Z]{2})\s+(?P<postal>[A-Z]\d[A-Z]\s?\d[A-Z]\d)$`), "CA"},
+	{regexp.MustCompile(`^(?P<region>[A-Z]{2,3})\s+(?P<postal>\d{4})$`), "AU"},
+	{regexp.MustCompile(`^(?:(?P<locality>\D+?)\s+)?(?P<postal>[A-Z]{1,2}\d[A-Z\d]?\s\d[A-Z]{2})$`), "GB"},
+}
+
+var (
+	// "1 Rue de la Poste", "12 bis Rue de la Paix", "221B Baker Street"
+	leadingNumberRegexp = regexp.MustCompile(`^(\d+(?:[-/]\d+)?[A-Za-z]?(?:\s+(?:bis|ter|quater))?)\s*,?\s+(\D.*)$`)
+	// "Hauptstraße 5", "Via Roma 1a"
+	trailingNumberRegexp = regexp.MustCompile(`^(\D.*?)\s+(\d+(?:[-/]\d+)?[A-Za-z]?)$`)
+	// "1", as a part of its own
+	numberRegexp = regexp.MustCompile(`^\d+(?:[-/]\d+)?[A-Za-z]?$`)
+)
+
+// Parse splits a free text address into its components using rules matching
+// common Western address layouts. Components which cannot be recognized are left empty.
+func Parse(address string) models.AddressComponents {
+	var c models.AddressComponents
+
+	// Addresses made of coordinates, when no actual address is known, have no component
+	if _, err := models.ParsePoint(address); err == nil {
+		return c
+	}
+
+	parts := splitParts(address)
+	if len(parts) > 0 {
+		if code := lookupCountry(parts[len(parts)-1]); code != "" {
+			c.CountryCode = code
+			parts = parts[:len(parts)-1]
+		}
+	}
+	if len(parts) == 0 {
+		return c
+	}
+
+	postalIndex := findPostalCode(&c, parts)
+	if postalIndex < 0 {
+		// Without postal code, parts are expected as street, locality then region
+		if len(parts) > 1 || hasHouseNumber(parts[0]) {
+			parts = parseStreet(&c, parts)
+		}
+		if len(parts) > 0 {
+			c.Locality = parts[0]
+		}
+		if len(parts) > 1 {
+			c.Region = parts[1]
+		}
+		return c
+	}
+
+	// Parts left of the postal code hold the street then the locality, if not found yet.
+	// Parts right of it hold the region.
+	head, tail := parts[:postalIndex], parts[postalIndex+1:]
+	if len(head) > 0 {
+		head = parseStreet(&c, head)
+	}
+	if c.Locality == "" && len(head) > 0 {
+		c.Locality = head[len(head)-1]
+	}
+	if c.Region == "" && len(tail) > 0 {
+		c.Region = tail[0]
+	}
+
+	return c
+}
+
+// findPostalCode sets postal code and the components next to it from the last address part
+// holding a postal code, and returns its index or -1. The first part is only searched when
+// it is the only one, as it otherwise holds the street, whose house number may look like a postal code.
+func findPostalCode(c *models.AddressComponents, parts []string) int {
+	for i := len(parts) - 1; i >= 0; i-- {
+		if i == 0 && len(parts) > 1 {
+			break
+		}
+		for _, pattern := range postalPatterns {
+			match := pattern.re.FindStringSubmatch(parts[i])
+			if match == nil {
+				continue
+			}
+			for j, name := range pattern.re.SubexpNames() {
+				switch name {
+				case "postal":
+					c.PostalCode = match[j]
+				case "locality":
+					c.Locality = strings.TrimSpace(match[j])
+				case "region":
+					c.Region = match[j]
+				}
+			}
+			if c.CountryCode == "" {
+				c.CountryCode = pattern.country
+			}
+			return i
+		}
+	}
+
+	return -1
+}
+
+// parseStreet sets street and house number from the first parts of an address
+// and returns the remaining parts. Leading parts without house number, like
+// building names, are skipped when a later part has one.
+func parseStreet(c *models.AddressComponents, parts []string) []string {
+	for i, part := range parts {
+		if numberRegexp.MatchString(part) || hasHouseNumber(part) {
+			parts = parts[i:]
+			break
+		}
+	}
+
+	if numberRegexp.MatchString(parts[0]) && len(parts) > 1 {
+		c.HouseNumber, c.Street = parts[0], parts[1]
+		return parts[2:]
+	}
+
+	if match := leadingNumberRegexp.FindStringSubmatch(parts[0]); match != nil {
+		c.HouseNumber, c.Street = match[1], match[2]
+	} else if match := trailingNumberRegexp.FindStringSubmatch(parts[0]); match != nil {
+		c.Street, c.HouseNumber = match[1], match[2]
+	} else {
+		c.Street = parts[0]
+	}
+
+	return parts[1:]
+}
+
+func hasHouseNumber(part string) bool {
+	return leadingNumberRegexp.MatchString(part) || trailingNumberRegexp.MatchString(part)
+}
+
+// splitParts splits an address on commas and line breaks, dropping empty parts
+func splitParts(address string) []string {
+	fields := strings.FieldsFunc(address, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ';'
+	})
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.Join(strings.Fields(field), " "); field != "" {
+			parts = append(parts, field)
+		}
+	}
+
+	return parts
+}
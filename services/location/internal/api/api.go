@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/pluscodes"
+	"github.com/edebernis/social-life-manager/services/location/internal/tiles"
 )
 
 // ILocationUsecase describes functions available in location usecase
@@ -12,14 +14,53 @@ type ILocationUsecase interface {
 	GetCategories(context.Context) (*models.Categories, error)
 	FindCategoryByID(context.Context, models.ID) (*models.Category, error)
 	UpdateCategory(context.Context, *models.Category) error
-	DeleteCategory(context.Context, models.ID) error
+	DeleteCategory(ctx context.Context, id models.ID, deletion models.CategoryDeletion) error
+	MergeCategories(ctx context.Context, targetID models.ID, sourceIDs []models.ID) (*models.BulkReport, error)
+	GetCategoryShares(context.Context, models.ID) (*models.Shares, error)
+	ShareCategory(ctx context.Context, id models.ID, share *models.Share) error
+	UnshareCategory(ctx context.Context, id models.ID, user models.ID) error
 
 	CreateLocation(context.Context, *models.Location) error
 	GetLocations(context.Context) (*models.Locations, error)
 	FindLocationByID(context.Context, models.ID) (*models.Location, error)
-	FindLocationsByCategory(context.Context, models.ID) (*models.Locations, error)
+	FindLocationsByCategory(ctx context.Context, catID models.ID, descendants bool) (*models.Locations, error)
+	GetExpandedLocations(context.Context, models.ID) (*models.Locations, error)
+	FindExpandedLocationByID(context.Context, models.ID) (*models.Location, error)
+	StreamLocations(context.Context, models.ID, func(*models.Location) error) error
+	FindLocationsNear(ctx context.Context, catID models.ID, center models.Point, radius float64) (*models.Locations, error)
+	FindLocationsInBoundingBox(context.Context, models.ID, models.BoundingBox) (*models.Locations, error)
+	GetLocationClusters(ctx context.Context, catID models.ID, bbox models.BoundingBox, zoom int) (*models.Clusters, error)
+	GetLocationTile(context.Context, tiles.Tile) ([]byte, error)
+	ResolvePlusCode(context.Context, string, models.ID) (*pluscodes.CodeArea, error)
 	UpdateLocation(context.Context, *models.Location) error
 	DeleteLocation(context.Context, models.ID) error
+	MoveLocations(ctx context.Context, ids []models.ID, catID models.ID) (*models.BulkReport, error)
+	GetLocationShares(context.Context, models.ID) (*models.Shares, error)
+	ShareLocation(ctx context.Context, id models.ID, share *models.Share) error
+	UnshareLocation(ctx context.Context, id models.ID, user models.ID) error
+
+	CreateTag(context.Context, *models.Tag) error
+	GetTags(context.Context) (*models.Tags, error)
+	FindTagByID(context.Context, models.ID) (*models.Tag, error)
+	UpdateTag(context.Context, *models.Tag) error
+	DeleteTag(context.Context, models.ID) error
+	FindLocationsByTags(ctx context.Context, catID models.ID, names []string, matchAll bool) (*models.Locations, error)
+
+	CreateGroup(context.Context, *models.Group) error
+	GetGroups(context.Context) (*models.Groups, error)
+	FindGroupByID(context.Context, models.ID) (*models.Group, error)
+	UpdateGroup(context.Context, *models.Group) error
+	DeleteGroup(context.Context, models.ID) error
+	GetGroupMembers(context.Context, models.ID) (*models.GroupMembers, error)
+	SetGroupMember(ctx context.Context, id models.ID, member *models.GroupMember) error
+	RemoveGroupMember(ctx context.Context, id models.ID, user models.ID) error
+
+	CreateShareLink(context.Context, *models.ShareLink) error
+	GetShareLinks(context.Context, models.ID) (*models.ShareLinks, error)
+	RevokeShareLink(ctx context.Context, id models.ID, link models.ID) error
+	GetPublicLocation(ctx context.Context, token string) (*models.PublicLocation, error)
+
+	ReverseGeocode(ctx context.Context, latitude, longitude float64) (*models.Place, error)
 }
 
 // API lists usecases of this service
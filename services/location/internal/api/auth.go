@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
@@ -21,6 +22,11 @@ type JWTClaims struct {
 	jwt.StandardClaims
 
 	Email string `json:"email,omitempty"`
+	// Roles of the user, like "admin".
+	Roles []string `json:"roles,omitempty"`
+	// Space separated scopes the token is restricted to, like "locations:read locations:write".
+	// The token is granted every scope of the user roles when empty.
+	Scope string `json:"scope,omitempty"`
 }
 
 // JWTAuthenticator authenticates request using JWT tokens
@@ -74,8 +80,11 @@ func (a *JWTAuthenticator) newContextWithClaims(ctx context.Context, token *jwt.
 		return ctx, errors.New("user ID cannot be nil")
 	}
 
-	return models.NewContextWithUser(
-		ctx,
-		models.NewUser(userID, claims.Email),
-	), nil
+	user := models.NewUser(userID, claims.Email)
+	user.Roles = claims.Roles
+	if claims.Scope != "" {
+		user.Scopes = strings.Fields(claims.Scope)
+	}
+
+	return models.NewContextWithUser(ctx, user), nil
 }
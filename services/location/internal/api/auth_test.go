@@ -1,7 +1,51 @@
 package api
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
 
 func TestAuthenticateWithSuccess(t *testing.T) {
 
 }
+
+func TestAuthenticateWithRolesAndScope(t *testing.T) {
+	auth := &JWTAuthenticator{Algorithm: "HS256", SecretKey: "secret"}
+	id := models.NewID()
+	token, err := utils.NewJWTToken("secret", "HS256", &JWTClaims{
+		StandardClaims: jwt.StandardClaims{Subject: id.String()},
+		Email:          "user@test.fr",
+		Roles:          []string{"viewer"},
+		Scope:          "locations:read  tags:write",
+	})
+	assert.NoError(t, err)
+
+	ctx, err := auth.Authenticate(context.Background(), token)
+	assert.NoError(t, err)
+
+	user, ok := models.NewUserFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, id, user.ID)
+	assert.Equal(t, []string{"viewer"}, user.Roles)
+	assert.Equal(t, []string{"locations:read", "tags:write"}, user.Scopes)
+}
+
+func TestAuthenticateWithoutScope(t *testing.T) {
+	auth := &JWTAuthenticator{Algorithm: "HS256", SecretKey: "secret"}
+	token, err := utils.NewJWTToken("secret", "HS256", &JWTClaims{
+		StandardClaims: jwt.StandardClaims{Subject: models.NewID().String()},
+	})
+	assert.NoError(t, err)
+
+	ctx, err := auth.Authenticate(context.Background(), token)
+	assert.NoError(t, err)
+
+	user, _ := models.NewUserFromContext(ctx)
+	assert.Nil(t, user.Roles)
+	assert.Nil(t, user.Scopes)
+}
@@ -0,0 +1,69 @@
+package grpcapi
+
+import (
+	"context"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MergeCategories merges source categories into target category, and reports categories that could not be merged
+func (s *GRPCServer) MergeCategories(ctx context.Context, req *pb.MergeCategoriesRequest) (*pb.MergeCategoriesResponse, error) {
+	targetID, err := models.ParseID(req.TargetId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category ID %s", req.TargetId)
+	}
+
+	sourceIDs, err := models.ParseIDs(req.SourceIds)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid source category IDs")
+	}
+
+	report, err := s.api.LocationUsecase.MergeCategories(ctx, targetID, sourceIDs)
+	if err != nil {
+		return nil, statusError("MergeCategories", err, "failed to merge categories")
+	}
+
+	succeeded, failed := newPBBulkReport(report)
+	return &pb.MergeCategoriesResponse{Succeeded: succeeded, Failed: failed}, nil
+}
+
+// MoveLocations moves user locations to specified category at once, and reports locations that could not be moved
+func (s *GRPCServer) MoveLocations(ctx context.Context, req *pb.MoveLocationsRequest) (*pb.MoveLocationsResponse, error) {
+	ids, err := models.ParseIDs(req.Ids)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid location IDs")
+	}
+
+	catID, err := models.ParseID(req.CategoryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category ID %s", req.CategoryId)
+	}
+
+	report, err := s.api.LocationUsecase.MoveLocations(ctx, ids, catID)
+	if err != nil {
+		return nil, statusError("MoveLocations", err, "failed to move locations")
+	}
+
+	succeeded, failed := newPBBulkReport(report)
+	return &pb.MoveLocationsResponse{Succeeded: succeeded, Failed: failed}, nil
+}
+
+func newPBBulkReport(report *models.BulkReport) ([]string, []*pb.BulkFailure) {
+	succeeded := make([]string, len(report.Succeeded))
+	for i, id := range report.Succeeded {
+		succeeded[i] = id.String()
+	}
+
+	failed := make([]*pb.BulkFailure, len(report.Failed))
+	for i, failure := range report.Failed {
+		failed[i] = &pb.BulkFailure{
+			Id:    failure.ID.String(),
+			Error: failure.Error,
+		}
+	}
+
+	return succeeded, failed
+}
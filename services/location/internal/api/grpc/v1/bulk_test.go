@@ -0,0 +1,89 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMergeCategoriesWithInvalidRequest(t *testing.T) {
+	for _, request := range []*pb.MergeCategoriesRequest{
+		{SourceIds: []string{"4b7a536e-7109-4a39-9549-f06f74f2093e"}},
+		{TargetId: "4b7a536e-7109-4a39-9549-f06f74f2093e"},
+		{TargetId: "4b7a536e-7109-4a39-9549-f06f74f2093e", SourceIds: []string{"invalid"}},
+	} {
+		_, err := client.MergeCategories(context.Background(), request)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), request)
+	}
+}
+
+func TestMergeCategoriesWithSuccess(t *testing.T) {
+	targetID := models.NewID()
+	sourceIDs := []models.ID{models.NewID(), models.NewID()}
+
+	report := models.NewBulkReport()
+	report.Succeed(sourceIDs[0])
+	report.Fail(sourceIDs[1], usecases.ErrCategoryNotFound)
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("MergeCategories", utils.MockContextMatcher, targetID, sourceIDs).
+		Return(report, nil)
+
+	request := &pb.MergeCategoriesRequest{
+		TargetId:  targetID.String(),
+		SourceIds: []string{sourceIDs[0].String(), sourceIDs[1].String()},
+	}
+	response, err := client.MergeCategories(context.Background(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) && assert.Len(t, response.Failed, 1) {
+		assert.Equal(t, []string{sourceIDs[0].String()}, response.Succeeded)
+		assert.Equal(t, sourceIDs[1].String(), response.Failed[0].Id)
+		assert.Equal(t, "category not found", response.Failed[0].Error)
+	}
+}
+
+func TestMoveLocationsWithCategoryNotFound(t *testing.T) {
+	server, conn := newTestGRPCClientConnection()
+	defer conn.Close()
+
+	ids := []models.ID{models.NewID()}
+	catID := models.NewID()
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("MoveLocations", utils.MockContextMatcher, ids, catID).
+		Return(nil, usecases.ErrCategoryNotFound)
+
+	request := &pb.MoveLocationsRequest{Ids: []string{ids[0].String()}, CategoryId: catID.String()}
+	_, err := pb.NewLocationServiceClient(conn).MoveLocations(context.Background(), request)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMoveLocationsWithSuccess(t *testing.T) {
+	ids := []models.ID{models.NewID(), models.NewID()}
+	catID := models.NewID()
+
+	report := models.NewBulkReport()
+	report.Succeed(ids[1])
+	report.Fail(ids[0], usecases.ErrLocationNotFound)
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("MoveLocations", utils.MockContextMatcher, ids, catID).
+		Return(report, nil)
+
+	request := &pb.MoveLocationsRequest{Ids: []string{ids[0].String(), ids[1].String()}, CategoryId: catID.String()}
+	response, err := client.MoveLocations(context.Background(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) && assert.Len(t, response.Failed, 1) {
+		assert.Equal(t, []string{ids[1].String()}, response.Succeeded)
+		assert.Equal(t, ids[0].String(), response.Failed[0].Id)
+	}
+}
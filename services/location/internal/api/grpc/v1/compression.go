@@ -0,0 +1,70 @@
+package grpcapi
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+
+	// Registers the gzip compressor, so that clients may use it
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+func init() {
+	encoding.RegisterCompressor(newZstdCompressor())
+}
+
+// zstdCompressor implements the gRPC encoding.Compressor interface using zstd.
+// gRPC messages are fully held in memory anyway, so they are encoded and decoded
+// in one go by shared stateless encoder and decoder, which are safe for concurrent use.
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCompressor() *zstdCompressor {
+	// Default options are valid, so no error may be returned
+	encoder, _ := zstd.NewWriter(nil)
+	decoder, _ := zstd.NewReader(nil)
+
+	return &zstdCompressor{encoder, decoder}
+}
+
+// Name is the value advertised in grpc-encoding headers
+func (c *zstdCompressor) Name() string {
+	return "zstd"
+}
+
+// Compress returns a writer compressing the whole message once it is closed
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &zstdMessageWriter{w: w, encoder: c.encoder}, nil
+}
+
+// Decompress reads the whole compressed message from r and returns a reader over its content
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := c.decoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(msg), nil
+}
+
+type zstdMessageWriter struct {
+	bytes.Buffer
+
+	w       io.Writer
+	encoder *zstd.Encoder
+}
+
+func (z *zstdMessageWriter) Close() error {
+	_, err := z.w.Write(z.encoder.EncodeAll(z.Bytes(), nil))
+	return err
+}
@@ -0,0 +1,54 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+func TestZstdCompressorRoundTrip(t *testing.T) {
+	compressor := newZstdCompressor()
+	message := bytes.Repeat([]byte("Test Location "), 100)
+
+	var buf bytes.Buffer
+	w, err := compressor.Compress(&buf)
+	assert.NoError(t, err)
+	_, err = w.Write(message)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	assert.Less(t, buf.Len(), len(message))
+
+	r, err := compressor.Decompress(&buf)
+	assert.NoError(t, err)
+	decompressed, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, message, decompressed)
+}
+
+func TestZstdCompressorWithInvalidData(t *testing.T) {
+	_, err := newZstdCompressor().Decompress(bytes.NewReader([]byte("invalid")))
+	assert.Error(t, err)
+}
+
+func TestCreateCategoryWithCompression(t *testing.T) {
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateCategory", utils.MockContextMatcher, mock.AnythingOfType("*models.Category")).
+		Return(nil)
+
+	for _, name := range []string{"gzip", "zstd"} {
+		request := &pb.CreateCategoryRequest{Name: "Test Category"}
+		response, err := client.CreateCategory(context.Background(), request, grpc.UseCompressor(name))
+
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, request.Name, response.Category.Name)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package grpcapi
+
+import (
+	"github.com/edebernis/social-life-manager/services/location/internal/apperrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError returns the gRPC status translated from a domain error.
+// Other errors are logged on behalf of method and reported with msg only.
+func statusError(method string, err error, msg string) error {
+	e, t := apperrors.Translate(err)
+	if e == nil || e.Kind == apperrors.KindInternal {
+		logger.Errorf("%s: %s. %v", method, msg, err)
+		return status.Error(codes.Internal, msg)
+	}
+
+	return status.Error(t.GRPCCode, e.Message)
+}
@@ -0,0 +1,34 @@
+package grpcapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/api"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{fmt.Errorf("CreateLocation: %w", usecases.ErrLocationAlreadyExists), codes.AlreadyExists, "location already exists"},
+		{usecases.ErrCategoryReadOnly, codes.PermissionDenied, "category is read-only"},
+		{&usecases.CategoryInUseError{Locations: 2}, codes.FailedPrecondition, "category still has 2 locations"},
+		{api.ErrOverloaded, codes.Unavailable, "service overloaded"},
+		{errors.New("connection refused"), codes.Internal, "failed to do it"},
+	}
+
+	for _, test := range tests {
+		st, ok := status.FromError(statusError("Test", test.err, "failed to do it"))
+		assert.True(t, ok, test.err)
+		assert.Equal(t, test.code, st.Code(), test.err)
+		assert.Equal(t, test.message, st.Message(), test.err)
+	}
+}
@@ -0,0 +1,165 @@
+package grpcapi
+
+import (
+	"context"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateGroup creates a new group of users, owned by the user
+func (s *GRPCServer) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
+	group := models.NewGroup(models.NewID(), req.Name)
+
+	err := s.api.LocationUsecase.CreateGroup(ctx, group)
+	if err != nil {
+		return nil, statusError("CreateGroup", err, "failed to create group")
+	}
+
+	return &pb.CreateGroupResponse{Group: newPBGroup(group)}, nil
+}
+
+// GetGroups returns all groups the user is a member of
+func (s *GRPCServer) GetGroups(ctx context.Context, req *pb.GetGroupsRequest) (*pb.GetGroupsResponse, error) {
+	groups, err := s.api.LocationUsecase.GetGroups(ctx)
+	if err != nil {
+		return nil, statusError("GetGroups", err, "failed to get groups")
+	}
+
+	response := &pb.GetGroupsResponse{
+		Groups: make([]*pb.Group, len(*groups)),
+	}
+	for i, group := range *groups {
+		response.Groups[i] = newPBGroup(group)
+	}
+
+	return response, nil
+}
+
+// GetGroup returns one specific group of the user
+func (s *GRPCServer) GetGroup(ctx context.Context, req *pb.GetGroupRequest) (*pb.GetGroupResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group ID %s", req.Id)
+	}
+
+	group, err := s.api.LocationUsecase.FindGroupByID(ctx, id)
+	if err != nil {
+		return nil, statusError("GetGroup", err, "failed to get group")
+	}
+
+	return &pb.GetGroupResponse{Group: newPBGroup(group)}, nil
+}
+
+// UpdateGroup renames one group
+func (s *GRPCServer) UpdateGroup(ctx context.Context, req *pb.UpdateGroupRequest) (*pb.UpdateGroupResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group ID %s", req.Id)
+	}
+
+	group := models.NewGroup(id, req.Name)
+
+	err = s.api.LocationUsecase.UpdateGroup(ctx, group)
+	if err != nil {
+		return nil, statusError("UpdateGroup", err, "failed to update group")
+	}
+
+	return &pb.UpdateGroupResponse{Group: newPBGroup(group)}, nil
+}
+
+// DeleteGroup deletes one group, giving its locations back to the users who created them
+func (s *GRPCServer) DeleteGroup(ctx context.Context, req *pb.DeleteGroupRequest) (*pb.DeleteGroupResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group ID %s", req.Id)
+	}
+
+	err = s.api.LocationUsecase.DeleteGroup(ctx, id)
+	if err != nil {
+		return nil, statusError("DeleteGroup", err, "failed to delete group")
+	}
+
+	return &pb.DeleteGroupResponse{}, nil
+}
+
+// GetGroupMembers returns the members of one group
+func (s *GRPCServer) GetGroupMembers(ctx context.Context, req *pb.GetGroupMembersRequest) (*pb.GetGroupMembersResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group ID %s", req.Id)
+	}
+
+	members, err := s.api.LocationUsecase.GetGroupMembers(ctx, id)
+	if err != nil {
+		return nil, statusError("GetGroupMembers", err, "failed to get group members")
+	}
+
+	response := &pb.GetGroupMembersResponse{
+		Members: make([]*pb.GroupMember, len(*members)),
+	}
+	for i, member := range *members {
+		response.Members[i] = newPBGroupMember(member)
+	}
+
+	return response, nil
+}
+
+// SetGroupMember adds a member to one group, or changes the role of a member
+func (s *GRPCServer) SetGroupMember(ctx context.Context, req *pb.SetGroupMemberRequest) (*pb.SetGroupMemberResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group ID %s", req.Id)
+	}
+
+	user, err := models.ParseID(req.Member.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID %s", req.Member.UserId)
+	}
+
+	member := models.NewGroupMember(user, models.GroupRole(req.Member.Role))
+
+	err = s.api.LocationUsecase.SetGroupMember(ctx, id, member)
+	if err != nil {
+		return nil, statusError("SetGroupMember", err, "failed to set group member")
+	}
+
+	return &pb.SetGroupMemberResponse{Member: newPBGroupMember(member)}, nil
+}
+
+// RemoveGroupMember removes a member from one group
+func (s *GRPCServer) RemoveGroupMember(ctx context.Context, req *pb.RemoveGroupMemberRequest) (*pb.RemoveGroupMemberResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group ID %s", req.Id)
+	}
+
+	user, err := models.ParseID(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID %s", req.UserId)
+	}
+
+	err = s.api.LocationUsecase.RemoveGroupMember(ctx, id, user)
+	if err != nil {
+		return nil, statusError("RemoveGroupMember", err, "failed to remove group member")
+	}
+
+	return &pb.RemoveGroupMemberResponse{}, nil
+}
+
+func newPBGroup(group *models.Group) *pb.Group {
+	return &pb.Group{
+		Id:   group.ID.String(),
+		Name: group.Name,
+		Role: string(group.Role),
+	}
+}
+
+func newPBGroupMember(member *models.GroupMember) *pb.GroupMember {
+	return &pb.GroupMember{
+		UserId: member.User.String(),
+		Role:   string(member.Role),
+	}
+}
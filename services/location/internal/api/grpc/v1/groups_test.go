@@ -0,0 +1,74 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateGroupWithSuccess(t *testing.T) {
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateGroup", utils.MockContextMatcher, mock.MatchedBy(func(group *models.Group) bool {
+			return group.Name == "Family"
+		})).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*models.Group).Role = models.GroupRoleOwner
+		}).
+		Return(nil)
+
+	request := &pb.CreateGroupRequest{Name: "Family"}
+	response, err := client.CreateGroup(context.Background(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) {
+		assert.Equal(t, "Family", response.Group.Name)
+		assert.Equal(t, "owner", response.Group.Role)
+	}
+}
+
+func TestGetGroupWithNotFound(t *testing.T) {
+	id := models.NewID()
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindGroupByID", utils.MockContextMatcher, id).
+		Return(nil, usecases.ErrGroupNotFound)
+
+	request := &pb.GetGroupRequest{Id: id.String()}
+	_, err := client.GetGroup(context.Background(), request)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSetGroupMemberWithInvalidRequest(t *testing.T) {
+	for _, request := range []*pb.SetGroupMemberRequest{
+		{Id: models.NewID().String()},
+		{Id: models.NewID().String(), Member: &pb.GroupMember{UserId: "invalid", Role: "member"}},
+		{Id: models.NewID().String(), Member: &pb.GroupMember{UserId: models.NewID().String(), Role: "editor"}},
+	} {
+		_, err := client.SetGroupMember(context.Background(), request)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), request)
+	}
+}
+
+func TestRemoveGroupMemberWithLastOwner(t *testing.T) {
+	id, user := models.NewID(), models.NewID()
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("RemoveGroupMember", utils.MockContextMatcher, id, user).
+		Return(usecases.ErrLastGroupOwner)
+
+	request := &pb.RemoveGroupMemberRequest{Id: id.String(), UserId: user.String()}
+	_, err := client.RemoveGroupMember(context.Background(), request)
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
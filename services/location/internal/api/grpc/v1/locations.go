@@ -5,30 +5,95 @@ import (
 
 	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
-	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // CreateCategory creates a new category
 func (s *GRPCServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		logger.Error("CreateCategory: failed to get user from request context.")
+		return nil, status.Error(codes.Internal, "failed to get user data")
+	}
+
+	parentID, err := models.ParseID(req.ParentId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid parent category ID %s", req.ParentId)
+	}
+
 	cat := models.NewCategory(models.NewID(), req.Name)
+	cat.SetParent(parentID)
+	cat.SetUser(user.ID)
 
-	err := s.api.LocationUsecase.CreateCategory(ctx, cat)
+	err = s.api.LocationUsecase.CreateCategory(ctx, cat)
 	if err != nil {
-		switch err {
-		case usecases.ErrCategoryAlreadyExists:
-			return nil, status.Errorf(codes.AlreadyExists, "category %s already exists", req.Name)
-		default:
-			logger.Errorf("CreateCategory: failed to create category. %v", err)
-			return nil, status.Error(codes.Internal, "failed to create category")
-		}
+		return nil, statusError("CreateCategory", err, "failed to create category")
 	}
 
 	return &pb.CreateCategoryResponse{
 		Category: &pb.Category{
-			Id:   cat.ID.String(),
-			Name: cat.Name,
+			Id:       cat.ID.String(),
+			Name:     cat.Name,
+			UserId:   user.ID.String(),
+			ParentId: req.ParentId,
 		},
 	}, nil
 }
+
+// FindLocationsNear returns user locations within a radius, nearest first
+func (s *GRPCServer) FindLocationsNear(ctx context.Context, req *pb.FindLocationsNearRequest) (*pb.FindLocationsNearResponse, error) {
+	catID, err := models.ParseID(req.CategoryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category ID %s", req.CategoryId)
+	}
+	center := models.NewPoint(req.Center.Latitude, req.Center.Longitude)
+
+	locs, err := s.api.LocationUsecase.FindLocationsNear(ctx, catID, center, req.Radius)
+	if err != nil {
+		return nil, statusError("FindLocationsNear", err, "failed to find locations")
+	}
+
+	response := &pb.FindLocationsNearResponse{
+		Locations: make([]*pb.NearLocation, len(*locs)),
+	}
+	for i, loc := range *locs {
+		response.Locations[i] = &pb.NearLocation{
+			Location: newPBLocation(loc),
+			Distance: *loc.Distance,
+		}
+	}
+
+	return response, nil
+}
+
+func newPBLocation(loc *models.Location) *pb.Location {
+	location := &pb.Location{
+		Id:         loc.ID.String(),
+		Name:       loc.Name,
+		Address:    loc.Address,
+		CategoryId: loc.Category.String(),
+		PlusCode:   loc.EncodePlusCode(),
+		Tags:       loc.Tags,
+		Role:       string(loc.Role),
+		AddressComponents: &pb.AddressComponents{
+			Street:      loc.Components.Street,
+			HouseNumber: loc.Components.HouseNumber,
+			PostalCode:  loc.Components.PostalCode,
+			Locality:    loc.Components.Locality,
+			Region:      loc.Components.Region,
+			CountryCode: loc.Components.CountryCode,
+		},
+	}
+	if loc.Group != nil {
+		location.GroupId = loc.Group.String()
+	}
+	if loc.Latitude != nil && loc.Longitude != nil {
+		location.Coordinates = &pb.Coordinates{
+			Latitude:  *loc.Latitude,
+			Longitude: *loc.Longitude,
+		}
+	}
+
+	return location
+}
@@ -6,12 +6,16 @@ import (
 	"testing"
 
 	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/api"
 	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
 	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -43,5 +47,107 @@ func TestCreateCategoryWithSuccess(t *testing.T) {
 		_, err := models.ParseID(response.Category.Id)
 		assert.NoError(t, err)
 		assert.Equal(t, request.Name, response.Category.Name)
+		_, err = models.ParseID(response.Category.UserId)
+		assert.NoError(t, err)
+	}
+}
+
+func TestCreateCategoryWithParentNotFound(t *testing.T) {
+	server, conn := newTestGRPCClientConnection()
+	defer conn.Close()
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateCategory", utils.MockContextMatcher, mock.AnythingOfType("*models.Category")).
+		Return(usecases.ErrParentCategoryNotFound)
+
+	request := &pb.CreateCategoryRequest{Name: "Tennis", ParentId: "4b7a536e-7109-4a39-9549-f06f74f2093e"}
+	_, err := pb.NewLocationServiceClient(conn).CreateCategory(context.Background(), request)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCreateCategoryWithOverloadedError(t *testing.T) {
+	server, conn := newTestGRPCClientConnection()
+	defer conn.Close()
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateCategory", utils.MockContextMatcher, mock.AnythingOfType("*models.Category")).
+		Return(api.ErrOverloaded)
+
+	request := &pb.CreateCategoryRequest{Name: "Test Category"}
+	_, err := pb.NewLocationServiceClient(conn).CreateCategory(context.Background(), request)
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestCreateCategoryWithForbiddenError(t *testing.T) {
+	server, conn := newTestGRPCClientConnection()
+	defer conn.Close()
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateCategory", utils.MockContextMatcher, mock.AnythingOfType("*models.Category")).
+		Return(usecases.ErrForbidden)
+
+	request := &pb.CreateCategoryRequest{Name: "Test Category"}
+	_, err := pb.NewLocationServiceClient(conn).CreateCategory(context.Background(), request)
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestFindLocationsNearWithInvalidRequest(t *testing.T) {
+	for _, request := range []*pb.FindLocationsNearRequest{
+		{Radius: 5000},
+		{Center: &pb.Coordinates{Latitude: 95, Longitude: 2.3376}, Radius: 5000},
+		{Center: &pb.Coordinates{Latitude: 48.8606111, Longitude: 2.3376}, Radius: 0},
+		{Center: &pb.Coordinates{Latitude: 48.8606111, Longitude: 2.3376}, Radius: 5000, CategoryId: "invalid"},
+	} {
+		_, err := client.FindLocationsNear(context.Background(), request)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), request)
+	}
+}
+
+func TestFindLocationsNearWithCategoryNotFound(t *testing.T) {
+	server, conn := newTestGRPCClientConnection()
+	defer conn.Close()
+
+	catID := models.NewID()
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationsNear", utils.MockContextMatcher, catID, models.NewPoint(48.8606111, 2.3376), 5000.0).
+		Return(nil, usecases.ErrCategoryNotFound)
+
+	request := &pb.FindLocationsNearRequest{
+		Center:     &pb.Coordinates{Latitude: 48.8606111, Longitude: 2.3376},
+		Radius:     5000,
+		CategoryId: catID.String(),
+	}
+	_, err := pb.NewLocationServiceClient(conn).FindLocationsNear(context.Background(), request)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestFindLocationsNearWithSuccess(t *testing.T) {
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Components = models.AddressComponents{Street: "rue de la Poste", HouseNumber: "1", PostalCode: "75001", Locality: "Paris"}
+	loc.SetCoordinates(48.8606111, 2.3376)
+	distance := 7.5
+	loc.Distance = &distance
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationsNear", utils.MockContextMatcher, models.NilID, models.NewPoint(48.8606, 2.3377), 5000.0).
+		Return(&models.Locations{loc}, nil)
+
+	request := &pb.FindLocationsNearRequest{
+		Center: &pb.Coordinates{Latitude: 48.8606, Longitude: 2.3377},
+		Radius: 5000,
+	}
+	response, err := client.FindLocationsNear(context.Background(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) && assert.Len(t, response.Locations, 1) {
+		assert.Equal(t, loc.ID.String(), response.Locations[0].Location.Id)
+		assert.Equal(t, 48.8606111, response.Locations[0].Location.Coordinates.Latitude)
+		assert.Equal(t, "75001", response.Locations[0].Location.AddressComponents.PostalCode)
+		assert.Equal(t, "8FW4V86Q+62", response.Locations[0].Location.PlusCode)
+		assert.Equal(t, 7.5, response.Locations[0].Distance)
 	}
 }
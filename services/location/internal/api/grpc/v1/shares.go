@@ -0,0 +1,144 @@
+package grpcapi
+
+import (
+	"context"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetLocationShares returns the roles granted on one location to other users
+func (s *GRPCServer) GetLocationShares(ctx context.Context, req *pb.GetLocationSharesRequest) (*pb.GetLocationSharesResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid location ID %s", req.Id)
+	}
+
+	shares, err := s.api.LocationUsecase.GetLocationShares(ctx, id)
+	if err != nil {
+		return nil, statusError("GetLocationShares", err, "failed to get location shares")
+	}
+
+	return &pb.GetLocationSharesResponse{Shares: newPBShares(shares)}, nil
+}
+
+// ShareLocation grants a role on one location to another user
+func (s *GRPCServer) ShareLocation(ctx context.Context, req *pb.ShareLocationRequest) (*pb.ShareLocationResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid location ID %s", req.Id)
+	}
+
+	share, err := newShare(req.Share)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.api.LocationUsecase.ShareLocation(ctx, id, share)
+	if err != nil {
+		return nil, statusError("ShareLocation", err, "failed to share location")
+	}
+
+	return &pb.ShareLocationResponse{Share: newPBShare(share)}, nil
+}
+
+// UnshareLocation revokes the role granted on one location to another user
+func (s *GRPCServer) UnshareLocation(ctx context.Context, req *pb.UnshareLocationRequest) (*pb.UnshareLocationResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid location ID %s", req.Id)
+	}
+
+	user, err := models.ParseID(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID %s", req.UserId)
+	}
+
+	err = s.api.LocationUsecase.UnshareLocation(ctx, id, user)
+	if err != nil {
+		return nil, statusError("UnshareLocation", err, "failed to unshare location")
+	}
+
+	return &pb.UnshareLocationResponse{}, nil
+}
+
+// GetCategoryShares returns the roles granted on one user category to other users
+func (s *GRPCServer) GetCategoryShares(ctx context.Context, req *pb.GetCategorySharesRequest) (*pb.GetCategorySharesResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category ID %s", req.Id)
+	}
+
+	shares, err := s.api.LocationUsecase.GetCategoryShares(ctx, id)
+	if err != nil {
+		return nil, statusError("GetCategoryShares", err, "failed to get category shares")
+	}
+
+	return &pb.GetCategorySharesResponse{Shares: newPBShares(shares)}, nil
+}
+
+// ShareCategory grants a role on all locations of one user category to another user
+func (s *GRPCServer) ShareCategory(ctx context.Context, req *pb.ShareCategoryRequest) (*pb.ShareCategoryResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category ID %s", req.Id)
+	}
+
+	share, err := newShare(req.Share)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.api.LocationUsecase.ShareCategory(ctx, id, share)
+	if err != nil {
+		return nil, statusError("ShareCategory", err, "failed to share category")
+	}
+
+	return &pb.ShareCategoryResponse{Share: newPBShare(share)}, nil
+}
+
+// UnshareCategory revokes the role granted on one user category to another user
+func (s *GRPCServer) UnshareCategory(ctx context.Context, req *pb.UnshareCategoryRequest) (*pb.UnshareCategoryResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category ID %s", req.Id)
+	}
+
+	user, err := models.ParseID(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID %s", req.UserId)
+	}
+
+	err = s.api.LocationUsecase.UnshareCategory(ctx, id, user)
+	if err != nil {
+		return nil, statusError("UnshareCategory", err, "failed to unshare category")
+	}
+
+	return &pb.UnshareCategoryResponse{}, nil
+}
+
+func newShare(share *pb.Share) (*models.Share, error) {
+	user, err := models.ParseID(share.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID %s", share.UserId)
+	}
+
+	return models.NewShare(user, models.ShareRole(share.Role)), nil
+}
+
+func newPBShare(share *models.Share) *pb.Share {
+	return &pb.Share{
+		UserId: share.User.String(),
+		Role:   string(share.Role),
+	}
+}
+
+func newPBShares(shares *models.Shares) []*pb.Share {
+	pbShares := make([]*pb.Share, len(*shares))
+	for i, share := range *shares {
+		pbShares[i] = newPBShare(share)
+	}
+	return pbShares
+}
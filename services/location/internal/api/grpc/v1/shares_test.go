@@ -0,0 +1,75 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetLocationSharesWithSuccess(t *testing.T) {
+	id := models.NewID()
+	share := models.NewShare(models.NewID(), models.ShareRoleEditor)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocationShares", utils.MockContextMatcher, id).
+		Return(&models.Shares{share}, nil)
+
+	request := &pb.GetLocationSharesRequest{Id: id.String()}
+	response, err := client.GetLocationShares(context.Background(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) && assert.Len(t, response.Shares, 1) {
+		assert.Equal(t, share.User.String(), response.Shares[0].UserId)
+		assert.Equal(t, "editor", response.Shares[0].Role)
+	}
+}
+
+func TestShareLocationWithInvalidRequest(t *testing.T) {
+	for _, request := range []*pb.ShareLocationRequest{
+		{Id: models.NewID().String()},
+		{Id: models.NewID().String(), Share: &pb.Share{UserId: "invalid", Role: "viewer"}},
+		{Id: models.NewID().String(), Share: &pb.Share{UserId: models.NewID().String(), Role: "admin"}},
+	} {
+		_, err := client.ShareLocation(context.Background(), request)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), request)
+	}
+}
+
+func TestShareLocationWithForbidden(t *testing.T) {
+	id := models.NewID()
+	share := models.NewShare(models.NewID(), models.ShareRoleViewer)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ShareLocation", utils.MockContextMatcher, id, share).
+		Return(usecases.ErrForbidden)
+
+	request := &pb.ShareLocationRequest{
+		Id:    id.String(),
+		Share: &pb.Share{UserId: share.User.String(), Role: "viewer"},
+	}
+	_, err := client.ShareLocation(context.Background(), request)
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestUnshareCategoryWithShareNotFound(t *testing.T) {
+	id, user := models.NewID(), models.NewID()
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UnshareCategory", utils.MockContextMatcher, id, user).
+		Return(usecases.ErrShareNotFound)
+
+	request := &pb.UnshareCategoryRequest{Id: id.String(), UserId: user.String()}
+	_, err := client.UnshareCategory(context.Background(), request)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
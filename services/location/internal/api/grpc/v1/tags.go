@@ -0,0 +1,128 @@
+package grpcapi
+
+import (
+	"context"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateTag creates a new user tag
+func (s *GRPCServer) CreateTag(ctx context.Context, req *pb.CreateTagRequest) (*pb.CreateTagResponse, error) {
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		logger.Error("CreateTag: failed to get user from request context.")
+		return nil, status.Error(codes.Internal, "failed to get user data")
+	}
+
+	tag := models.NewTag(models.NewID(), req.Name, user.ID)
+
+	err := s.api.LocationUsecase.CreateTag(ctx, tag)
+	if err != nil {
+		return nil, statusError("CreateTag", err, "failed to create tag")
+	}
+
+	return &pb.CreateTagResponse{Tag: newPBTag(tag)}, nil
+}
+
+// GetTags returns all user tags
+func (s *GRPCServer) GetTags(ctx context.Context, req *pb.GetTagsRequest) (*pb.GetTagsResponse, error) {
+	tags, err := s.api.LocationUsecase.GetTags(ctx)
+	if err != nil {
+		return nil, statusError("GetTags", err, "failed to get tags")
+	}
+
+	response := &pb.GetTagsResponse{
+		Tags: make([]*pb.Tag, len(*tags)),
+	}
+	for i, tag := range *tags {
+		response.Tags[i] = newPBTag(tag)
+	}
+
+	return response, nil
+}
+
+// GetTag returns one specific user tag
+func (s *GRPCServer) GetTag(ctx context.Context, req *pb.GetTagRequest) (*pb.GetTagResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag ID %s", req.Id)
+	}
+
+	tag, err := s.api.LocationUsecase.FindTagByID(ctx, id)
+	if err != nil {
+		return nil, statusError("GetTag", err, "failed to get tag")
+	}
+
+	return &pb.GetTagResponse{Tag: newPBTag(tag)}, nil
+}
+
+// UpdateTag renames one user tag
+func (s *GRPCServer) UpdateTag(ctx context.Context, req *pb.UpdateTagRequest) (*pb.UpdateTagResponse, error) {
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		logger.Error("UpdateTag: failed to get user from request context.")
+		return nil, status.Error(codes.Internal, "failed to get user data")
+	}
+
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag ID %s", req.Id)
+	}
+
+	tag := models.NewTag(id, req.Name, user.ID)
+
+	err = s.api.LocationUsecase.UpdateTag(ctx, tag)
+	if err != nil {
+		return nil, statusError("UpdateTag", err, "failed to update tag")
+	}
+
+	return &pb.UpdateTagResponse{Tag: newPBTag(tag)}, nil
+}
+
+// DeleteTag deletes one user tag, removing it from all locations
+func (s *GRPCServer) DeleteTag(ctx context.Context, req *pb.DeleteTagRequest) (*pb.DeleteTagResponse, error) {
+	id, err := models.ParseID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag ID %s", req.Id)
+	}
+
+	err = s.api.LocationUsecase.DeleteTag(ctx, id)
+	if err != nil {
+		return nil, statusError("DeleteTag", err, "failed to delete tag")
+	}
+
+	return &pb.DeleteTagResponse{}, nil
+}
+
+// FindLocationsByTags returns user locations having all or any of requested tags
+func (s *GRPCServer) FindLocationsByTags(ctx context.Context, req *pb.FindLocationsByTagsRequest) (*pb.FindLocationsByTagsResponse, error) {
+	catID, err := models.ParseID(req.CategoryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category ID %s", req.CategoryId)
+	}
+
+	locs, err := s.api.LocationUsecase.FindLocationsByTags(ctx, catID, models.NormalizeTagNames(req.Tags), !req.MatchAny)
+	if err != nil {
+		return nil, statusError("FindLocationsByTags", err, "failed to find locations")
+	}
+
+	response := &pb.FindLocationsByTagsResponse{
+		Locations: make([]*pb.Location, len(*locs)),
+	}
+	for i, loc := range *locs {
+		response.Locations[i] = newPBLocation(loc)
+	}
+
+	return response, nil
+}
+
+func newPBTag(tag *models.Tag) *pb.Tag {
+	return &pb.Tag{
+		Id:     tag.ID.String(),
+		Name:   tag.Name,
+		UserId: tag.User.String(),
+	}
+}
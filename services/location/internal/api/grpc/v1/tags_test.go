@@ -0,0 +1,124 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/edebernis/social-life-manager/services/location/api/grpc/v1"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateTagWithSuccess(t *testing.T) {
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateTag", utils.MockContextMatcher, mock.AnythingOfType("*models.Tag")).
+		Return(nil)
+
+	request := &pb.CreateTagRequest{Name: "Favorite"}
+	response, err := client.CreateTag(context.Background(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) {
+		_, err := models.ParseID(response.Tag.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, request.Name, response.Tag.Name)
+	}
+}
+
+func TestCreateTagWithMultibyteName(t *testing.T) {
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateTag", utils.MockContextMatcher, mock.AnythingOfType("*models.Tag")).
+		Return(nil)
+
+	// Length of tag names is counted in characters by the usecase, not in bytes
+	request := &pb.CreateTagRequest{Name: strings.Repeat("é", 50)}
+	_, err := client.CreateTag(context.Background(), request)
+
+	assert.NoError(t, err)
+}
+
+func TestCreateTagWithInvalidRequest(t *testing.T) {
+	for _, request := range []*pb.CreateTagRequest{
+		{Name: ""},
+		{Name: "Cheap,Vegan"},
+	} {
+		_, err := client.CreateTag(context.Background(), request)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), request)
+	}
+}
+
+func TestCreateTagWithAlreadyExists(t *testing.T) {
+	server, conn := newTestGRPCClientConnection()
+	defer conn.Close()
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateTag", utils.MockContextMatcher, mock.AnythingOfType("*models.Tag")).
+		Return(usecases.ErrTagAlreadyExists)
+
+	request := &pb.CreateTagRequest{Name: "Favorite"}
+	_, err := pb.NewLocationServiceClient(conn).CreateTag(context.Background(), request)
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestGetTagWithNotFound(t *testing.T) {
+	id := models.NewID()
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindTagByID", utils.MockContextMatcher, id).
+		Return(nil, usecases.ErrTagNotFound)
+
+	_, err := client.GetTag(context.Background(), &pb.GetTagRequest{Id: id.String()})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDeleteTagWithSuccess(t *testing.T) {
+	id := models.NewID()
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteTag", utils.MockContextMatcher, id).
+		Return(nil)
+
+	_, err := client.DeleteTag(context.Background(), &pb.DeleteTagRequest{Id: id.String()})
+
+	assert.NoError(t, err)
+}
+
+func TestFindLocationsByTagsWithInvalidRequest(t *testing.T) {
+	for _, request := range []*pb.FindLocationsByTagsRequest{
+		{},
+		{Tags: []string{"Favorite"}, CategoryId: "invalid"},
+	} {
+		_, err := client.FindLocationsByTags(context.Background(), request)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), request)
+	}
+}
+
+func TestFindLocationsByTagsWithSuccess(t *testing.T) {
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Tags = []string{"Favorite", "Vegan"}
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationsByTags", utils.MockContextMatcher, models.NilID, []string{"Favorite", "Vegan"}, false).
+		Return(&models.Locations{loc}, nil)
+
+	request := &pb.FindLocationsByTagsRequest{
+		Tags:     []string{"Vegan ", "Favorite"},
+		MatchAny: true,
+	}
+	response, err := client.FindLocationsByTags(context.Background(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) && assert.Len(t, response.Locations, 1) {
+		assert.Equal(t, loc.ID.String(), response.Locations[0].Id)
+		assert.Equal(t, []string{"Favorite", "Vegan"}, response.Locations[0].Tags)
+	}
+}
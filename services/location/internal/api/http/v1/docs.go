@@ -0,0 +1,138 @@
+package httpapi
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	apidocs "github.com/edebernis/social-life-manager/services/location/api/http/v1"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Local path of swagger-ui assets, embedded into the binary.
+	docsAssetsURL  = "/docs/assets"
+	docsAssetsPath = docsAssetsURL + "/*filepath"
+)
+
+var (
+	// Routes served outside of the API base path, for health checks and documentation browsing.
+	// They are not part of the API, so they are not described by its OpenAPI specification.
+	undocumentedRoutes = map[string]bool{
+		"GET /ping":              true,
+		"GET /docs":              true,
+		"GET " + docsAssetsPath:  true,
+		"HEAD " + docsAssetsPath: true,
+	}
+
+	// Matches gin path parameters, like ":id". A parameter may be followed by an extension,
+	// like ":y.mvt", which is kept out of its OpenAPI name.
+	pathParamRegexp = regexp.MustCompile(`:(\w+)`)
+
+	docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Locations Service REST API</title>
+  <link rel="stylesheet" href="{{ .AssetsURL }}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{ .AssetsURL }}/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      window.ui = SwaggerUIBundle({
+        url: {{ .SpecURL }},
+        dom_id: "#swagger-ui",
+      });
+    };
+  </script>
+</body>
+</html>
+`))
+)
+
+func (s *HTTPServer) openAPISpecURL() string {
+	return s.BaseURL + "/v1/openapi.json"
+}
+
+// handleOpenAPISpec godoc
+// @Summary Get OpenAPI specification
+// @Description Get the OpenAPI 3 specification of this API, in JSON format.
+// @Tags docs
+// @Produce  json
+// @Success 200
+// @Router /openapi.json [get]
+func (s *HTTPServer) handleOpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", apidocs.OpenAPISpec)
+}
+
+// docsAssets returns swagger-ui assets embedded into the binary
+func docsAssets() http.FileSystem {
+	assets, err := fs.Sub(apidocs.SwaggerUI, "swagger-ui")
+	if err != nil {
+		panic(err)
+	}
+	return http.FS(assets)
+}
+
+// handleDocs serves the interactive documentation of this API, built from its OpenAPI specification.
+func (s *HTTPServer) handleDocs(c *gin.Context) {
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+
+	if err := docsTemplate.Execute(c.Writer, gin.H{"SpecURL": s.openAPISpecURL(), "AssetsURL": docsAssetsURL}); err != nil {
+		logger.Errorf("Docs: failed to render docs page. %v", err)
+	}
+}
+
+// CheckDocumentation ensures that every route served by the HTTP server is described
+// in the OpenAPI specification, except the explicitly undocumented ones. Routes are looked
+// up relatively to the spec server URL, so routes outside of it cannot be documented.
+func (s *HTTPServer) CheckDocumentation() error {
+	spec, err := openapi3.NewLoader().LoadFromData(apidocs.OpenAPISpec)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI spec. %w", err)
+	}
+
+	var basePath string
+	if len(spec.Servers) > 0 {
+		u, err := url.Parse(spec.Servers[0].URL)
+		if err != nil {
+			return fmt.Errorf("invalid OpenAPI server URL : %s. %w", spec.Servers[0].URL, err)
+		}
+		basePath = u.Path
+	}
+
+	var undocumented []string
+	for _, route := range s.router.Routes() {
+		if undocumentedRoutes[route.Method+" "+route.Path] {
+			continue
+		}
+
+		path := pathParamRegexp.ReplaceAllString(route.Path, "{$1}")
+		if basePath != "" {
+			if !strings.HasPrefix(path, basePath+"/") {
+				undocumented = append(undocumented, fmt.Sprintf("%s %s", route.Method, route.Path))
+				continue
+			}
+			path = strings.TrimPrefix(path, basePath)
+		}
+
+		item := spec.Paths.Find(path)
+		if item == nil || item.GetOperation(route.Method) == nil {
+			undocumented = append(undocumented, fmt.Sprintf("%s %s", route.Method, route.Path))
+		}
+	}
+
+	if len(undocumented) > 0 {
+		return fmt.Errorf("routes missing from OpenAPI spec : %s", strings.Join(undocumented, ", "))
+	}
+
+	return nil
+}
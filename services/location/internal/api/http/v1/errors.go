@@ -1,6 +1,14 @@
 package httpapi
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/apperrors"
+	"github.com/gin-gonic/gin"
+)
 
 // HTTPError model. Contains HTTP status code and a message describing the error.
 type HTTPError struct {
@@ -8,6 +16,18 @@ type HTTPError struct {
 	Code int `json:"code" example:"400"`
 	// String describing the error that occurred.
 	Message string `json:"message" example:"Bad Request"`
+	// Problem type, identifying the kind of error. Set for domain errors only.
+	Type string `json:"type,omitempty" example:"not-found"`
+	// Request field the error is about, if any.
+	Field string `json:"field,omitempty" example:"category_id"`
+	// Whether the same request may succeed later.
+	Retryable bool `json:"retryable,omitempty" example:"false"`
+}
+
+// StreamError model. Ends streams of newline delimited JSON cut short by an error.
+type StreamError struct {
+	// Error that stopped the stream.
+	Error HTTPError `json:"error"`
 }
 
 func newError(ctx *gin.Context, status int, err error) {
@@ -17,3 +37,27 @@ func newError(ctx *gin.Context, status int, err error) {
 	}
 	ctx.JSON(status, er)
 }
+
+// abortWithError aborts current request with the translation of a domain error.
+// Other errors are logged on behalf of handler and reported with msg only.
+func abortWithError(c *gin.Context, handler string, err error, msg string) {
+	e, t := apperrors.Translate(err)
+	if e == nil || e.Kind == apperrors.KindInternal {
+		logger.Errorf("%s: %s. %v", handler, strings.ToLower(msg[:1])+msg[1:], err)
+		abort(c, http.StatusInternalServerError, msg)
+		return
+	}
+
+	c.AbortWithStatusJSON(t.HTTPStatus, HTTPError{
+		Code:      t.HTTPStatus,
+		Message:   capitalize(e.Message),
+		Type:      t.ProblemType,
+		Field:     e.Field,
+		Retryable: e.Retryable,
+	})
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,39 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// handleGeocodeReverse godoc
+// @Summary Reverse geocode coordinates
+// @Description Find the address at specified coordinates, like a pin dropped on a map.
+// @Description Coordinates are returned as address when none can be found or when geocoding is disabled.
+// @Tags geocoding
+// @Produce  json
+// @Param lat query number true "Latitude in decimal degrees, like 48.8606111"
+// @Param lng query number true "Longitude in decimal degrees, like 2.3376"
+// @Success 200 {object} models.Place "The place found at these coordinates"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /geocode/reverse [get]
+func (s *HTTPServer) handleGeocodeReverse(c *gin.Context) {
+	var query models.ReverseGeocode
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Errorf("GeocodeReverse: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	place, err := s.api.LocationUsecase.ReverseGeocode(c.Request.Context(), *query.Latitude, *query.Longitude)
+	if err != nil {
+		abortWithError(c, "GeocodeReverse", err, "Failed to reverse geocode coordinates")
+		return
+	}
+
+	c.JSON(http.StatusOK, place)
+}
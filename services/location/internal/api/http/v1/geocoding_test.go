@@ -0,0 +1,73 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/api"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV1GeocodeReverseWithInvalidQuery(t *testing.T) {
+	for _, url := range []string{
+		"/api/v1/geocode/reverse",
+		"/api/v1/geocode/reverse?lat=48.8606111",
+		"/api/v1/geocode/reverse?lat=90.5&lng=2.3376",
+		"/api/v1/geocode/reverse?lat=48.8606111&lng=abc",
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", url, nil, nil)
+
+		server.handleGeocodeReverse(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), url)
+	}
+}
+
+func TestV1GeocodeReverseWithError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/geocode/reverse?lat=48.8606111&lng=2.3376", nil, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ReverseGeocode", utils.MockContextMatcher, 48.8606111, 2.3376).
+		Return(nil, errors.New("failed"))
+
+	server.handleGeocodeReverse(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, ctx.Writer.Status())
+}
+
+func TestV1GeocodeReverseWithOverloadedError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/geocode/reverse?lat=48.8606111&lng=2.3376", nil, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ReverseGeocode", utils.MockContextMatcher, 48.8606111, 2.3376).
+		Return(nil, api.ErrOverloaded)
+
+	server.handleGeocodeReverse(ctx)
+
+	assert.Equal(t, http.StatusServiceUnavailable, ctx.Writer.Status())
+}
+
+func TestV1GeocodeReverseWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/geocode/reverse?lat=0&lng=2.3376", nil, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ReverseGeocode", utils.MockContextMatcher, 0.0, 2.3376).
+		Return(models.NewPlaceFromCoordinates(0, 2.3376), nil)
+
+	server.handleGeocodeReverse(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var place models.Place
+	err := json.NewDecoder(resp.Result().Body).Decode(&place)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0, 2.3376", place.Address)
+		assert.Equal(t, 0.0, place.Latitude)
+		assert.Equal(t, 2.3376, place.Longitude)
+	}
+}
@@ -0,0 +1,327 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// handleGroupsCreate godoc
+// @Summary Create group
+// @Description Create a new group of users, like a household, owning locations together.
+// @Description The user becomes the owner of the group.
+// @Tags groups
+// @Accept  json
+// @Produce  json
+// @Param group body models.CreateGroup true "New group"
+// @Success 200 {object} models.Group "The created group"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /groups [post]
+func (s *HTTPServer) handleGroupsCreate(c *gin.Context) {
+	var body models.CreateGroup
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Errorf("GroupsCreate: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	group := models.NewGroup(models.NewID(), body.Name)
+
+	err := s.api.LocationUsecase.CreateGroup(c.Request.Context(), group)
+	switch {
+	case err != nil:
+		abortWithError(c, "GroupsCreate", err, "Failed to create group")
+		return
+	default:
+		c.JSON(http.StatusOK, group)
+	}
+}
+
+// handleGroupsGet godoc
+// @Summary Get groups
+// @Description Get all groups the user is a member of, sorted by name, along with the role of the user.
+// @Tags groups
+// @Produce  json
+// @Success 200 {object} models.Groups "The returned groups"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /groups [get]
+func (s *HTTPServer) handleGroupsGet(c *gin.Context) {
+	groups, err := s.api.LocationUsecase.GetGroups(c.Request.Context())
+	switch {
+	case err != nil:
+		abortWithError(c, "GroupsGet", err, "Failed to get groups")
+		return
+	default:
+		c.JSON(http.StatusOK, groups)
+	}
+}
+
+// handleGroupsGetByID godoc
+// @Summary Get group with specified ID
+// @Description Get one specific group of the user using provided ID.
+// @Tags groups
+// @Produce  json
+// @Param id path string true "Group ID"
+// @Success 200 {object} models.Group "The returned group"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /groups/{id} [get]
+func (s *HTTPServer) handleGroupsGetByID(c *gin.Context) {
+	var query models.GetGroupByID
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("GroupsGetByID: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("GroupsGetByID: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	group, err := s.api.LocationUsecase.FindGroupByID(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		abortWithError(c, "GroupsGetByID", err, "Failed to get group")
+		return
+	default:
+		c.JSON(http.StatusOK, group)
+	}
+}
+
+// handleGroupsUpdate godoc
+// @Summary Update group
+// @Description Rename specified group. Only owners of the group may rename it.
+// @Tags groups
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Group ID"
+// @Param group body models.UpdateGroup true "Group name"
+// @Success 200 {object} models.Group "The updated group"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /groups/{id} [put]
+func (s *HTTPServer) handleGroupsUpdate(c *gin.Context) {
+	var query models.UpdateGroupQuery
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("GroupsUpdate: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	var body models.UpdateGroup
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Errorf("GroupsUpdate: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("GroupsUpdate: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	group := models.NewGroup(id, body.Name)
+
+	err = s.api.LocationUsecase.UpdateGroup(c.Request.Context(), group)
+	switch {
+	case err != nil:
+		abortWithError(c, "GroupsUpdate", err, "Failed to update group")
+		return
+	default:
+		c.JSON(http.StatusOK, group)
+	}
+}
+
+// handleGroupsDelete godoc
+// @Summary Delete group
+// @Description Delete one specific group using provided ID. Only owners of the group may delete it.
+// @Description Locations of the group are given back to the users who created them.
+// @Tags groups
+// @Param id path string true "Group ID"
+// @Success 204 "OK"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /groups/{id} [delete]
+func (s *HTTPServer) handleGroupsDelete(c *gin.Context) {
+	var query models.DeleteGroup
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("GroupsDelete: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("GroupsDelete: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	err = s.api.LocationUsecase.DeleteGroup(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		abortWithError(c, "GroupsDelete", err, "Failed to delete group")
+		return
+	default:
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handleGroupsMembersGet godoc
+// @Summary Get group members
+// @Description Get the members of specified group of the user, along with their role.
+// @Tags groups
+// @Produce  json
+// @Param id path string true "Group ID"
+// @Success 200 {array} models.GroupMember "List of members"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /groups/{id}/members [get]
+func (s *HTTPServer) handleGroupsMembersGet(c *gin.Context) {
+	var query models.GetGroupMembers
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("GroupsMembersGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("GroupsMembersGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	members, err := s.api.LocationUsecase.GetGroupMembers(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		abortWithError(c, "GroupsMembersGet", err, "Failed to get group members")
+		return
+	default:
+		c.JSON(http.StatusOK, members)
+	}
+}
+
+// handleGroupsMembersPut godoc
+// @Summary Set group member
+// @Description Add a user to specified group, or change the role of a member.
+// @Description Owners manage the group, its members and its locations, and members may update the locations of the group.
+// @Description Only owners of the group may manage its members, and the group must keep at least one owner.
+// @Tags groups
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Group ID"
+// @Param user_id path string true "User ID"
+// @Param member body models.SetGroupMember true "Role of the member"
+// @Success 200 {object} models.GroupMember "The group member"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 409 {object} HTTPError "Conflict"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /groups/{id}/members/{user_id} [put]
+func (s *HTTPServer) handleGroupsMembersPut(c *gin.Context) {
+	id, user, ok := bindGroupMemberQuery(c, "GroupsMembersPut")
+	if !ok {
+		return
+	}
+
+	var body models.SetGroupMember
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Errorf("GroupsMembersPut: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	member := models.NewGroupMember(user, models.GroupRole(body.Role))
+
+	err := s.api.LocationUsecase.SetGroupMember(c.Request.Context(), id, member)
+	switch {
+	case err != nil:
+		abortWithError(c, "GroupsMembersPut", err, "Failed to set group member")
+		return
+	default:
+		c.JSON(http.StatusOK, member)
+	}
+}
+
+// handleGroupsMembersDelete godoc
+// @Summary Remove group member
+// @Description Remove a member from specified group.
+// @Description Owners of the group may remove any member, and members may leave the group, as long as it keeps at least one owner.
+// @Tags groups
+// @Param id path string true "Group ID"
+// @Param user_id path string true "User ID"
+// @Success 204 "OK"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 409 {object} HTTPError "Conflict"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /groups/{id}/members/{user_id} [delete]
+func (s *HTTPServer) handleGroupsMembersDelete(c *gin.Context) {
+	id, user, ok := bindGroupMemberQuery(c, "GroupsMembersDelete")
+	if !ok {
+		return
+	}
+
+	err := s.api.LocationUsecase.RemoveGroupMember(c.Request.Context(), id, user)
+	switch {
+	case err != nil:
+		abortWithError(c, "GroupsMembersDelete", err, "Failed to remove group member")
+		return
+	default:
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// bindGroupMemberQuery parses the IDs of the group and of the member, aborting the request when invalid
+func bindGroupMemberQuery(c *gin.Context, name string) (models.ID, models.ID, bool) {
+	var query models.GroupMemberQuery
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("%s: invalid query. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return models.NilID, models.NilID, false
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("%s: invalid query. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return models.NilID, models.NilID, false
+	}
+
+	user, err := models.ParseID(query.User)
+	if err != nil {
+		logger.Errorf("%s: invalid query. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return models.NilID, models.NilID, false
+	}
+
+	return id, user, true
+}
@@ -0,0 +1,277 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestV1CreateGroupWithoutName(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/groups", &gin.H{}, nil)
+
+	server.handleGroupsCreate(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1CreateGroupWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "POST", "/api/v1/groups", &gin.H{
+		"name": "Family",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateGroup", utils.MockContextMatcher, mock.AnythingOfType("*models.Group")).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*models.Group).Role = models.GroupRoleOwner
+		}).
+		Return(nil)
+
+	server.handleGroupsCreate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedGroup models.Group
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedGroup)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, models.NilID, returnedGroup.ID)
+		assert.Equal(t, "Family", returnedGroup.Name)
+		assert.Equal(t, models.GroupRoleOwner, returnedGroup.Role)
+	}
+}
+
+func TestV1GetGroupsWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/groups", nil, nil)
+
+	group := models.NewGroup(models.NewID(), "Family")
+	group.Role = models.GroupRoleMember
+	groups := &models.Groups{group}
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetGroups", utils.MockContextMatcher).
+		Return(groups, nil)
+
+	server.handleGroupsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedGroups models.Groups
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedGroups)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *groups, returnedGroups)
+	}
+}
+
+func TestV1GetGroupByIDWithNotFoundError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/groups/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindGroupByID", utils.MockContextMatcher, id).
+		Return(nil, usecases.ErrGroupNotFound)
+
+	server.handleGroupsGetByID(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1UpdateGroupWithForbiddenError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/groups/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		&gin.H{
+			"name": "Household",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UpdateGroup", utils.MockContextMatcher, models.NewGroup(id, "Household")).
+		Return(usecases.ErrForbidden)
+
+	server.handleGroupsUpdate(ctx)
+
+	assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
+}
+
+func TestV1DeleteGroupWithSuccess(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/groups/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteGroup", utils.MockContextMatcher, id).
+		Return(nil)
+
+	server.handleGroupsDelete(ctx)
+
+	assert.Equal(t, http.StatusNoContent, ctx.Writer.Status())
+}
+
+func TestV1GetGroupMembersWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/groups/4b7a536e-7109-4a39-9549-f06f74f2093e/members",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	members := &models.GroupMembers{models.NewGroupMember(models.NewID(), models.GroupRoleOwner)}
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetGroupMembers", utils.MockContextMatcher, id).
+		Return(members, nil)
+
+	server.handleGroupsMembersGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedMembers models.GroupMembers
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedMembers)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *members, returnedMembers)
+	}
+}
+
+func TestV1SetGroupMemberWithInvalidBody(t *testing.T) {
+	for _, body := range []*gin.H{
+		nil,
+		{"role": ""},
+		{"role": "editor"},
+	} {
+		ctx, _, server := newHandlerTestContext(
+			t,
+			"PUT",
+			"/api/v1/groups/4b7a536e-7109-4a39-9549-f06f74f2093e/members/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			body,
+			&[]gin.Param{
+				{
+					Key:   "id",
+					Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+				},
+				{
+					Key:   "user_id",
+					Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+				},
+			},
+		)
+
+		server.handleGroupsMembersPut(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+	}
+}
+
+func TestV1SetGroupMemberWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/groups/4b7a536e-7109-4a39-9549-f06f74f2093e/members/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+		&gin.H{
+			"role": "member",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+			{
+				Key:   "user_id",
+				Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.ParseID("9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e")
+	member := models.NewGroupMember(user, models.GroupRoleMember)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("SetGroupMember", utils.MockContextMatcher, id, member).
+		Return(nil)
+
+	server.handleGroupsMembersPut(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedMember models.GroupMember
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedMember)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *member, returnedMember)
+	}
+}
+
+func TestV1RemoveGroupMemberWithLastOwnerError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/groups/4b7a536e-7109-4a39-9549-f06f74f2093e/members/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+			{
+				Key:   "user_id",
+				Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.ParseID("9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("RemoveGroupMember", utils.MockContextMatcher, id, user).
+		Return(usecases.ErrLastGroupOwner)
+
+	server.handleGroupsMembersDelete(ctx)
+
+	assert.Equal(t, http.StatusConflict, ctx.Writer.Status())
+}
@@ -1,13 +1,20 @@
 package httpapi
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
-	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/internal/pluscodes"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	mimeNDJSON = "application/x-ndjson"
+	// Number of streamed locations written before flushing them to the client
+	streamFlushInterval = 100
+)
+
 // handleCategoriesCreate godoc
 // @Summary Create categories
 // @Description Create new categories.
@@ -17,7 +24,10 @@ import (
 // @Param category body models.CreateCategory true "New category"
 // @Success 200 {object} models.Category "The created category"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /categories [post]
 func (s *HTTPServer) handleCategoriesCreate(c *gin.Context) {
 	var body models.CreateCategory
@@ -27,19 +37,21 @@ func (s *HTTPServer) handleCategoriesCreate(c *gin.Context) {
 		return
 	}
 
+	user, ok := models.NewUserFromContext(c.Request.Context())
+	if !ok {
+		logger.Error("CategoriesCreate: failed to get user from request context.")
+		abort(c, http.StatusInternalServerError, "Failed to get user data")
+		return
+	}
+
 	cat := models.NewCategory(models.NewID(), body.Name)
+	cat.SetParent(body.Parent)
+	cat.SetUser(user.ID)
 
 	err := s.api.LocationUsecase.CreateCategory(c.Request.Context(), cat)
 	if err != nil {
-		switch err {
-		case usecases.ErrCategoryAlreadyExists:
-			abort(c, http.StatusBadRequest, "Category already exists")
-			return
-		default:
-			logger.Errorf("CategoriesCreate: failed to create category. %v", err)
-			abort(c, http.StatusInternalServerError, "Failed to create category")
-			return
-		}
+		abortWithError(c, "CategoriesCreate", err, "Failed to create category")
+		return
 	}
 
 	c.JSON(http.StatusOK, cat)
@@ -47,21 +59,33 @@ func (s *HTTPServer) handleCategoriesCreate(c *gin.Context) {
 
 // handleCategoriesGet godoc
 // @Summary Get categories
-// @Description Get all categories.
+// @Description Get all user categories, along with default categories shared by all users.
+// @Description Categories are returned as trees, nested in their parent children, when requested.
 // @Tags categories
 // @Produce  json
+// @Param tree query bool false "Return categories as trees"
 // @Success 200 {object} models.Categories "The returned categories"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /categories [get]
 func (s *HTTPServer) handleCategoriesGet(c *gin.Context) {
+	var query models.GetCategories
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Errorf("CategoriesGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
 	cats, err := s.api.LocationUsecase.GetCategories(c.Request.Context())
 
 	switch {
 	case err != nil:
-		logger.Errorf("CategoriesGet: failed to get categories. %v", err)
-		abort(c, http.StatusInternalServerError, "Failed to get categories")
+		abortWithError(c, "CategoriesGet", err, "Failed to get categories")
 		return
+	case query.Tree:
+		c.JSON(http.StatusOK, cats.Tree())
 	default:
 		c.JSON(http.StatusOK, cats)
 	}
@@ -70,14 +94,16 @@ func (s *HTTPServer) handleCategoriesGet(c *gin.Context) {
 
 // handleCategoriesGetByID godoc
 // @Summary Get category with specified ID
-// @Description Get one specific category using provided ID.
+// @Description Get one specific category using provided ID. Categories shared with the user, or holding locations visible to them, are found too.
 // @Tags categories
 // @Produce  json
 // @Param id path string true "Category ID"
 // @Success 200 {object} models.Category "The returned category"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 404 {object} HTTPError "Not found"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /categories/{id} [get]
 func (s *HTTPServer) handleCategoriesGetByID(c *gin.Context) {
 	var query models.GetCategoryByID
@@ -96,12 +122,8 @@ func (s *HTTPServer) handleCategoriesGetByID(c *gin.Context) {
 
 	cat, err := s.api.LocationUsecase.FindCategoryByID(c.Request.Context(), id)
 	switch {
-	case err == usecases.ErrCategoryNotFound:
-		abort(c, http.StatusNotFound, "Category not found")
-		return
 	case err != nil:
-		logger.Errorf("CategoriesGetByID: failed to get category %s. %v", query.ID, err)
-		abort(c, http.StatusInternalServerError, "Failed to get category")
+		abortWithError(c, "CategoriesGetByID", err, "Failed to get category")
 		return
 	default:
 		c.JSON(http.StatusOK, cat)
@@ -110,15 +132,17 @@ func (s *HTTPServer) handleCategoriesGetByID(c *gin.Context) {
 
 // handleCategoriesUpdate godoc
 // @Summary Update category
-// @Description Update specified category using provided values.
+// @Description Update specified category using provided values. Default categories are read-only, except for admins.
 // @Tags categories
 // @Produce  json
 // @Param id path string true "Category ID"
-// @Param name body models.UpdateCategory false "Category name"
+// @Param category body models.UpdateCategory false "Category name and parent"
 // @Success 200 {object} models.Category "The updated category"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 404 {object} HTTPError "Not Found"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /categories/{id} [put]
 func (s *HTTPServer) handleCategoriesUpdate(c *gin.Context) {
 	var query models.UpdateCategoryQuery
@@ -142,16 +166,21 @@ func (s *HTTPServer) handleCategoriesUpdate(c *gin.Context) {
 		return
 	}
 
+	user, ok := models.NewUserFromContext(c.Request.Context())
+	if !ok {
+		logger.Error("CategoriesUpdate: failed to get user from request context.")
+		abort(c, http.StatusInternalServerError, "Failed to get user data")
+		return
+	}
+
 	cat := models.NewCategory(id, body.Name)
+	cat.SetParent(body.Parent)
+	cat.SetUser(user.ID)
 
 	err = s.api.LocationUsecase.UpdateCategory(c.Request.Context(), cat)
 	switch {
-	case err == usecases.ErrCategoryNotFound:
-		abort(c, http.StatusNotFound, "Category not found")
-		return
 	case err != nil:
-		logger.Errorf("CategoriesUpdate: failed to update category %s. %v", id, err)
-		abort(c, http.StatusInternalServerError, "Failed to update category")
+		abortWithError(c, "CategoriesUpdate", err, "Failed to update category")
 		return
 	default:
 		c.JSON(http.StatusOK, cat)
@@ -160,13 +189,20 @@ func (s *HTTPServer) handleCategoriesUpdate(c *gin.Context) {
 
 // handleCategoriesDelete godoc
 // @Summary Delete category
-// @Description Delete one specific category using provided ID.
+// @Description Delete one specific category using provided ID. Default categories are read-only, except for admins.
+// @Description Categories that still have locations are only deleted when their locations are reassigned or deleted along with them.
+// @Description Child categories move to top level.
 // @Tags categories
 // @Param id path string true "Category ID"
+// @Param reassign_to query string false "ID of the category to move locations to"
+// @Param cascade query bool false "Delete locations along with the category"
 // @Success 204 "OK"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 404 {object} HTTPError "Not Found"
+// @Failure 409 {object} HTTPError "Conflict"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /categories/{id} [delete]
 func (s *HTTPServer) handleCategoriesDelete(c *gin.Context) {
 	var query models.DeleteCategory
@@ -176,6 +212,13 @@ func (s *HTTPServer) handleCategoriesDelete(c *gin.Context) {
 		return
 	}
 
+	var options models.DeleteCategoryOptions
+	if err := c.ShouldBindQuery(&options); err != nil {
+		logger.Errorf("CategoriesDelete: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
 	id, err := models.ParseID(query.ID)
 	if err != nil {
 		logger.Errorf("CategoriesDelete: invalid query. %v", err)
@@ -183,20 +226,82 @@ func (s *HTTPServer) handleCategoriesDelete(c *gin.Context) {
 		return
 	}
 
-	err = s.api.LocationUsecase.DeleteCategory(c.Request.Context(), id)
+	deletion := models.CategoryDeletion{Cascade: options.Cascade}
+	if options.ReassignTo != "" {
+		target, err := models.ParseID(options.ReassignTo)
+		if err != nil {
+			logger.Errorf("CategoriesDelete: invalid query. %v", err)
+			abort(c, http.StatusBadRequest, "Invalid query")
+			return
+		}
+		deletion.ReassignTo = &target
+	}
+
+	err = s.api.LocationUsecase.DeleteCategory(c.Request.Context(), id, deletion)
 	switch {
-	case err == usecases.ErrCategoryNotFound:
-		abort(c, http.StatusNotFound, "Category not found")
-		return
 	case err != nil:
-		logger.Errorf("CategoriesDelete: failed to delete category %s. %v", id, err)
-		abort(c, http.StatusInternalServerError, "Failed to delete category")
+		abortWithError(c, "CategoriesDelete", err, "Failed to delete category")
 		return
 	default:
 		c.Status(http.StatusNoContent)
 	}
 }
 
+// handleCategoriesMerge godoc
+// @Summary Merge categories
+// @Description Merge source categories into specified category. Locations and child categories of sources move to it, then sources are deleted.
+// @Description Each source is merged on its own, in a single transaction. Sources that cannot be merged are reported along with the reason.
+// @Tags categories
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Target category ID"
+// @Param sources body models.MergeCategories true "Categories to merge"
+// @Success 200 {object} models.BulkReport "Merged and failed categories"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /categories/{id}/merge [post]
+func (s *HTTPServer) handleCategoriesMerge(c *gin.Context) {
+	var query models.MergeCategoriesQuery
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("CategoriesMerge: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	var body models.MergeCategories
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Errorf("CategoriesMerge: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("CategoriesMerge: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	sources, err := models.ParseIDs(body.Sources)
+	if err != nil {
+		logger.Errorf("CategoriesMerge: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	report, err := s.api.LocationUsecase.MergeCategories(c.Request.Context(), id, sources)
+	switch {
+	case err != nil:
+		abortWithError(c, "CategoriesMerge", err, "Failed to merge categories")
+		return
+	default:
+		c.JSON(http.StatusOK, report)
+	}
+}
+
 // handleLocationsCreate godoc
 // @Summary Create locations
 // @Description Create new user locations.
@@ -206,8 +311,10 @@ func (s *HTTPServer) handleCategoriesDelete(c *gin.Context) {
 // @Param location body models.CreateLocation true "New location"
 // @Success 200 {object} models.Location "The created location"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 404 {object} HTTPError "Not Found"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /locations [post]
 func (s *HTTPServer) handleLocationsCreate(c *gin.Context) {
 	var body models.CreateLocation
@@ -225,21 +332,26 @@ func (s *HTTPServer) handleLocationsCreate(c *gin.Context) {
 	}
 
 	loc := models.NewLocation(models.NewID(), body.Name, body.Address, body.Category, user.ID)
+	if body.Latitude != nil && body.Longitude != nil {
+		loc.SetCoordinates(*body.Latitude, *body.Longitude)
+	}
+	if body.Components != nil {
+		loc.Components = *body.Components
+	}
+	loc.SetTags(body.Tags)
+	loc.SetGroup(body.Group)
+	if body.PlusCode != "" {
+		area, ok := s.resolvePlusCode(c, "LocationsCreate", body.PlusCode, body.PlusCodeReference)
+		if !ok {
+			return
+		}
+		loc.SetCoordinates(area.Center())
+	}
 
 	err := s.api.LocationUsecase.CreateLocation(c.Request.Context(), loc)
 	if err != nil {
-		switch err {
-		case usecases.ErrLocationAlreadyExists:
-			abort(c, http.StatusBadRequest, "Location already exists")
-			return
-		case usecases.ErrCategoryNotFound:
-			abort(c, http.StatusNotFound, "Category not found")
-			return
-		default:
-			logger.Errorf("LocationsCreate: failed to create location. %v", err)
-			abort(c, http.StatusInternalServerError, "Failed to create location")
-			return
-		}
+		abortWithError(c, "LocationsCreate", err, "Failed to create location")
+		return
 	}
 
 	c.JSON(http.StatusOK, loc)
@@ -247,14 +359,30 @@ func (s *HTTPServer) handleLocationsCreate(c *gin.Context) {
 
 // handleLocationsGet godoc
 // @Summary Get locations
-// @Description Get all user locations.
+// @Description Get all user locations, along with locations of their groups and locations shared with them, with the role of the user.
+// @Description Locations are streamed one JSON document per line when requesting application/x-ndjson.
+// @Description Streams cut short by an error end with a line holding only an error object.
+// @Description Locations within radius meters of near point are returned nearest first, along with their distance.
+// @Description Locations may be filtered by tags, along with category only.
 // @Tags locations
-// @Produce  json
+// @Produce  json,application/x-ndjson
 // @Param category_id query string false "Category ID"
+// @Param include_descendants query bool false "Also return locations of descendant categories, along with category_id only"
+// @Param near query string false "Search point formatted as latitude,longitude, like 48.8606111,2.3376"
+// @Param radius query number false "Search radius in meters, required along with near. Up to 200000."
+// @Param bbox query string false "Area formatted as west,south,east,north, like 2.25,48.81,2.42,48.9"
+// @Param plus_code query string false "Open Location Code of the area, like 8FW4V800+. Short codes may be followed by a locality, like V86Q+62 Paris."
+// @Param plus_code_reference query string false "ID of a location used as reference to recover short plus code"
+// @Param tags query string false "Comma separated list of tag names, like Favorite,Vegan"
+// @Param match query string false "Whether locations must have all requested tags, or any of them. Defaults to all." Enums(all, any)
+// @Param fields query string false "Comma separated list of fields to return, like id,name"
+// @Param expand query string false "Embed related object" Enums(category)
 // @Success 200 {object} models.Locations "The returned locations"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 404 {object} HTTPError "Not Found"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /locations [get]
 func (s *HTTPServer) handleLocationsGet(c *gin.Context) {
 	var query models.GetLocations
@@ -271,37 +399,231 @@ func (s *HTTPServer) handleLocationsGet(c *gin.Context) {
 		return
 	}
 
+	fields, err := query.SelectedFields()
+	if err != nil {
+		logger.Errorf("LocationsGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	near, err := query.NearPoint()
+	if err != nil {
+		logger.Errorf("LocationsGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	bbox, err := query.BoundingBox()
+	if err != nil {
+		logger.Errorf("LocationsGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	if query.PlusCode != "" {
+		refID, err := models.ParseID(query.PlusCodeReference)
+		if err != nil {
+			logger.Errorf("LocationsGet: invalid query. %v", err)
+			abort(c, http.StatusBadRequest, "Invalid query")
+			return
+		}
+
+		area, ok := s.resolvePlusCode(c, "LocationsGet", query.PlusCode, refID)
+		if !ok {
+			return
+		}
+		bbox = &models.BoundingBox{
+			West:  area.LongitudeLo,
+			South: area.LatitudeLo,
+			East:  area.LongitudeHi,
+			North: area.LatitudeHi,
+		}
+	}
+
+	spatial := near != nil || bbox != nil
+	if near != nil && bbox != nil {
+		abort(c, http.StatusBadRequest, "Bounding box is not supported with proximity search")
+		return
+	}
+	if query.IncludeDescendants && (catID == models.NilID || spatial || query.ExpandCategory()) {
+		abort(c, http.StatusBadRequest, "Descendant categories are only supported when filtering by category alone")
+		return
+	}
+	tags := query.TagNames()
+	if tags != nil && (spatial || query.ExpandCategory() || query.IncludeDescendants) {
+		abort(c, http.StatusBadRequest, "Tag filters are only supported along with category")
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, mimeNDJSON) == mimeNDJSON {
+		switch {
+		case query.ExpandCategory():
+			abort(c, http.StatusBadRequest, "Expansion is not supported when streaming")
+			return
+		case spatial:
+			abort(c, http.StatusBadRequest, "Spatial filters are not supported when streaming")
+			return
+		case query.IncludeDescendants:
+			abort(c, http.StatusBadRequest, "Descendant categories are not supported when streaming")
+			return
+		case tags != nil:
+			abort(c, http.StatusBadRequest, "Tag filters are not supported when streaming")
+			return
+		}
+		s.streamLocations(c, catID, fields)
+		return
+	}
+
 	var locations *models.Locations
-	if catID != models.NilID {
-		locations, err = s.api.LocationUsecase.FindLocationsByCategory(c.Request.Context(), catID)
-	} else {
+	switch {
+	case spatial && query.ExpandCategory():
+		abort(c, http.StatusBadRequest, "Expansion is not supported with spatial filters")
+		return
+	case near != nil:
+		locations, err = s.api.LocationUsecase.FindLocationsNear(c.Request.Context(), catID, *near, query.Radius)
+	case bbox != nil:
+		locations, err = s.api.LocationUsecase.FindLocationsInBoundingBox(c.Request.Context(), catID, *bbox)
+	case query.ExpandCategory():
+		locations, err = s.api.LocationUsecase.GetExpandedLocations(c.Request.Context(), catID)
+	case tags != nil:
+		locations, err = s.api.LocationUsecase.FindLocationsByTags(c.Request.Context(), catID, tags, query.MatchAllTags())
+	case catID != models.NilID:
+		locations, err = s.api.LocationUsecase.FindLocationsByCategory(c.Request.Context(), catID, query.IncludeDescendants)
+	default:
 		locations, err = s.api.LocationUsecase.GetLocations(c.Request.Context())
 	}
 
 	switch {
-	case err == usecases.ErrCategoryNotFound:
-		abort(c, http.StatusNotFound, "Category not found")
-		return
 	case err != nil:
-		logger.Errorf("LocationsGet: failed to get locations. %v", err)
-		abort(c, http.StatusInternalServerError, "Failed to get locations")
+		abortWithError(c, "LocationsGet", err, "Failed to get locations")
 		return
+	case fields != nil:
+		views := make([]map[string]interface{}, len(*locations))
+		for i, loc := range *locations {
+			views[i] = loc.SelectFields(fields)
+		}
+		c.JSON(http.StatusOK, views)
 	default:
 		c.JSON(http.StatusOK, locations)
 	}
 
 }
 
+// resolvePlusCode returns the area covered by a plus code, or aborts the request and returns false
+func (s *HTTPServer) resolvePlusCode(c *gin.Context, handler, code string, referenceID models.ID) (*pluscodes.CodeArea, bool) {
+	area, err := s.api.LocationUsecase.ResolvePlusCode(c.Request.Context(), code, referenceID)
+	if err != nil {
+		abortWithError(c, handler, err, "Failed to resolve plus code")
+		return nil, false
+	}
+
+	return area, true
+}
+
+// streamLocations writes user locations as newline delimited JSON while they are read from
+// the repository. Once the first location has been sent, errors are logged and told to the client
+// by a last line holding the error only, so that truncated streams are not mistaken for complete ones.
+func (s *HTTPServer) streamLocations(c *gin.Context, catID models.ID, fields []string) {
+	encoder := json.NewEncoder(c.Writer)
+	count := 0
+
+	err := s.api.LocationUsecase.StreamLocations(c.Request.Context(), catID, func(loc *models.Location) error {
+		if count == 0 {
+			c.Header("Content-Type", mimeNDJSON)
+			c.Status(http.StatusOK)
+		}
+		var view interface{} = loc
+		if fields != nil {
+			view = loc.SelectFields(fields)
+		}
+		if err := encoder.Encode(view); err != nil {
+			return err
+		}
+
+		count++
+		if count%streamFlushInterval == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+
+	switch {
+	case err == nil && count == 0:
+		c.Header("Content-Type", mimeNDJSON)
+		c.Status(http.StatusOK)
+		c.Writer.WriteHeaderNow()
+	case err == nil:
+		return
+	case count > 0:
+		logger.Errorf("LocationsGet: failed to stream locations after %d rows. %v", count, err)
+		_ = encoder.Encode(StreamError{HTTPError{Code: http.StatusInternalServerError, Message: "Failed to get locations"}})
+		c.Abort()
+	default:
+		abortWithError(c, "LocationsGet", err, "Failed to get locations")
+	}
+}
+
+// handleLocationsGetClusters godoc
+// @Summary Get clusters of locations
+// @Description Group user locations inside a map viewport into clusters, with their count and centroid.
+// @Description Clusters are geohash cells sized for the zoom level, so that overlapping markers are merged.
+// @Tags locations
+// @Produce  json
+// @Param bbox query string true "Area formatted as west,south,east,north, like 2.25,48.81,2.42,48.9"
+// @Param zoom query int true "Map zoom level, from 0 to 22"
+// @Param category_id query string false "Category ID"
+// @Success 200 {object} models.Clusters "The returned clusters"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /locations/clusters [get]
+func (s *HTTPServer) handleLocationsGetClusters(c *gin.Context) {
+	var query models.GetLocationClusters
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Errorf("LocationsGetClusters: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	catID, err := models.ParseID(query.Category)
+	if err != nil {
+		logger.Errorf("LocationsGetClusters: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	bbox, err := models.ParseBoundingBox(query.BBox)
+	if err != nil {
+		logger.Errorf("LocationsGetClusters: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	clusters, err := s.api.LocationUsecase.GetLocationClusters(c.Request.Context(), catID, bbox, *query.Zoom)
+	if err != nil {
+		abortWithError(c, "LocationsGetClusters", err, "Failed to get clusters")
+		return
+	}
+
+	c.JSON(http.StatusOK, clusters)
+}
+
 // handleLocationsGetByID godoc
 // @Summary Get location with specified ID
 // @Description Get one specific location using provided ID.
 // @Tags locations
 // @Produce  json
 // @Param id path string true "Location ID"
+// @Param fields query string false "Comma separated list of fields to return, like id,name"
+// @Param expand query string false "Embed related object" Enums(category)
 // @Success 200 {object} models.Location "The returned location"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 404 {object} HTTPError "Not found"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /locations/{id} [get]
 func (s *HTTPServer) handleLocationsGetByID(c *gin.Context) {
 	var query models.GetLocationByID
@@ -318,15 +640,32 @@ func (s *HTTPServer) handleLocationsGetByID(c *gin.Context) {
 		return
 	}
 
-	loc, err := s.api.LocationUsecase.FindLocationByID(c.Request.Context(), id)
-	switch {
-	case err == usecases.ErrLocationNotFound:
-		abort(c, http.StatusNotFound, "Location not found")
+	var view models.LocationView
+	if err := c.ShouldBindQuery(&view); err != nil {
+		logger.Errorf("LocationsGetByID: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
 		return
+	}
+
+	fields, err := view.SelectedFields()
+	if err != nil {
+		logger.Errorf("LocationsGetByID: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	var loc *models.Location
+	if view.ExpandCategory() {
+		loc, err = s.api.LocationUsecase.FindExpandedLocationByID(c.Request.Context(), id)
+	} else {
+		loc, err = s.api.LocationUsecase.FindLocationByID(c.Request.Context(), id)
+	}
+	switch {
 	case err != nil:
-		logger.Errorf("LocationsGetByID: failed to get location %s. %v", query.ID, err)
-		abort(c, http.StatusInternalServerError, "Failed to get location")
+		abortWithError(c, "LocationsGetByID", err, "Failed to get location")
 		return
+	case fields != nil:
+		c.JSON(http.StatusOK, loc.SelectFields(fields))
 	default:
 		c.JSON(http.StatusOK, loc)
 	}
@@ -341,8 +680,10 @@ func (s *HTTPServer) handleLocationsGetByID(c *gin.Context) {
 // @Param location body models.UpdateLocation true "Updated location"
 // @Success 200 {object} models.Location "The updated location"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 404 {object} HTTPError "Not Found"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /locations/{id} [put]
 func (s *HTTPServer) handleLocationsUpdate(c *gin.Context) {
 	var query models.UpdateLocationQuery
@@ -374,18 +715,18 @@ func (s *HTTPServer) handleLocationsUpdate(c *gin.Context) {
 	}
 
 	loc := models.NewLocation(id, body.Name, body.Address, body.Category, user.ID)
+	if body.Latitude != nil && body.Longitude != nil {
+		loc.SetCoordinates(*body.Latitude, *body.Longitude)
+	}
+	if body.Components != nil {
+		loc.Components = *body.Components
+	}
+	loc.SetTags(body.Tags)
 
 	err = s.api.LocationUsecase.UpdateLocation(c.Request.Context(), loc)
 	switch {
-	case err == usecases.ErrLocationNotFound:
-		abort(c, http.StatusNotFound, "Location not found")
-		return
-	case err == usecases.ErrCategoryNotFound:
-		abort(c, http.StatusNotFound, "Category not found")
-		return
 	case err != nil:
-		logger.Errorf("LocationsUpdate: failed to update location %s. %v", id, err)
-		abort(c, http.StatusInternalServerError, "Failed to update location")
+		abortWithError(c, "LocationsUpdate", err, "Failed to update location")
 		return
 	default:
 		c.JSON(http.StatusOK, loc)
@@ -399,8 +740,10 @@ func (s *HTTPServer) handleLocationsUpdate(c *gin.Context) {
 // @Param id path string true "Location ID"
 // @Success 204 "OK"
 // @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
 // @Failure 404 {object} HTTPError "Not Found"
 // @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
 // @Router /locations/{id} [delete]
 func (s *HTTPServer) handleLocationsDelete(c *gin.Context) {
 	var query models.DeleteLocation
@@ -419,14 +762,56 @@ func (s *HTTPServer) handleLocationsDelete(c *gin.Context) {
 
 	err = s.api.LocationUsecase.DeleteLocation(c.Request.Context(), id)
 	switch {
-	case err == usecases.ErrLocationNotFound:
-		abort(c, http.StatusNotFound, "Location not found")
-		return
 	case err != nil:
-		logger.Errorf("LocationsDelete: failed to delete location %s. %v", id, err)
-		abort(c, http.StatusInternalServerError, "Failed to delete location")
+		abortWithError(c, "LocationsDelete", err, "Failed to delete location")
 		return
 	default:
 		c.Status(http.StatusNoContent)
 	}
 }
+
+// handleLocationsBulkMove godoc
+// @Summary Move locations
+// @Description Move many user locations to specified category at once. Locations that cannot be found are reported as failed.
+// @Tags locations
+// @Accept  json
+// @Produce  json
+// @Param locations body models.MoveLocations true "Locations to move"
+// @Success 200 {object} models.BulkReport "Moved and failed locations"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /locations/bulk-move [post]
+func (s *HTTPServer) handleLocationsBulkMove(c *gin.Context) {
+	var body models.MoveLocations
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Errorf("LocationsBulkMove: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	ids, err := models.ParseIDs(body.IDs)
+	if err != nil {
+		logger.Errorf("LocationsBulkMove: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	catID, err := models.ParseID(body.Category)
+	if err != nil {
+		logger.Errorf("LocationsBulkMove: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	report, err := s.api.LocationUsecase.MoveLocations(c.Request.Context(), ids, catID)
+	switch {
+	case err != nil:
+		abortWithError(c, "LocationsBulkMove", err, "Failed to move locations")
+		return
+	default:
+		c.JSON(http.StatusOK, report)
+	}
+}
@@ -3,11 +3,15 @@ package httpapi
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/edebernis/social-life-manager/services/location/internal/api"
 	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/pluscodes"
 	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
 	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
 	"github.com/gin-gonic/gin"
@@ -37,6 +41,30 @@ func TestV1CreateCategoryWithAlreadyExistsError(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
 }
 
+func TestV1CreateCategoryWithParentNotFoundError(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "POST", "/api/v1/categories", &gin.H{
+		"name":      "Test Category",
+		"parent_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateCategory", utils.MockContextMatcher, mock.AnythingOfType("*models.Category")).
+		Return(fmt.Errorf("CreateCategory: %w", usecases.ErrParentCategoryNotFound))
+
+	server.handleCategoriesCreate(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+
+	var httpError HTTPError
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &httpError))
+	assert.Equal(t, HTTPError{
+		Code:    http.StatusNotFound,
+		Message: "Parent category not found",
+		Type:    "not-found",
+		Field:   "parent_id",
+	}, httpError)
+}
+
 func TestV1CreateCategoryWithError(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/categories", &gin.H{
 		"name": "Test Category",
@@ -95,6 +123,29 @@ func TestV1GetCategoriesWithSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
 }
 
+func TestV1GetCategoriesAsTree(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/categories?tree=true", nil, nil)
+
+	sport := models.NewCategory(models.NewID(), "Sport")
+	tennis := models.NewCategory(models.NewID(), "Tennis")
+	tennis.SetParent(sport.ID)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetCategories", utils.MockContextMatcher).
+		Return(&models.Categories{tennis, sport}, nil)
+
+	server.handleCategoriesGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedCats models.Categories
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedCats)
+	if assert.NoError(t, err) && assert.Len(t, returnedCats, 1) && assert.Len(t, returnedCats[0].Children, 1) {
+		assert.Equal(t, sport.ID, returnedCats[0].ID)
+		assert.Equal(t, tennis.ID, returnedCats[0].Children[0].ID)
+	}
+}
+
 func TestV1GetCategoriesByIDWithError(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(
 		t,
@@ -210,6 +261,69 @@ func TestV1GetCategoriesByIDWithSuccess(t *testing.T) {
 	}
 }
 
+func TestV1UpdateCategoryWithCycle(t *testing.T) {
+	parentID := models.NewID()
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		&gin.H{
+			"name":      "Test Category",
+			"parent_id": parentID.String(),
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	cat := models.NewCategory(id, "Test Category")
+	cat.SetParent(parentID)
+	cat.SetUser(user.ID)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UpdateCategory", utils.MockContextMatcher, cat).
+		Return(usecases.ErrCategoryCycle)
+
+	server.handleCategoriesUpdate(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1UpdateCategoryWithDefaultCategory(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		&gin.H{
+			"name": "Test Category",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	cat := models.NewCategory(id, "Test Category")
+	cat.SetUser(user.ID)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UpdateCategory", utils.MockContextMatcher, cat).
+		Return(usecases.ErrCategoryReadOnly)
+
+	server.handleCategoriesUpdate(ctx)
+
+	assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
+}
+
 func TestV1UpdateCategoryWithError(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(
 		t,
@@ -227,7 +341,9 @@ func TestV1UpdateCategoryWithError(t *testing.T) {
 	)
 
 	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
 	cat := models.NewCategory(id, "Test Category")
+	cat.SetUser(user.ID)
 
 	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
 		On("UpdateCategory", utils.MockContextMatcher, cat).
@@ -255,7 +371,9 @@ func TestV1UpdateCategoryWithCategoryNotFound(t *testing.T) {
 	)
 
 	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
 	cat := models.NewCategory(id, "Test Category")
+	cat.SetUser(user.ID)
 
 	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
 		On("UpdateCategory", utils.MockContextMatcher, cat).
@@ -339,7 +457,9 @@ func TestV1UpdateCategoryWithSuccess(t *testing.T) {
 	)
 
 	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
 	cat := models.NewCategory(id, "Test Category")
+	cat.SetUser(user.ID)
 
 	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
 		On("UpdateCategory", utils.MockContextMatcher, cat).
@@ -356,6 +476,31 @@ func TestV1UpdateCategoryWithSuccess(t *testing.T) {
 	}
 }
 
+func TestV1DeleteCategoryWithDefaultCategory(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteCategory", utils.MockContextMatcher, id, models.CategoryDeletion{}).
+		Return(usecases.ErrCategoryReadOnly)
+
+	server.handleCategoriesDelete(ctx)
+
+	assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
+}
+
 func TestV1DeleteCategoryWithError(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(
 		t,
@@ -373,7 +518,7 @@ func TestV1DeleteCategoryWithError(t *testing.T) {
 	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
 
 	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
-		On("DeleteCategory", utils.MockContextMatcher, id).
+		On("DeleteCategory", utils.MockContextMatcher, id, models.CategoryDeletion{}).
 		Return(errors.New(("failed")))
 
 	server.handleCategoriesDelete(ctx)
@@ -398,7 +543,7 @@ func TestV1DeleteCategoryWithCategoryNotFound(t *testing.T) {
 	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
 
 	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
-		On("DeleteCategory", utils.MockContextMatcher, id).
+		On("DeleteCategory", utils.MockContextMatcher, id, models.CategoryDeletion{}).
 		Return(usecases.ErrCategoryNotFound)
 
 	server.handleCategoriesDelete(ctx)
@@ -406,6 +551,134 @@ func TestV1DeleteCategoryWithCategoryNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
 }
 
+func TestV1DeleteCategoryWithLocationsLeft(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteCategory", utils.MockContextMatcher, id, models.CategoryDeletion{}).
+		Return(&usecases.CategoryInUseError{Locations: 3})
+
+	server.handleCategoriesDelete(ctx)
+
+	assert.Equal(t, http.StatusConflict, ctx.Writer.Status())
+	assert.Contains(t, resp.Body.String(), "Category still has 3 locations")
+}
+
+func TestV1DeleteCategoryWithReassign(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e?reassign_to=550e8400-e29b-41d4-a716-446655440000",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	target, _ := models.ParseID("550e8400-e29b-41d4-a716-446655440000")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteCategory", utils.MockContextMatcher, id, models.CategoryDeletion{ReassignTo: &target}).
+		Return(nil)
+
+	server.handleCategoriesDelete(ctx)
+
+	assert.Equal(t, http.StatusNoContent, ctx.Writer.Status())
+}
+
+func TestV1DeleteCategoryWithTargetCategoryNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e?reassign_to=550e8400-e29b-41d4-a716-446655440000",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	target, _ := models.ParseID("550e8400-e29b-41d4-a716-446655440000")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteCategory", utils.MockContextMatcher, id, models.CategoryDeletion{ReassignTo: &target}).
+		Return(usecases.ErrTargetCategoryNotFound)
+
+	server.handleCategoriesDelete(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1DeleteCategoryWithCascade(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e?cascade=true",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteCategory", utils.MockContextMatcher, id, models.CategoryDeletion{Cascade: true}).
+		Return(nil)
+
+	server.handleCategoriesDelete(ctx)
+
+	assert.Equal(t, http.StatusNoContent, ctx.Writer.Status())
+}
+
+func TestV1DeleteCategoryWithInvalidOptions(t *testing.T) {
+	for _, options := range []string{
+		"reassign_to=123",
+		"cascade=maybe",
+		"cascade=true&reassign_to=550e8400-e29b-41d4-a716-446655440000",
+	} {
+		ctx, _, server := newHandlerTestContext(
+			t,
+			"DELETE",
+			"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e?"+options,
+			nil,
+			&[]gin.Param{
+				{
+					Key:   "id",
+					Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+				},
+			},
+		)
+
+		server.handleCategoriesDelete(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), options)
+	}
+}
+
 func TestV1DeleteCategoryWithInvalidID(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(
 		t,
@@ -456,7 +729,7 @@ func TestV1DeleteCategoryWithSuccess(t *testing.T) {
 	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
 
 	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
-		On("DeleteCategory", utils.MockContextMatcher, id).
+		On("DeleteCategory", utils.MockContextMatcher, id, models.CategoryDeletion{}).
 		Return(nil)
 
 	server.handleCategoriesDelete(ctx)
@@ -464,6 +737,92 @@ func TestV1DeleteCategoryWithSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, ctx.Writer.Status())
 }
 
+func TestV1MergeCategoriesWithInvalidBody(t *testing.T) {
+	for _, payload := range []*gin.H{
+		{},
+		{"sources": []string{}},
+		{"sources": []string{"123"}},
+	} {
+		ctx, _, server := newHandlerTestContext(
+			t,
+			"POST",
+			"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e/merge",
+			payload,
+			&[]gin.Param{
+				{
+					Key:   "id",
+					Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+				},
+			},
+		)
+
+		server.handleCategoriesMerge(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), payload)
+	}
+}
+
+func TestV1MergeCategoriesWithCategoryNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"POST",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e/merge",
+		&gin.H{"sources": []string{"550e8400-e29b-41d4-a716-446655440000"}},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	source, _ := models.ParseID("550e8400-e29b-41d4-a716-446655440000")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("MergeCategories", utils.MockContextMatcher, id, []models.ID{source}).
+		Return(nil, usecases.ErrCategoryNotFound)
+
+	server.handleCategoriesMerge(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1MergeCategoriesWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"POST",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e/merge",
+		&gin.H{"sources": []string{"550e8400-e29b-41d4-a716-446655440000", "6ba7b810-9dad-41d1-80b4-00c04fd430c8"}},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	sources, _ := models.ParseIDs([]string{"550e8400-e29b-41d4-a716-446655440000", "6ba7b810-9dad-41d1-80b4-00c04fd430c8"})
+
+	report := models.NewBulkReport()
+	report.Succeed(sources[0])
+	report.Fail(sources[1], usecases.ErrCategoryReadOnly)
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("MergeCategories", utils.MockContextMatcher, id, sources).
+		Return(report, nil)
+
+	server.handleCategoriesMerge(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedReport models.BulkReport
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedReport)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *report, returnedReport)
+	}
+}
+
 func TestV1CreateLocationWithoutName(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
 		"address":     "1 rue de la Poste, 75001 Paris",
@@ -557,6 +916,22 @@ func TestV1CreateLocationWithError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, ctx.Writer.Status())
 }
 
+func TestV1CreateLocationWithOverloadedError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+		"name":        "Test Location",
+		"address":     "1 rue de la Poste, 75001 Paris",
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateLocation", utils.MockContextMatcher, mock.AnythingOfType("*models.Location")).
+		Return(api.ErrOverloaded)
+
+	server.handleLocationsCreate(ctx)
+
+	assert.Equal(t, http.StatusServiceUnavailable, ctx.Writer.Status())
+}
+
 func TestV1CreateLocationWithSuccess(t *testing.T) {
 	ctx, resp, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
 		"name":        "Test Location",
@@ -584,11 +959,198 @@ func TestV1CreateLocationWithSuccess(t *testing.T) {
 	}
 }
 
-func TestV1GetLocationsWithError(t *testing.T) {
-	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations", nil, nil)
-
-	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
-		On("GetLocations", utils.MockContextMatcher).
+func TestV1CreateLocationWithCoordinates(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+		"name":        "Test Location",
+		"address":     "1 rue de la Poste, 75001 Paris",
+		"latitude":    48.8606111,
+		"longitude":   2.3376,
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateLocation", utils.MockContextMatcher, mock.AnythingOfType("*models.Location")).
+		Return(nil)
+
+	server.handleLocationsCreate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var loc models.Location
+	err := json.NewDecoder(resp.Result().Body).Decode(&loc)
+	if assert.NoError(t, err) && assert.NotNil(t, loc.Latitude) && assert.NotNil(t, loc.Longitude) {
+		assert.Equal(t, 48.8606111, *loc.Latitude)
+		assert.Equal(t, 2.3376, *loc.Longitude)
+	}
+}
+
+func TestV1CreateLocationWithCoordinatesOnly(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+		"name":        "Test Location",
+		"latitude":    48.8606111,
+		"longitude":   2.3376,
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateLocation", utils.MockContextMatcher, mock.MatchedBy(func(loc *models.Location) bool {
+			return loc.Address == "" && loc.Latitude != nil && loc.Longitude != nil
+		})).
+		Return(nil)
+
+	server.handleLocationsCreate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+}
+
+func TestV1CreateLocationWithInvalidCoordinates(t *testing.T) {
+	for _, coordinates := range []gin.H{
+		{"latitude": 90.5, "longitude": 2.3376},
+		{"latitude": 48.8606111, "longitude": -180.5},
+		{"latitude": 48.86061112, "longitude": 2.3376},
+		{"latitude": 48.8606111},
+	} {
+		body := gin.H{
+			"name":        "Test Location",
+			"address":     "1 rue de la Poste, 75001 Paris",
+			"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+		}
+		for k, v := range coordinates {
+			body[k] = v
+		}
+		ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &body, nil)
+
+		server.handleLocationsCreate(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), coordinates)
+	}
+}
+
+func TestV1CreateLocationWithAddressComponentsOnly(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+		"name": "Test Location",
+		"address_components": gin.H{
+			"street":       "Rue de la Poste",
+			"house_number": "1",
+			"postal_code":  "75001",
+			"locality":     "Paris",
+			"country_code": "FR",
+		},
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateLocation", utils.MockContextMatcher, mock.MatchedBy(func(loc *models.Location) bool {
+			return loc.Address == "" && loc.Components.PostalCode == "75001" && loc.Components.CountryCode == "FR"
+		})).
+		Return(nil)
+
+	server.handleLocationsCreate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+}
+
+func TestV1CreateLocationWithInvalidAddressComponents(t *testing.T) {
+	for _, components := range []gin.H{
+		{"locality": "Paris", "country_code": "fr"},
+		{"locality": "Paris", "country_code": "XX"},
+		{"postal_code": "750017500175001750017500175001"},
+	} {
+		ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+			"name":               "Test Location",
+			"address":            "1 rue de la Poste, 75001 Paris",
+			"address_components": components,
+			"category_id":        "4b7a536e-7109-4a39-9549-f06f74f2093e",
+		}, nil)
+
+		server.handleLocationsCreate(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), components)
+	}
+}
+
+func TestV1CreateLocationWithPlusCode(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+		"name":        "Test Location",
+		"plus_code":   "8FW4V86Q+62",
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	area, _ := pluscodes.Decode("8FW4V86Q+62")
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ResolvePlusCode", utils.MockContextMatcher, "8FW4V86Q+62", models.NilID).
+		Return(&area, nil)
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateLocation", utils.MockContextMatcher, mock.MatchedBy(func(loc *models.Location) bool {
+			return loc.Latitude != nil && loc.Longitude != nil && loc.EncodePlusCode() == "8FW4V86Q+62"
+		})).
+		Return(nil)
+
+	server.handleLocationsCreate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+}
+
+func TestV1CreateLocationWithInvalidPlusCode(t *testing.T) {
+	for _, plusCode := range []gin.H{
+		{"plus_code": "8FW4V86Q62"},
+		{"plus_code": "8FW4V86Q+6"},
+		{"plus_code": "8FW4V86Q+62", "latitude": 48.8606111, "longitude": 2.3376},
+	} {
+		body := gin.H{
+			"name":        "Test Location",
+			"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+		}
+		for k, v := range plusCode {
+			body[k] = v
+		}
+		ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &body, nil)
+
+		server.handleLocationsCreate(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), plusCode)
+	}
+}
+
+func TestV1CreateLocationWithPlusCodeReferenceNotFoundError(t *testing.T) {
+	refID := models.NewID()
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+		"name":                "Test Location",
+		"plus_code":           "V86Q+62",
+		"plus_code_reference": refID.String(),
+		"category_id":         "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ResolvePlusCode", utils.MockContextMatcher, "V86Q+62", refID).
+		Return(nil, usecases.ErrLocationNotFound)
+
+	server.handleLocationsCreate(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1CreateLocationWithPlusCodeReferenceRequiredError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+		"name":        "Test Location",
+		"plus_code":   "V86Q+62",
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ResolvePlusCode", utils.MockContextMatcher, "V86Q+62", models.NilID).
+		Return(nil, usecases.ErrPlusCodeReferenceRequired)
+
+	server.handleLocationsCreate(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsWithError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations", nil, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocations", utils.MockContextMatcher).
 		Return(nil, errors.New("failed"))
 
 	server.handleLocationsGet(ctx)
@@ -596,6 +1158,18 @@ func TestV1GetLocationsWithError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, ctx.Writer.Status())
 }
 
+func TestV1GetLocationsWithOverloadedError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations", nil, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocations", utils.MockContextMatcher).
+		Return(nil, api.ErrOverloaded)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusServiceUnavailable, ctx.Writer.Status())
+}
+
 func TestV1GetLocationsByCategoryWithCategoryNotFound(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(
 		t,
@@ -608,7 +1182,7 @@ func TestV1GetLocationsByCategoryWithCategoryNotFound(t *testing.T) {
 	catID, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
 
 	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
-		On("FindLocationsByCategory", utils.MockContextMatcher, catID).
+		On("FindLocationsByCategory", utils.MockContextMatcher, catID, false).
 		Return(nil, usecases.ErrCategoryNotFound)
 
 	server.handleLocationsGet(ctx)
@@ -650,7 +1224,7 @@ func TestV1GetLocationsByCategoryWithSuccess(t *testing.T) {
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", catID, user.ID)
 
 	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
-		On("FindLocationsByCategory", utils.MockContextMatcher, catID).
+		On("FindLocationsByCategory", utils.MockContextMatcher, catID, false).
 		Return(&models.Locations{loc}, nil)
 
 	server.handleLocationsGet(ctx)
@@ -664,6 +1238,395 @@ func TestV1GetLocationsByCategoryWithSuccess(t *testing.T) {
 	}
 }
 
+func TestV1GetLocationsByCategoryWithDescendants(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations?category_id=4b7a536e-7109-4a39-9549-f06f74f2093e&include_descendants=true",
+		nil,
+		nil,
+	)
+
+	catID, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationsByCategory", utils.MockContextMatcher, catID, true).
+		Return(&models.Locations{}, nil)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsWithInvalidDescendants(t *testing.T) {
+	for _, url := range []string{
+		"/api/v1/locations?include_descendants=true",
+		"/api/v1/locations?category_id=4b7a536e-7109-4a39-9549-f06f74f2093e&include_descendants=true&expand=category",
+		"/api/v1/locations?category_id=4b7a536e-7109-4a39-9549-f06f74f2093e&include_descendants=true&bbox=2.25,48.81,2.42,48.9",
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", url, nil, nil)
+
+		server.handleLocationsGet(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), url)
+	}
+}
+
+func TestV1GetLocationsWithInvalidFields(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations?fields=id,unknown", nil, nil)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsWithInvalidExpand(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations?expand=user", nil, nil)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsWithFieldsAndExpand(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/locations?fields=id,name&expand=category", nil, nil)
+
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc.ExpandedCategory = cat
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetExpandedLocations", utils.MockContextMatcher, models.NilID).
+		Return(&models.Locations{loc}, nil)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedLocs []map[string]interface{}
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedLocs)
+	if assert.NoError(t, err) && assert.Len(t, returnedLocs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"id":       loc.ID.String(),
+			"name":     loc.Name,
+			"category": map[string]interface{}{"id": cat.ID.String(), "name": cat.Name},
+		}, returnedLocs[0])
+	}
+}
+
+func TestV1GetLocationsNearWithInvalidQuery(t *testing.T) {
+	for _, url := range []string{
+		"/api/v1/locations?near=48.8606111,2.3376",
+		"/api/v1/locations?near=48.8606111&radius=5000",
+		"/api/v1/locations?near=95,2.3376&radius=5000",
+		"/api/v1/locations?near=48.8606111,2.3376&radius=-1",
+		"/api/v1/locations?near=48.8606111,2.3376&radius=500000",
+		"/api/v1/locations?near=48.8606111,2.3376&radius=5000&expand=category",
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", url, nil, nil)
+
+		server.handleLocationsGet(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), url)
+	}
+}
+
+func TestV1GetLocationsNearWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations?near=48.8606,2.3377&radius=5000&category_id=4b7a536e-7109-4a39-9549-f06f74f2093e&fields=id,distance",
+		nil,
+		nil,
+	)
+
+	catID, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", catID, user.ID)
+	distance := 7.5
+	loc.Distance = &distance
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationsNear", utils.MockContextMatcher, catID, models.NewPoint(48.8606, 2.3377), 5000.0).
+		Return(&models.Locations{loc}, nil)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedLocs []map[string]interface{}
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedLocs)
+	if assert.NoError(t, err) && assert.Len(t, returnedLocs, 1) {
+		assert.Equal(t, map[string]interface{}{"id": loc.ID.String(), "distance": 7.5}, returnedLocs[0])
+	}
+}
+
+func TestV1GetLocationsInBoundingBoxWithInvalidQuery(t *testing.T) {
+	for _, url := range []string{
+		"/api/v1/locations?bbox=2.25,48.81,2.42",
+		"/api/v1/locations?bbox=2.25,48.9,2.42,48.81",
+		"/api/v1/locations?bbox=2.25,48.81,2.42,48.9&near=48.8606111,2.3376&radius=5000",
+		"/api/v1/locations?bbox=2.25,48.81,2.42,48.9&expand=category",
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", url, nil, nil)
+
+		server.handleLocationsGet(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), url)
+	}
+}
+
+func TestV1GetLocationsInBoundingBoxWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/locations?bbox=2.25,48.81,2.42,48.9", nil, nil)
+
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	loc.SetCoordinates(48.8606111, 2.3376)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationsInBoundingBox", utils.MockContextMatcher, models.NilID, models.BoundingBox{West: 2.25, South: 48.81, East: 2.42, North: 48.9}).
+		Return(&models.Locations{loc}, nil)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedLocs models.Locations
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedLocs)
+	if assert.NoError(t, err) {
+		loc.PlusCode = "8FW4V86Q+62"
+		assert.Equal(t, models.Locations{loc}, returnedLocs)
+	}
+}
+
+func TestV1GetLocationsByPlusCodeWithInvalidQuery(t *testing.T) {
+	for _, url := range []string{
+		"/api/v1/locations?plus_code=8FW4V8",
+		"/api/v1/locations?plus_code=8FW4V800%2B&bbox=2.25,48.81,2.42,48.9",
+		"/api/v1/locations?plus_code=V86Q%2B62&plus_code_reference=abc",
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", url, nil, nil)
+
+		server.handleLocationsGet(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), url)
+	}
+}
+
+func TestV1GetLocationsByPlusCodeWithReferenceRequiredError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations?plus_code=V86Q%2B62", nil, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ResolvePlusCode", utils.MockContextMatcher, "V86Q+62", models.NilID).
+		Return(nil, usecases.ErrPlusCodeReferenceRequired)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsByPlusCodeWithSuccess(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations?plus_code=8FW4V800%2B", nil, nil)
+
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	loc.SetCoordinates(48.8606111, 2.3376)
+
+	area, _ := pluscodes.Decode("8FW4V800+")
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ResolvePlusCode", utils.MockContextMatcher, "8FW4V800+", models.NilID).
+		Return(&area, nil)
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationsInBoundingBox", utils.MockContextMatcher, models.NilID, models.BoundingBox{
+			West:  area.LongitudeLo,
+			South: area.LatitudeLo,
+			East:  area.LongitudeHi,
+			North: area.LatitudeHi,
+		}).
+		Return(&models.Locations{loc}, nil)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+}
+
+func TestV1GetLocationClustersWithInvalidQuery(t *testing.T) {
+	for _, url := range []string{
+		"/api/v1/locations/clusters?zoom=12",
+		"/api/v1/locations/clusters?bbox=2.25,48.81,2.42,48.9",
+		"/api/v1/locations/clusters?bbox=2.25,48.81,2.42,48.9&zoom=23",
+		"/api/v1/locations/clusters?bbox=2.25,48.81,2.42&zoom=12",
+		"/api/v1/locations/clusters?bbox=2.25,48.81,2.42,48.9&zoom=12&category_id=invalid",
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", url, nil, nil)
+
+		server.handleLocationsGetClusters(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), url)
+	}
+}
+
+func TestV1GetLocationClustersWithCategoryNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations/clusters?bbox=2.25,48.81,2.42,48.9&zoom=12&category_id=4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		nil,
+	)
+
+	catID, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocationClusters", utils.MockContextMatcher, catID, models.BoundingBox{West: 2.25, South: 48.81, East: 2.42, North: 48.9}, 12).
+		Return(nil, usecases.ErrCategoryNotFound)
+
+	server.handleLocationsGetClusters(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1GetLocationClustersWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/locations/clusters?bbox=2.25,48.81,2.42,48.9&zoom=0", nil, nil)
+
+	clusters := models.Clusters{models.NewCluster("u", 3, 48.86, 2.33)}
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocationClusters", utils.MockContextMatcher, models.NilID, models.BoundingBox{West: 2.25, South: 48.81, East: 2.42, North: 48.9}, 0).
+		Return(&clusters, nil)
+
+	server.handleLocationsGetClusters(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedClusters models.Clusters
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedClusters)
+	if assert.NoError(t, err) {
+		assert.Equal(t, clusters, returnedClusters)
+	}
+}
+
+func TestV1GetLocationsAsNDJSONNear(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations?near=48.8606,2.3377&radius=5000", nil, nil)
+	ctx.Request.Header.Set("Accept", mimeNDJSON)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsAsNDJSONWithExpand(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations?expand=category", nil, nil)
+	ctx.Request.Header.Set("Accept", mimeNDJSON)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsAsNDJSONWithCategoryNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations?category_id=4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		nil,
+	)
+	ctx.Request.Header.Set("Accept", mimeNDJSON)
+
+	catID, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("StreamLocations", utils.MockContextMatcher, catID, mock.Anything).
+		Return(usecases.ErrCategoryNotFound)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsAsNDJSONWithErrorWhileStreaming(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/locations", nil, nil)
+	ctx.Request.Header.Set("Accept", mimeNDJSON)
+
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("StreamLocations", utils.MockContextMatcher, models.NilID, mock.Anything).
+		Return(errors.New("failed")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*models.Location) error)
+			_ = fn(loc)
+		})
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.True(t, ctx.IsAborted())
+
+	decoder := json.NewDecoder(resp.Result().Body)
+	var returnedLoc models.Location
+	if assert.NoError(t, decoder.Decode(&returnedLoc)) {
+		assert.Equal(t, *loc, returnedLoc)
+	}
+	// Truncation is told by a last line holding the error
+	var streamErr StreamError
+	if assert.NoError(t, decoder.Decode(&streamErr)) {
+		assert.Equal(t, http.StatusInternalServerError, streamErr.Error.Code)
+	}
+	assert.False(t, decoder.More())
+}
+
+func TestV1GetLocationsAsNDJSONWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/locations", nil, nil)
+	ctx.Request.Header.Set("Accept", mimeNDJSON)
+
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	locs := models.Locations{
+		models.NewLocation(models.NewID(), "Test Location 1", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID),
+		models.NewLocation(models.NewID(), "Test Location 2", "2 rue de la Poste, 75001 Paris", models.NewID(), user.ID),
+	}
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("StreamLocations", utils.MockContextMatcher, models.NilID, mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*models.Location) error)
+			for _, loc := range locs {
+				_ = fn(loc)
+			}
+		})
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Equal(t, mimeNDJSON, resp.Header().Get("Content-Type"))
+
+	lines := strings.Split(strings.TrimSpace(resp.Body.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		for i, line := range lines {
+			var returnedLoc models.Location
+			assert.NoError(t, json.Unmarshal([]byte(line), &returnedLoc))
+			assert.Equal(t, *locs[i], returnedLoc)
+		}
+	}
+}
+
+func TestV1GetLocationsAsNDJSONWithoutLocations(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/locations", nil, nil)
+	ctx.Request.Header.Set("Accept", mimeNDJSON)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("StreamLocations", utils.MockContextMatcher, models.NilID, mock.Anything).
+		Return(nil)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, mimeNDJSON, resp.Header().Get("Content-Type"))
+	assert.Empty(t, resp.Body.String())
+}
+
 func TestV1GetLocationsByIDWithError(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(
 		t,
@@ -844,6 +1807,38 @@ func TestV1UpdateLocationWithCategoryNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
 }
 
+func TestV1UpdateLocationWithLocationAlreadyExists(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		&gin.H{
+			"name":        "Test Location",
+			"address":     "1 rue de la Poste, 75001 Paris",
+			"category_id": "550e8400-e29b-41d4-a716-446655440000",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	catID, _ := models.ParseID("550e8400-e29b-41d4-a716-446655440000")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	loc := models.NewLocation(id, "Test Location", "1 rue de la Poste, 75001 Paris", catID, user.ID)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UpdateLocation", utils.MockContextMatcher, loc).
+		Return(usecases.ErrLocationAlreadyExists)
+
+	server.handleLocationsUpdate(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
 func TestV1UpdateLocationWithLocationNotFound(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(
 		t,
@@ -922,6 +1917,59 @@ func TestV1UpdateLocationWithInvalidCategory(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
 }
 
+func TestV1GetLocationsByIDWithExpand(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e?expand=category",
+		nil,
+		&[]gin.Param{{Key: "id", Value: "4b7a536e-7109-4a39-9549-f06f74f2093e"}},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(id, "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc.ExpandedCategory = cat
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindExpandedLocationByID", utils.MockContextMatcher, id).
+		Return(loc, nil)
+
+	server.handleLocationsGetByID(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedLoc models.Location
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedLoc)
+	if assert.NoError(t, err) {
+		assert.Equal(t, loc, &returnedLoc)
+	}
+}
+
+func TestV1GetLocationsByIDWithFields(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e?fields=address",
+		nil,
+		&[]gin.Param{{Key: "id", Value: "4b7a536e-7109-4a39-9549-f06f74f2093e"}},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	loc := models.NewLocation(id, "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationByID", utils.MockContextMatcher, id).
+		Return(loc, nil)
+
+	server.handleLocationsGetByID(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.JSONEq(t, `{"address": "1 rue de la Poste, 75001 Paris"}`, resp.Body.String())
+}
+
 func TestV1UpdateLocationWithInvalidQuery(t *testing.T) {
 	ctx, _, server := newHandlerTestContext(
 		t,
@@ -1104,3 +2152,63 @@ func TestV1DeleteLocationWithSuccess(t *testing.T) {
 
 	assert.Equal(t, http.StatusNoContent, ctx.Writer.Status())
 }
+
+func TestV1BulkMoveLocationsWithInvalidBody(t *testing.T) {
+	for _, payload := range []*gin.H{
+		{"ids": []string{"550e8400-e29b-41d4-a716-446655440000"}},
+		{"ids": []string{}, "category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e"},
+		{"ids": []string{"123"}, "category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e"},
+		{"ids": []string{"550e8400-e29b-41d4-a716-446655440000"}, "category_id": "123"},
+	} {
+		ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations/bulk-move", payload, nil)
+
+		server.handleLocationsBulkMove(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), payload)
+	}
+}
+
+func TestV1BulkMoveLocationsWithCategoryNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations/bulk-move", &gin.H{
+		"ids":         []string{"550e8400-e29b-41d4-a716-446655440000"},
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	id, _ := models.ParseID("550e8400-e29b-41d4-a716-446655440000")
+	catID, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("MoveLocations", utils.MockContextMatcher, []models.ID{id}, catID).
+		Return(nil, usecases.ErrCategoryNotFound)
+
+	server.handleLocationsBulkMove(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1BulkMoveLocationsWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "POST", "/api/v1/locations/bulk-move", &gin.H{
+		"ids":         []string{"550e8400-e29b-41d4-a716-446655440000", "6ba7b810-9dad-41d1-80b4-00c04fd430c8"},
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+	}, nil)
+
+	ids, _ := models.ParseIDs([]string{"550e8400-e29b-41d4-a716-446655440000", "6ba7b810-9dad-41d1-80b4-00c04fd430c8"})
+	catID, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	report := models.NewBulkReport()
+	report.Succeed(ids[0])
+	report.Fail(ids[1], usecases.ErrLocationNotFound)
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("MoveLocations", utils.MockContextMatcher, ids, catID).
+		Return(report, nil)
+
+	server.handleLocationsBulkMove(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedReport models.BulkReport
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedReport)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *report, returnedReport)
+	}
+}
@@ -1,16 +1,20 @@
 package httpapi
 
 import (
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/edebernis/social-life-manager/services/location/internal/api"
 	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 )
@@ -187,3 +191,308 @@ func (mw *metricsMiddleware) handlerFunc() gin.HandlerFunc {
 		mw.responseSize.Observe(responseSize)
 	}
 }
+
+// corsMiddleware applies the Cross-Origin Resource Sharing policy.
+// Preflight requests are answered directly, before any authentication.
+type corsMiddleware struct {
+	config *CORSConfig
+
+	allowedMethods string
+	allowedHeaders string
+	maxAge         string
+}
+
+func newCORSMiddleware(config *CORSConfig) *corsMiddleware {
+	return &corsMiddleware{
+		config:         config,
+		allowedMethods: strings.Join(config.AllowedMethods, ", "),
+		allowedHeaders: strings.Join(config.AllowedHeaders, ", "),
+		maxAge:         strconv.Itoa(int(config.MaxAge.Seconds())),
+	}
+}
+
+// allowsOrigin checks origin against allowed origins. Patterns may contain one
+// wildcard to allow every subdomain, like "https://*.example.com".
+// "*" never allows credentialed requests, so that no origin is ever reflected for it.
+func (mw *corsMiddleware) allowsOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, pattern := range mw.config.AllowedOrigins {
+		pattern = strings.ToLower(pattern)
+
+		if pattern == "*" {
+			if !mw.config.AllowCredentials {
+				return true
+			}
+			continue
+		}
+		if pattern == origin {
+			return true
+		}
+
+		parts := strings.SplitN(pattern, "*", 2)
+		if len(parts) != 2 || len(origin) <= len(parts[0])+len(parts[1]) {
+			continue
+		}
+
+		if strings.HasPrefix(origin, parts[0]) && strings.HasSuffix(origin, parts[1]) {
+			subdomain := origin[len(parts[0]) : len(origin)-len(parts[1])]
+			if !strings.ContainsAny(subdomain, "/:") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (mw *corsMiddleware) allowsAnyOrigin() bool {
+	for _, pattern := range mw.config.AllowedOrigins {
+		if pattern == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowsPreflight checks the method and the headers requested by a preflight request
+// against allowed methods and headers. Header names are case-insensitive.
+func (mw *corsMiddleware) allowsPreflight(method, headers string) bool {
+	if !containsFold(mw.config.AllowedMethods, method) {
+		return false
+	}
+
+	for _, header := range strings.Split(headers, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !containsFold(mw.config.AllowedHeaders, header) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mw *corsMiddleware) handlerFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if !mw.allowsOrigin(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		// Preflights requesting methods or headers that are not allowed get no allow headers at all
+		if preflight && !mw.allowsPreflight(c.GetHeader("Access-Control-Request-Method"), c.GetHeader("Access-Control-Request-Headers")) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		// Browsers refuse a wildcard origin when credentials are sent
+		if mw.allowsAnyOrigin() && !mw.config.AllowCredentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if mw.config.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			c.Next()
+			return
+		}
+
+		header.Set("Access-Control-Allow-Methods", mw.allowedMethods)
+		if mw.allowedHeaders != "" {
+			header.Set("Access-Control-Allow-Headers", mw.allowedHeaders)
+		}
+		if mw.config.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", mw.maxAge)
+		}
+
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// responseEncoder is implemented by every compressed stream writer supported by compressionMiddleware
+type responseEncoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// compressionMiddleware compresses response bodies using the preferred encoding
+// among those accepted by the client in its Accept-Encoding header.
+type compressionMiddleware struct {
+	// Supported encodings, by order of preference
+	encodings []string
+	encoders  map[string]*sync.Pool
+}
+
+func newCompressionMiddleware() *compressionMiddleware {
+	return &compressionMiddleware{
+		encodings: []string{"zstd", "gzip"},
+		encoders: map[string]*sync.Pool{
+			"zstd": {New: func() interface{} {
+				// Options are valid, so no error may be returned
+				encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+				return encoder
+			}},
+			"gzip": {New: func() interface{} {
+				return gzip.NewWriter(nil)
+			}},
+		},
+	}
+}
+
+// negotiate returns the supported encoding with the highest quality value in
+// specified Accept-Encoding header, or an empty string if none is acceptable.
+func (mw *compressionMiddleware) negotiate(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if value, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = value
+				}
+			}
+		}
+		qualities[name] = q
+	}
+
+	var best string
+	var bestQ float64
+	for _, encoding := range mw.encodings {
+		q, ok := qualities[encoding]
+		if !ok {
+			q = qualities["*"]
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+
+	return best
+}
+
+func (mw *compressionMiddleware) handlerFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := mw.negotiate(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		w := &compressedResponseWriter{
+			ResponseWriter: c.Writer,
+			encoding:       encoding,
+			pool:           mw.encoders[encoding],
+		}
+		c.Writer = w
+
+		c.Next()
+
+		w.close()
+		c.Writer = w.ResponseWriter
+	}
+}
+
+// compressedResponseWriter compresses everything written to the response body.
+// The encoder is only set up on first write, so that empty responses are left untouched.
+type compressedResponseWriter struct {
+	gin.ResponseWriter
+
+	encoding string
+	pool     *sync.Pool
+	encoder  responseEncoder
+	// Set when the body must be written as is, because it is already encoded
+	passthrough bool
+}
+
+func (w *compressedResponseWriter) Write(data []byte) (int, error) {
+	if w.encoder == nil && !w.passthrough {
+		w.start()
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+
+	return w.encoder.Write(data)
+}
+
+func (w *compressedResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressedResponseWriter) start() {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		w.passthrough = true
+		return
+	}
+
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+
+	w.encoder = w.pool.Get().(responseEncoder)
+	w.encoder.Reset(w.ResponseWriter)
+}
+
+// Flush sends compressed data buffered so far to the client
+func (w *compressedResponseWriter) Flush() {
+	if w.encoder != nil {
+		if err := w.encoder.Flush(); err != nil {
+			logger.Errorf("compressionMiddleware: failed to flush %s encoder. %v", w.encoding, err)
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressedResponseWriter) close() {
+	if w.encoder == nil {
+		return
+	}
+
+	if err := w.encoder.Close(); err != nil {
+		logger.Errorf("compressionMiddleware: failed to close %s encoder. %v", w.encoding, err)
+	}
+	w.pool.Put(w.encoder)
+	w.encoder = nil
+}
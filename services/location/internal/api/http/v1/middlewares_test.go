@@ -1,9 +1,11 @@
 package httpapi
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,9 +14,11 @@ import (
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/edebernis/social-life-manager/services/location/internal/api"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
 	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
@@ -443,3 +447,352 @@ func TestMetricsMiddlewareRequestsCount(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func newTestCORSMiddleware(config *CORSConfig) *HTTPServer {
+	gin.SetMode(gin.TestMode)
+	server := &HTTPServer{
+		&Config{CORS: *config},
+		"/api",
+		nil,
+		nil,
+		gin.New(),
+		nil,
+	}
+
+	server.router.Use(newCORSMiddleware(&server.Config.CORS).handlerFunc())
+	server.router.GET("/testCORSMiddleware", func(c *gin.Context) {
+		c.JSON(http.StatusOK, nil)
+	})
+
+	return server
+}
+
+func TestCORSMiddlewareWithPreflightRequest(t *testing.T) {
+	resp := httptest.NewRecorder()
+
+	server := newTestCORSMiddleware(&CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Authorization"},
+		MaxAge:         time.Hour,
+	})
+
+	req, err := http.NewRequest("OPTIONS", "/testCORSMiddleware", nil)
+	if err != nil {
+		t.FailNow()
+	}
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, "https://app.example.com", resp.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST", resp.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization", resp.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "3600", resp.Header().Get("Access-Control-Max-Age"))
+	assert.Empty(t, resp.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORSMiddlewareWithPreflightRequestFromUnknownOrigin(t *testing.T) {
+	resp := httptest.NewRecorder()
+
+	server := newTestCORSMiddleware(&CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+	})
+
+	req, err := http.NewRequest("OPTIONS", "/testCORSMiddleware", nil)
+	if err != nil {
+		t.FailNow()
+	}
+	req.Header.Set("Origin", "https://evil.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Empty(t, resp.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSMiddlewareWithWildcardSubdomain(t *testing.T) {
+	server := newTestCORSMiddleware(&CORSConfig{
+		AllowedOrigins:   []string{"https://*.example.com"},
+		AllowCredentials: true,
+	})
+
+	tests := map[string]string{
+		"https://app.example.com":       "https://app.example.com",
+		"https://a.b.example.com":       "https://a.b.example.com",
+		"https://example.com":           "",
+		"https://app.example.com.evil":  "",
+		"http://app.example.com":        "",
+		"https://evil.com/.example.com": "",
+	}
+
+	for origin, expected := range tests {
+		resp := httptest.NewRecorder()
+
+		req, err := http.NewRequest("GET", "/testCORSMiddleware", nil)
+		if err != nil {
+			t.FailNow()
+		}
+		req.Header.Set("Origin", origin)
+
+		server.router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, expected, resp.Header().Get("Access-Control-Allow-Origin"), origin)
+	}
+}
+
+func TestCORSMiddlewareWithAnyOrigin(t *testing.T) {
+	resp := httptest.NewRecorder()
+
+	server := newTestCORSMiddleware(&CORSConfig{
+		AllowedOrigins: []string{"*"},
+	})
+
+	req, err := http.NewRequest("GET", "/testCORSMiddleware", nil)
+	if err != nil {
+		t.FailNow()
+	}
+	req.Header.Set("Origin", "https://app.example.com")
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "*", resp.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSMiddlewareWithPreflightRequestForDisallowedMethod(t *testing.T) {
+	resp := httptest.NewRecorder()
+
+	server := newTestCORSMiddleware(&CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET"},
+	})
+
+	req, err := http.NewRequest("OPTIONS", "/testCORSMiddleware", nil)
+	if err != nil {
+		t.FailNow()
+	}
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "DELETE")
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Empty(t, resp.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, resp.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestCORSMiddlewareWithPreflightRequestForDisallowedHeader(t *testing.T) {
+	server := newTestCORSMiddleware(&CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+	})
+
+	tests := map[string]int{
+		"authorization":               http.StatusNoContent,
+		"Authorization, content-type": http.StatusNoContent,
+		"Authorization, X-Custom":     http.StatusForbidden,
+		"X-Custom":                    http.StatusForbidden,
+		"":                            http.StatusNoContent,
+	}
+
+	for headers, expected := range tests {
+		resp := httptest.NewRecorder()
+
+		req, err := http.NewRequest("OPTIONS", "/testCORSMiddleware", nil)
+		if err != nil {
+			t.FailNow()
+		}
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", "GET")
+		req.Header.Set("Access-Control-Request-Headers", headers)
+
+		server.router.ServeHTTP(resp, req)
+
+		assert.Equal(t, expected, resp.Code, headers)
+		if expected == http.StatusForbidden {
+			assert.Empty(t, resp.Header().Get("Access-Control-Allow-Headers"), headers)
+		}
+	}
+}
+
+func TestCORSMiddlewareWithAnyOriginAndCredentials(t *testing.T) {
+	resp := httptest.NewRecorder()
+
+	server := newTestCORSMiddleware(&CORSConfig{
+		AllowedOrigins:   []string{"*"},
+		AllowCredentials: true,
+	})
+
+	req, err := http.NewRequest("GET", "/testCORSMiddleware", nil)
+	if err != nil {
+		t.FailNow()
+	}
+	req.Header.Set("Origin", "https://evil.com")
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, resp.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORSConfigValidate(t *testing.T) {
+	assert.NoError(t, (&CORSConfig{AllowedOrigins: []string{"*"}}).Validate())
+	assert.NoError(t, (&CORSConfig{AllowedOrigins: []string{"https://*.example.com"}, AllowCredentials: true}).Validate())
+	assert.Error(t, (&CORSConfig{AllowedOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true}).Validate())
+}
+
+func TestCORSMiddlewareAnswersPreflightBeforeAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+
+	api := api.NewAPI(new(mocks.LocationUsecaseMock))
+	auth := NewJWTAuthenticator(jwt.SigningMethodHS256.Name, "secret")
+	server := NewHTTPServer(api, auth, prometheus.NewRegistry(), &Config{
+		CORS: CORSConfig{
+			AllowedOrigins:   []string{"https://app.example.com"},
+			AllowedMethods:   []string{"GET"},
+			AllowedHeaders:   []string{"Authorization"},
+			AllowCredentials: true,
+		},
+	})
+
+	req, err := http.NewRequest("OPTIONS", "/api/v1/locations", nil)
+	if err != nil {
+		t.FailNow()
+	}
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	req.Header.Set("Access-Control-Request-Headers", "Authorization")
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, "https://app.example.com", resp.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", resp.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func newTestCompressionMiddleware() *HTTPServer {
+	gin.SetMode(gin.TestMode)
+	server := &HTTPServer{
+		&Config{},
+		"/api",
+		nil,
+		nil,
+		gin.New(),
+		nil,
+	}
+
+	server.router.Use(newCompressionMiddleware().handlerFunc())
+	server.router.GET("/testCompressionMiddleware", func(c *gin.Context) {
+		c.String(http.StatusOK, strings.Repeat("Test Location ", 100))
+	})
+	server.router.DELETE("/testCompressionMiddleware", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	return server
+}
+
+func TestCompressionMiddlewareNegotiation(t *testing.T) {
+	mw := newCompressionMiddleware()
+
+	assert.Equal(t, "", mw.negotiate(""))
+	assert.Equal(t, "", mw.negotiate("identity, br"))
+	assert.Equal(t, "gzip", mw.negotiate("gzip, deflate"))
+	assert.Equal(t, "zstd", mw.negotiate("gzip, zstd"))
+	assert.Equal(t, "gzip", mw.negotiate("zstd;q=0.5, gzip;q=0.8"))
+	assert.Equal(t, "gzip", mw.negotiate("zstd;q=0, *"))
+	assert.Equal(t, "", mw.negotiate("*;q=0"))
+}
+
+func TestCompressionMiddlewareWithGzip(t *testing.T) {
+	server := newTestCompressionMiddleware()
+
+	for i := 0; i < 2; i++ {
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/testCompressionMiddleware", nil)
+		if err != nil {
+			t.FailNow()
+		}
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		server.router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "gzip", resp.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", resp.Header().Get("Vary"))
+
+		r, err := gzip.NewReader(resp.Body)
+		if assert.NoError(t, err) {
+			body, err := ioutil.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, strings.Repeat("Test Location ", 100), string(body))
+		}
+	}
+}
+
+func TestCompressionMiddlewareWithZstd(t *testing.T) {
+	server := newTestCompressionMiddleware()
+
+	for i := 0; i < 2; i++ {
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/testCompressionMiddleware", nil)
+		if err != nil {
+			t.FailNow()
+		}
+		req.Header.Set("Accept-Encoding", "gzip, zstd")
+
+		server.router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "zstd", resp.Header().Get("Content-Encoding"))
+
+		r, err := zstd.NewReader(resp.Body)
+		if assert.NoError(t, err) {
+			body, err := ioutil.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, strings.Repeat("Test Location ", 100), string(body))
+			r.Close()
+		}
+	}
+}
+
+func TestCompressionMiddlewareWithoutAcceptEncoding(t *testing.T) {
+	server := newTestCompressionMiddleware()
+	resp := httptest.NewRecorder()
+
+	req, err := http.NewRequest("GET", "/testCompressionMiddleware", nil)
+	if err != nil {
+		t.FailNow()
+	}
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "", resp.Header().Get("Content-Encoding"))
+	assert.Equal(t, strings.Repeat("Test Location ", 100), resp.Body.String())
+}
+
+func TestCompressionMiddlewareWithEmptyResponse(t *testing.T) {
+	server := newTestCompressionMiddleware()
+	resp := httptest.NewRecorder()
+
+	req, err := http.NewRequest("DELETE", "/testCompressionMiddleware", nil)
+	if err != nil {
+		t.FailNow()
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, "", resp.Header().Get("Content-Encoding"))
+	assert.Empty(t, resp.Body.Bytes())
+}
@@ -8,15 +8,29 @@ import (
 )
 
 func (s *HTTPServer) routes(auth api.Authenticator) {
-	// Default middlewares used on every routes
+	// Default middlewares used on every routes. Compression comes first so that
+	// error responses written by other middlewares are compressed too.
+	s.router.Use(newCompressionMiddleware().handlerFunc())
 	s.router.Use(loggerMiddleware())
 	s.router.Use(errorMiddleware())
 	s.router.Use(recoveryMiddleware())
 	s.router.Use(newMetricsMiddleware(s.PrometheusRegistry).handlerFunc())
+	s.router.Use(newCORSMiddleware(&s.Config.CORS).handlerFunc())
 
 	// Healthchecks routes
 	s.router.GET("/ping", s.handlePing)
 
+	// Documentation routes, publicly available
+	s.router.GET("/docs", s.handleDocs)
+	s.router.StaticFS(docsAssetsURL, docsAssets())
+	s.router.GET(s.openAPISpecURL(), s.handleOpenAPISpec)
+
+	// Public routes, available to guests without authentication. Share link tokens give access to them.
+	public := s.router.Group(s.BaseURL + "/v1/public")
+	{
+		public.GET("/locations/:token", s.handlePublicLocationsGet)
+	}
+
 	// Main APÌ routes group, versioned.
 	api := s.router.Group(s.BaseURL, authMiddleware(auth))
 	{
@@ -29,27 +43,65 @@ func (s *HTTPServer) routes(auth api.Authenticator) {
 				categories.GET(":id", s.handleCategoriesGetByID)
 				categories.PUT(":id", s.handleCategoriesUpdate)
 				categories.DELETE(":id", s.handleCategoriesDelete)
+				categories.POST(":id/merge", s.handleCategoriesMerge)
+				categories.GET(":id/shares", s.handleCategoriesSharesGet)
+				categories.PUT(":id/shares/:user_id", s.handleCategoriesSharesPut)
+				categories.DELETE(":id/shares/:user_id", s.handleCategoriesSharesDelete)
 			}
 
 			locations := v1.Group("/locations")
 			{
 				locations.POST("", s.handleLocationsCreate)
+				locations.POST("/bulk-move", s.handleLocationsBulkMove)
 				locations.GET("", s.handleLocationsGet)
+				locations.GET("/clusters", s.handleLocationsGetClusters)
 				locations.GET(":id", s.handleLocationsGetByID)
 				locations.PUT(":id", s.handleLocationsUpdate)
 				locations.DELETE(":id", s.handleLocationsDelete)
+				locations.GET(":id/shares", s.handleLocationsSharesGet)
+				locations.PUT(":id/shares/:user_id", s.handleLocationsSharesPut)
+				locations.DELETE(":id/shares/:user_id", s.handleLocationsSharesDelete)
+				locations.POST(":id/share-links", s.handleLocationsShareLinksCreate)
+				locations.GET(":id/share-links", s.handleLocationsShareLinksGet)
+				locations.DELETE(":id/share-links/:link_id", s.handleLocationsShareLinksDelete)
+			}
+
+			tags := v1.Group("/tags")
+			{
+				tags.POST("", s.handleTagsCreate)
+				tags.GET("", s.handleTagsGet)
+				tags.GET(":id", s.handleTagsGetByID)
+				tags.PUT(":id", s.handleTagsUpdate)
+				tags.DELETE(":id", s.handleTagsDelete)
+			}
+
+			groups := v1.Group("/groups")
+			{
+				groups.POST("", s.handleGroupsCreate)
+				groups.GET("", s.handleGroupsGet)
+				groups.GET(":id", s.handleGroupsGetByID)
+				groups.PUT(":id", s.handleGroupsUpdate)
+				groups.DELETE(":id", s.handleGroupsDelete)
+				groups.GET(":id/members", s.handleGroupsMembersGet)
+				groups.PUT(":id/members/:user_id", s.handleGroupsMembersPut)
+				groups.DELETE(":id/members/:user_id", s.handleGroupsMembersDelete)
+			}
+
+			geocode := v1.Group("/geocode")
+			{
+				geocode.GET("/reverse", s.handleGeocodeReverse)
+			}
+
+			tiles := v1.Group("/tiles")
+			{
+				// Wildcards span the whole path segment, so "y.mvt" is the parameter name
+				tiles.GET(":z/:x/:y.mvt", s.handleTilesGet)
 			}
 		}
 	}
 }
 
-// handlePing godoc
-// @Summary Ping API
-// @Description Basic check of HTTP API health. Ensure that HTTP service is working correctly.
-// @Tags healthchecks
-// @Produce  json
-// @Success 200
-// @Router /ping [get]
+// handlePing is a basic check of HTTP API health. Ensure that HTTP service is working correctly.
 func (s *HTTPServer) handlePing(c *gin.Context) {
 	c.JSON(http.StatusOK, nil)
 }
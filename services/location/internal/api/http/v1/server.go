@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -88,9 +89,43 @@ type Config struct {
 	ReadHeaderTimeout time.Duration
 	ReadTimeout       time.Duration
 	WriteTimeout      time.Duration
+
+	// Cross-Origin Resource Sharing policy
+	CORS CORSConfig
+}
+
+// CORSConfig describes which cross-origin requests are allowed by the server
+type CORSConfig struct {
+	// Origins allowed to call the API, like "https://app.example.com".
+	// Wildcard subdomains are supported, like "https://*.example.com". "*" allows every origin.
+	AllowedOrigins []string
+	// HTTP methods allowed in cross-origin requests.
+	AllowedMethods []string
+	// HTTP headers allowed in cross-origin requests.
+	AllowedHeaders []string
+	// Allow cookies and Authorization headers to be sent by browsers.
+	AllowCredentials bool
+	// How long preflight responses can be cached by browsers.
+	MaxAge time.Duration
+}
+
+// Validate checks that the policy is safe. Allowing credentials from any origin would let
+// every website make authenticated requests on behalf of users.
+func (c *CORSConfig) Validate() error {
+	if !c.AllowCredentials {
+		return nil
+	}
+
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			return errors.New("CORS credentials cannot be allowed along with any origin \"*\"")
+		}
+	}
+
+	return nil
 }
 
 // Abort current request and return consistent error to the user
 func abort(c *gin.Context, code int, errorMsg string) {
-	c.AbortWithStatusJSON(code, HTTPError{code, errorMsg})
+	c.AbortWithStatusJSON(code, HTTPError{Code: code, Message: errorMsg})
 }
@@ -78,3 +78,92 @@ func TestHTTPServerUnknownRoute(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, resp.Code)
 }
+
+func TestHTTPServerRoutesAreDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	api := api.NewAPI(new(mocks.LocationUsecaseMock))
+	server := NewHTTPServer(api, nil, prometheus.NewRegistry(), &Config{})
+
+	assert.NoError(t, server.CheckDocumentation())
+}
+
+func TestHTTPServerUndocumentedRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	api := api.NewAPI(new(mocks.LocationUsecaseMock))
+	server := NewHTTPServer(api, nil, prometheus.NewRegistry(), &Config{})
+	server.router.GET("/undocumented", func(c *gin.Context) {})
+
+	assert.Error(t, server.CheckDocumentation())
+}
+
+func TestHTTPServerRouteOutsideBasePath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	api := api.NewAPI(new(mocks.LocationUsecaseMock))
+	server := NewHTTPServer(api, nil, prometheus.NewRegistry(), &Config{})
+	// Documented relatively to the base path only
+	server.router.GET("/locations", func(c *gin.Context) {})
+
+	assert.Error(t, server.CheckDocumentation())
+}
+
+func TestHTTPServerOpenAPISpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+
+	api := api.NewAPI(new(mocks.LocationUsecaseMock))
+	server := NewHTTPServer(api, nil, prometheus.NewRegistry(), &Config{})
+
+	req, _ := http.NewRequest("GET", "/api/v1/openapi.json", nil)
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var spec map[string]interface{}
+	err := json.NewDecoder(resp.Body).Decode(&spec)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "3.0.3", spec["openapi"])
+	}
+}
+
+func TestHTTPServerDocs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+
+	api := api.NewAPI(new(mocks.LocationUsecaseMock))
+	server := NewHTTPServer(api, nil, prometheus.NewRegistry(), &Config{})
+
+	req, _ := http.NewRequest("GET", "/docs", nil)
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "SwaggerUIBundle")
+	assert.Contains(t, resp.Body.String(), `src="/docs/assets/swagger-ui-bundle.js"`)
+	assert.NotContains(t, resp.Body.String(), "https://")
+}
+
+func TestHTTPServerDocsAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	api := api.NewAPI(new(mocks.LocationUsecaseMock))
+	server := NewHTTPServer(api, nil, prometheus.NewRegistry(), &Config{})
+
+	tests := map[string]int{
+		"/docs/assets/swagger-ui.css":       http.StatusOK,
+		"/docs/assets/swagger-ui-bundle.js": http.StatusOK,
+		"/docs/assets/unknown.js":           http.StatusNotFound,
+	}
+
+	for path, expected := range tests {
+		resp := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+
+		server.router.ServeHTTP(resp, req)
+
+		assert.Equal(t, expected, resp.Code, path)
+	}
+}
@@ -0,0 +1,182 @@
+package httpapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// handleLocationsShareLinksCreate godoc
+// @Summary Create location share link
+// @Description Create a public link to specified location, for guests who are not users, like people invited to an event.
+// @Description The returned token gives access to the name, address and coordinates of the location through the public API, until it expires or is revoked.
+// @Description Only users managing the location, its owner or users it is shared with as owner, may create links.
+// @Tags share-links
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Location ID"
+// @Param link body models.CreateShareLink false "Validity of the link"
+// @Success 200 {object} models.ShareLink "The created share link"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /locations/{id}/share-links [post]
+func (s *HTTPServer) handleLocationsShareLinksCreate(c *gin.Context) {
+	var query models.CreateShareLinkQuery
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("LocationsShareLinksCreate: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	// Body is optional, links are valid for DefaultShareLinkValidity unless specified
+	var body models.CreateShareLink
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		logger.Errorf("LocationsShareLinksCreate: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("LocationsShareLinksCreate: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	validity := models.DefaultShareLinkValidity
+	if body.ExpiresIn > 0 {
+		validity = time.Duration(body.ExpiresIn) * time.Second
+	}
+	link := models.NewShareLink(models.NewID(), id, time.Now().Add(validity).Truncate(time.Second))
+
+	err = s.api.LocationUsecase.CreateShareLink(c.Request.Context(), link)
+	switch {
+	case err != nil:
+		abortWithError(c, "LocationsShareLinksCreate", err, "Failed to create share link")
+		return
+	default:
+		c.JSON(http.StatusOK, link)
+	}
+}
+
+// handleLocationsShareLinksGet godoc
+// @Summary Get location share links
+// @Description Get the public links to specified location along with their token and the number of times they were used, expired ones included.
+// @Description Only users managing the location may list them.
+// @Tags share-links
+// @Produce  json
+// @Param id path string true "Location ID"
+// @Success 200 {array} models.ShareLink "List of share links"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /locations/{id}/share-links [get]
+func (s *HTTPServer) handleLocationsShareLinksGet(c *gin.Context) {
+	var query models.GetShareLinks
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("LocationsShareLinksGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("LocationsShareLinksGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	links, err := s.api.LocationUsecase.GetShareLinks(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		abortWithError(c, "LocationsShareLinksGet", err, "Failed to get share links")
+		return
+	default:
+		c.JSON(http.StatusOK, links)
+	}
+}
+
+// handleLocationsShareLinksDelete godoc
+// @Summary Revoke location share link
+// @Description Revoke a public link to specified location, so that its token cannot be used anymore.
+// @Tags share-links
+// @Param id path string true "Location ID"
+// @Param link_id path string true "Share link ID"
+// @Success 204 "OK"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /locations/{id}/share-links/{link_id} [delete]
+func (s *HTTPServer) handleLocationsShareLinksDelete(c *gin.Context) {
+	var query models.ShareLinkQuery
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("LocationsShareLinksDelete: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("LocationsShareLinksDelete: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	link, err := models.ParseID(query.Link)
+	if err != nil {
+		logger.Errorf("LocationsShareLinksDelete: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	err = s.api.LocationUsecase.RevokeShareLink(c.Request.Context(), id, link)
+	switch {
+	case err != nil:
+		abortWithError(c, "LocationsShareLinksDelete", err, "Failed to revoke share link")
+		return
+	default:
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handlePublicLocationsGet godoc
+// @Summary Get location with share link
+// @Description Get the name, address and coordinates of the location shared by a public link. No authentication is needed, as the token of the link gives access to the location.
+// @Description Invalid, expired and revoked tokens are all answered with 404 Not Found.
+// @Tags public
+// @Produce  json
+// @Param token path string true "Share link token"
+// @Success 200 {object} models.PublicLocation "The shared location"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /public/locations/{token} [get]
+func (s *HTTPServer) handlePublicLocationsGet(c *gin.Context) {
+	var query models.GetPublicLocation
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("PublicLocationsGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	loc, err := s.api.LocationUsecase.GetPublicLocation(c.Request.Context(), query.Token)
+	switch {
+	case err != nil:
+		abortWithError(c, "PublicLocationsGet", err, "Failed to get location")
+		return
+	default:
+		c.JSON(http.StatusOK, loc)
+	}
+}
@@ -0,0 +1,225 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/api"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestV1CreateShareLinkWithInvalidBody(t *testing.T) {
+	for _, body := range []*gin.H{
+		{"expires_in": 10},
+		{"expires_in": 31 * 24 * 3600},
+		{"expires_in": "1d"},
+	} {
+		ctx, _, server := newHandlerTestContext(
+			t,
+			"POST",
+			"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/share-links",
+			body,
+			&[]gin.Param{
+				{
+					Key:   "id",
+					Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+				},
+			},
+		)
+
+		server.handleLocationsShareLinksCreate(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+	}
+}
+
+func TestV1CreateShareLinkWithDefaultValidity(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"POST",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/share-links",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateShareLink", utils.MockContextMatcher, mock.MatchedBy(func(link *models.ShareLink) bool {
+			validity := time.Until(link.ExpiresAt)
+			return link.Location == id && validity > models.DefaultShareLinkValidity-time.Minute && validity <= models.DefaultShareLinkValidity
+		})).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*models.ShareLink).Token = "token"
+		}).
+		Return(nil)
+
+	server.handleLocationsShareLinksCreate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedLink models.ShareLink
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedLink)
+	if assert.NoError(t, err) {
+		assert.Equal(t, id, returnedLink.Location)
+		assert.Equal(t, "token", returnedLink.Token)
+	}
+}
+
+func TestV1CreateShareLinkWithForbiddenError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"POST",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/share-links",
+		&gin.H{
+			"expires_in": 3600,
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateShareLink", utils.MockContextMatcher, mock.AnythingOfType("*models.ShareLink")).
+		Return(usecases.ErrForbidden)
+
+	server.handleLocationsShareLinksCreate(ctx)
+
+	assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
+}
+
+func TestV1GetShareLinksWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/share-links",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	link := models.NewShareLink(models.NewID(), id, time.Date(2021, 3, 8, 12, 0, 0, 0, time.UTC))
+	link.Token = "token"
+	link.Accesses = 3
+	links := &models.ShareLinks{link}
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetShareLinks", utils.MockContextMatcher, id).
+		Return(links, nil)
+
+	server.handleLocationsShareLinksGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedLinks models.ShareLinks
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedLinks)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *links, returnedLinks)
+	}
+}
+
+func TestV1RevokeShareLinkWithNotFoundError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/share-links/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+			{
+				Key:   "link_id",
+				Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	link, _ := models.ParseID("9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("RevokeShareLink", utils.MockContextMatcher, id, link).
+		Return(usecases.ErrShareLinkNotFound)
+
+	server.handleLocationsShareLinksDelete(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1GetPublicLocationWithNotFoundError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/public/locations/token",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "token",
+				Value: "token",
+			},
+		},
+	)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetPublicLocation", utils.MockContextMatcher, "token").
+		Return(nil, usecases.ErrShareLinkNotFound)
+
+	server.handlePublicLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestHTTPServerPublicLocationWithoutAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+
+	latitude, longitude := 48.8606111, 2.3376
+	loc := &models.PublicLocation{
+		Name:      "Home",
+		Address:   "1 rue de la Poste, 75001 Paris",
+		Latitude:  &latitude,
+		Longitude: &longitude,
+	}
+	usecase := new(mocks.LocationUsecaseMock)
+	usecase.On("GetPublicLocation", mock.Anything, "token").Return(loc, nil)
+
+	// No authenticator is given, so that any authenticated route would fail
+	server := NewHTTPServer(api.NewAPI(usecase), nil, prometheus.NewRegistry(), &Config{})
+
+	req, _ := http.NewRequest("GET", "/api/v1/public/locations/token", nil)
+
+	server.router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var returnedLocation models.PublicLocation
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedLocation)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *loc, returnedLocation)
+	}
+}
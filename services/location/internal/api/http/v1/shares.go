@@ -0,0 +1,255 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// handleLocationsSharesGet godoc
+// @Summary Get location shares
+// @Description Get the roles granted on specified location to other users.
+// @Description Only the owner of the location, and users it is shared with as owner, may list them.
+// @Tags shares
+// @Produce  json
+// @Param id path string true "Location ID"
+// @Success 200 {array} models.Share "List of shares"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /locations/{id}/shares [get]
+func (s *HTTPServer) handleLocationsSharesGet(c *gin.Context) {
+	id, ok := bindSharesQuery(c, "LocationsSharesGet")
+	if !ok {
+		return
+	}
+
+	shares, err := s.api.LocationUsecase.GetLocationShares(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		abortWithError(c, "LocationsSharesGet", err, "Failed to get location shares")
+		return
+	default:
+		c.JSON(http.StatusOK, shares)
+	}
+}
+
+// handleLocationsSharesPut godoc
+// @Summary Share location
+// @Description Grant a role on specified location to another user, replacing the role already granted to them if any.
+// @Description Owners may update, delete and share the location, editors may update it, and viewers may only read it.
+// @Tags shares
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Location ID"
+// @Param user_id path string true "User ID"
+// @Param share body models.GrantShare true "Granted role"
+// @Success 200 {object} models.Share "The granted share"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /locations/{id}/shares/{user_id} [put]
+func (s *HTTPServer) handleLocationsSharesPut(c *gin.Context) {
+	id, share, ok := bindGrantShare(c, "LocationsSharesPut")
+	if !ok {
+		return
+	}
+
+	err := s.api.LocationUsecase.ShareLocation(c.Request.Context(), id, share)
+	switch {
+	case err != nil:
+		abortWithError(c, "LocationsSharesPut", err, "Failed to share location")
+		return
+	default:
+		c.JSON(http.StatusOK, share)
+	}
+}
+
+// handleLocationsSharesDelete godoc
+// @Summary Unshare location
+// @Description Revoke the role granted on specified location to a user.
+// @Tags shares
+// @Param id path string true "Location ID"
+// @Param user_id path string true "User ID"
+// @Success 204 "OK"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /locations/{id}/shares/{user_id} [delete]
+func (s *HTTPServer) handleLocationsSharesDelete(c *gin.Context) {
+	id, user, ok := bindShareQuery(c, "LocationsSharesDelete")
+	if !ok {
+		return
+	}
+
+	err := s.api.LocationUsecase.UnshareLocation(c.Request.Context(), id, user)
+	switch {
+	case err != nil:
+		abortWithError(c, "LocationsSharesDelete", err, "Failed to unshare location")
+		return
+	default:
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handleCategoriesSharesGet godoc
+// @Summary Get category shares
+// @Description Get the roles granted on specified user category to other users.
+// @Tags shares
+// @Produce  json
+// @Param id path string true "Category ID"
+// @Success 200 {array} models.Share "List of shares"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /categories/{id}/shares [get]
+func (s *HTTPServer) handleCategoriesSharesGet(c *gin.Context) {
+	id, ok := bindSharesQuery(c, "CategoriesSharesGet")
+	if !ok {
+		return
+	}
+
+	shares, err := s.api.LocationUsecase.GetCategoryShares(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		abortWithError(c, "CategoriesSharesGet", err, "Failed to get category shares")
+		return
+	default:
+		c.JSON(http.StatusOK, shares)
+	}
+}
+
+// handleCategoriesSharesPut godoc
+// @Summary Share category
+// @Description Grant a role on all locations of specified user category to another user, replacing the role already granted to them if any.
+// @Description Default categories cannot be shared.
+// @Tags shares
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Category ID"
+// @Param user_id path string true "User ID"
+// @Param share body models.GrantShare true "Granted role"
+// @Success 200 {object} models.Share "The granted share"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /categories/{id}/shares/{user_id} [put]
+func (s *HTTPServer) handleCategoriesSharesPut(c *gin.Context) {
+	id, share, ok := bindGrantShare(c, "CategoriesSharesPut")
+	if !ok {
+		return
+	}
+
+	err := s.api.LocationUsecase.ShareCategory(c.Request.Context(), id, share)
+	switch {
+	case err != nil:
+		abortWithError(c, "CategoriesSharesPut", err, "Failed to share category")
+		return
+	default:
+		c.JSON(http.StatusOK, share)
+	}
+}
+
+// handleCategoriesSharesDelete godoc
+// @Summary Unshare category
+// @Description Revoke the role granted on specified user category to a user.
+// @Tags shares
+// @Param id path string true "Category ID"
+// @Param user_id path string true "User ID"
+// @Success 204 "OK"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /categories/{id}/shares/{user_id} [delete]
+func (s *HTTPServer) handleCategoriesSharesDelete(c *gin.Context) {
+	id, user, ok := bindShareQuery(c, "CategoriesSharesDelete")
+	if !ok {
+		return
+	}
+
+	err := s.api.LocationUsecase.UnshareCategory(c.Request.Context(), id, user)
+	switch {
+	case err != nil:
+		abortWithError(c, "CategoriesSharesDelete", err, "Failed to unshare category")
+		return
+	default:
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// bindSharesQuery parses the ID of the shared location or category, aborting the request when invalid
+func bindSharesQuery(c *gin.Context, name string) (models.ID, bool) {
+	var query models.GetShares
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("%s: invalid query. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return models.NilID, false
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("%s: invalid query. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return models.NilID, false
+	}
+
+	return id, true
+}
+
+// bindShareQuery parses the IDs of the shared location or category and of the user,
+// aborting the request when invalid
+func bindShareQuery(c *gin.Context, name string) (models.ID, models.ID, bool) {
+	var query models.ShareQuery
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("%s: invalid query. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return models.NilID, models.NilID, false
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("%s: invalid query. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return models.NilID, models.NilID, false
+	}
+
+	user, err := models.ParseID(query.User)
+	if err != nil {
+		logger.Errorf("%s: invalid query. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return models.NilID, models.NilID, false
+	}
+
+	return id, user, true
+}
+
+// bindGrantShare parses the ID of the shared location or category and the granted share,
+// aborting the request when invalid
+func bindGrantShare(c *gin.Context, name string) (models.ID, *models.Share, bool) {
+	id, user, ok := bindShareQuery(c, name)
+	if !ok {
+		return models.NilID, nil, false
+	}
+
+	var body models.GrantShare
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Errorf("%s: invalid body. %v", name, err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return models.NilID, nil, false
+	}
+
+	return id, models.NewShare(user, models.ShareRole(body.Role)), true
+}
@@ -0,0 +1,238 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV1GetLocationSharesWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/shares",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	shares := &models.Shares{models.NewShare(models.NewID(), models.ShareRoleEditor)}
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocationShares", utils.MockContextMatcher, id).
+		Return(shares, nil)
+
+	server.handleLocationsSharesGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedShares models.Shares
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedShares)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *shares, returnedShares)
+	}
+}
+
+func TestV1ShareLocationWithInvalidBody(t *testing.T) {
+	for _, body := range []*gin.H{
+		nil,
+		{"role": ""},
+		{"role": "admin"},
+	} {
+		ctx, _, server := newHandlerTestContext(
+			t,
+			"PUT",
+			"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/shares/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			body,
+			&[]gin.Param{
+				{
+					Key:   "id",
+					Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+				},
+				{
+					Key:   "user_id",
+					Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+				},
+			},
+		)
+
+		server.handleLocationsSharesPut(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+	}
+}
+
+func TestV1ShareLocationWithForbiddenError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/shares/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+		&gin.H{
+			"role": "viewer",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+			{
+				Key:   "user_id",
+				Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.ParseID("9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ShareLocation", utils.MockContextMatcher, id, models.NewShare(user, models.ShareRoleViewer)).
+		Return(usecases.ErrForbidden)
+
+	server.handleLocationsSharesPut(ctx)
+
+	assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
+}
+
+func TestV1ShareLocationWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/shares/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+		&gin.H{
+			"role": "editor",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+			{
+				Key:   "user_id",
+				Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.ParseID("9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e")
+	share := models.NewShare(user, models.ShareRoleEditor)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ShareLocation", utils.MockContextMatcher, id, share).
+		Return(nil)
+
+	server.handleLocationsSharesPut(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedShare models.Share
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedShare)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *share, returnedShare)
+	}
+}
+
+func TestV1UnshareLocationWithShareNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e/shares/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+			{
+				Key:   "user_id",
+				Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.ParseID("9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UnshareLocation", utils.MockContextMatcher, id, user).
+		Return(usecases.ErrShareNotFound)
+
+	server.handleLocationsSharesDelete(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1ShareCategoryWithReadOnlyCategory(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e/shares/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+		&gin.H{
+			"role": "viewer",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+			{
+				Key:   "user_id",
+				Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.ParseID("9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("ShareCategory", utils.MockContextMatcher, id, models.NewShare(user, models.ShareRoleViewer)).
+		Return(usecases.ErrCategoryReadOnly)
+
+	server.handleCategoriesSharesPut(ctx)
+
+	assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
+}
+
+func TestV1UnshareCategoryWithSuccess(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/categories/4b7a536e-7109-4a39-9549-f06f74f2093e/shares/9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+			{
+				Key:   "user_id",
+				Value: "9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.ParseID("9f1c4fb2-5a3e-4bbf-9f48-0e0c2b1c7d5e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UnshareCategory", utils.MockContextMatcher, id, user).
+		Return(nil)
+
+	server.handleCategoriesSharesDelete(ctx)
+
+	assert.Equal(t, http.StatusNoContent, ctx.Writer.Status())
+}
@@ -0,0 +1,199 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// handleTagsCreate godoc
+// @Summary Create tags
+// @Description Create new user tags. Tags given to locations are created too when missing.
+// @Tags tags
+// @Accept  json
+// @Produce  json
+// @Param tag body models.CreateTag true "New tag"
+// @Success 200 {object} models.Tag "The created tag"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /tags [post]
+func (s *HTTPServer) handleTagsCreate(c *gin.Context) {
+	var body models.CreateTag
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Errorf("TagsCreate: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	user, ok := models.NewUserFromContext(c.Request.Context())
+	if !ok {
+		logger.Error("TagsCreate: failed to get user from request context.")
+		abort(c, http.StatusInternalServerError, "Failed to get user data")
+		return
+	}
+
+	tag := models.NewTag(models.NewID(), body.Name, user.ID)
+
+	err := s.api.LocationUsecase.CreateTag(c.Request.Context(), tag)
+	switch {
+	case err != nil:
+		abortWithError(c, "TagsCreate", err, "Failed to create tag")
+		return
+	default:
+		c.JSON(http.StatusOK, tag)
+	}
+}
+
+// handleTagsGet godoc
+// @Summary Get tags
+// @Description Get all user tags, sorted by name.
+// @Tags tags
+// @Produce  json
+// @Success 200 {object} models.Tags "The returned tags"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /tags [get]
+func (s *HTTPServer) handleTagsGet(c *gin.Context) {
+	tags, err := s.api.LocationUsecase.GetTags(c.Request.Context())
+	switch {
+	case err != nil:
+		abortWithError(c, "TagsGet", err, "Failed to get tags")
+		return
+	default:
+		c.JSON(http.StatusOK, tags)
+	}
+}
+
+// handleTagsGetByID godoc
+// @Summary Get tag with specified ID
+// @Description Get one specific user tag using provided ID.
+// @Tags tags
+// @Produce  json
+// @Param id path string true "Tag ID"
+// @Success 200 {object} models.Tag "The returned tag"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /tags/{id} [get]
+func (s *HTTPServer) handleTagsGetByID(c *gin.Context) {
+	var query models.GetTagByID
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("TagsGetByID: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("TagsGetByID: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	tag, err := s.api.LocationUsecase.FindTagByID(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		abortWithError(c, "TagsGetByID", err, "Failed to get tag")
+		return
+	default:
+		c.JSON(http.StatusOK, tag)
+	}
+}
+
+// handleTagsUpdate godoc
+// @Summary Update tag
+// @Description Rename specified tag. Locations keep the renamed tag.
+// @Tags tags
+// @Produce  json
+// @Param id path string true "Tag ID"
+// @Param tag body models.UpdateTag true "Tag name"
+// @Success 200 {object} models.Tag "The updated tag"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /tags/{id} [put]
+func (s *HTTPServer) handleTagsUpdate(c *gin.Context) {
+	var query models.UpdateTagQuery
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("TagsUpdate: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	var body models.UpdateTag
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Errorf("TagsUpdate: invalid body. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("TagsUpdate: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	user, ok := models.NewUserFromContext(c.Request.Context())
+	if !ok {
+		logger.Error("TagsUpdate: failed to get user from request context.")
+		abort(c, http.StatusInternalServerError, "Failed to get user data")
+		return
+	}
+
+	tag := models.NewTag(id, body.Name, user.ID)
+
+	err = s.api.LocationUsecase.UpdateTag(c.Request.Context(), tag)
+	switch {
+	case err != nil:
+		abortWithError(c, "TagsUpdate", err, "Failed to update tag")
+		return
+	default:
+		c.JSON(http.StatusOK, tag)
+	}
+}
+
+// handleTagsDelete godoc
+// @Summary Delete tag
+// @Description Delete one specific tag using provided ID. Tag is removed from all locations.
+// @Tags tags
+// @Param id path string true "Tag ID"
+// @Success 204 "OK"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /tags/{id} [delete]
+func (s *HTTPServer) handleTagsDelete(c *gin.Context) {
+	var query models.DeleteTag
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("TagsDelete: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	id, err := models.ParseID(query.ID)
+	if err != nil {
+		logger.Errorf("TagsDelete: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	err = s.api.LocationUsecase.DeleteTag(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		abortWithError(c, "TagsDelete", err, "Failed to delete tag")
+		return
+	default:
+		c.Status(http.StatusNoContent)
+	}
+}
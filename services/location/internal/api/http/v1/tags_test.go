@@ -0,0 +1,382 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestV1CreateTagWithInvalidBody(t *testing.T) {
+	for _, body := range []*gin.H{
+		nil,
+		{"name": ""},
+		{"name": "Vegan,Favorite"},
+	} {
+		ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/tags", body, nil)
+
+		server.handleTagsCreate(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+	}
+}
+
+func TestV1CreateTagWithAlreadyExistsError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/tags", &gin.H{
+		"name": "Favorite",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateTag", utils.MockContextMatcher, mock.AnythingOfType("*models.Tag")).
+		Return(usecases.ErrTagAlreadyExists)
+
+	server.handleTagsCreate(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1CreateTagWithForbiddenError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/tags", &gin.H{
+		"name": "Favorite",
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateTag", utils.MockContextMatcher, mock.AnythingOfType("*models.Tag")).
+		Return(usecases.ErrForbidden)
+
+	server.handleTagsCreate(ctx)
+
+	assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
+}
+
+func TestV1CreateTagWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "POST", "/api/v1/tags", &gin.H{
+		"name": "Favorite",
+	}, nil)
+
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateTag", utils.MockContextMatcher, mock.AnythingOfType("*models.Tag")).
+		Return(nil)
+
+	server.handleTagsCreate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var tag models.Tag
+	err := json.NewDecoder(resp.Result().Body).Decode(&tag)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Favorite", tag.Name)
+		assert.Equal(t, user.ID, tag.User)
+	}
+}
+
+func TestV1GetTagsWithError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/tags", nil, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetTags", utils.MockContextMatcher).
+		Return(nil, errors.New("failed"))
+
+	server.handleTagsGet(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, ctx.Writer.Status())
+}
+
+func TestV1GetTagsWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/tags", nil, nil)
+
+	tags := &models.Tags{models.NewTag(models.NewID(), "Favorite", models.NewID())}
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetTags", utils.MockContextMatcher).
+		Return(tags, nil)
+
+	server.handleTagsGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedTags models.Tags
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedTags)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *tags, returnedTags)
+	}
+}
+
+func TestV1GetTagsByIDWithTagNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/tags/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindTagByID", utils.MockContextMatcher, id).
+		Return(nil, usecases.ErrTagNotFound)
+
+	server.handleTagsGetByID(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1UpdateTagWithAlreadyExistsError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/tags/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		&gin.H{
+			"name": "Favorite",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UpdateTag", utils.MockContextMatcher, mock.AnythingOfType("*models.Tag")).
+		Return(usecases.ErrTagAlreadyExists)
+
+	server.handleTagsUpdate(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
+
+func TestV1UpdateTagWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/tags/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		&gin.H{
+			"name": "Favorite",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+	user, _ := models.NewUserFromContext(ctx.Request.Context())
+	tag := models.NewTag(id, "Favorite", user.ID)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UpdateTag", utils.MockContextMatcher, tag).
+		Return(nil)
+
+	server.handleTagsUpdate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var returnedTag models.Tag
+	err := json.NewDecoder(resp.Result().Body).Decode(&returnedTag)
+	if assert.NoError(t, err) {
+		assert.Equal(t, *tag, returnedTag)
+	}
+}
+
+func TestV1DeleteTagWithTagNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/tags/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteTag", utils.MockContextMatcher, id).
+		Return(usecases.ErrTagNotFound)
+
+	server.handleTagsDelete(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1DeleteTagWithSuccess(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"DELETE",
+		"/api/v1/tags/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	id, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("DeleteTag", utils.MockContextMatcher, id).
+		Return(nil)
+
+	server.handleTagsDelete(ctx)
+
+	assert.Equal(t, http.StatusNoContent, ctx.Writer.Status())
+}
+
+func TestV1CreateLocationWithTags(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+		"name":        "Test Location",
+		"address":     "1 rue de la Poste, 75001 Paris",
+		"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+		"tags":        []string{"Vegan", "Favorite", " Vegan"},
+	}, nil)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("CreateLocation", utils.MockContextMatcher, mock.MatchedBy(func(loc *models.Location) bool {
+			return assert.ObjectsAreEqual([]string{"Favorite", "Vegan"}, loc.Tags)
+		})).
+		Return(nil)
+
+	server.handleLocationsCreate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+
+	var loc models.Location
+	err := json.NewDecoder(resp.Result().Body).Decode(&loc)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Favorite", "Vegan"}, loc.Tags)
+	}
+}
+
+func TestV1CreateLocationWithInvalidTags(t *testing.T) {
+	for _, tags := range [][]string{
+		{""},
+		{"Vegan,Favorite"},
+	} {
+		ctx, _, server := newHandlerTestContext(t, "POST", "/api/v1/locations", &gin.H{
+			"name":        "Test Location",
+			"address":     "1 rue de la Poste, 75001 Paris",
+			"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			"tags":        tags,
+		}, nil)
+
+		server.handleLocationsCreate(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), tags)
+	}
+}
+
+func TestV1UpdateLocationWithoutTags(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"PUT",
+		"/api/v1/locations/4b7a536e-7109-4a39-9549-f06f74f2093e",
+		&gin.H{
+			"name":        "Test Location",
+			"address":     "1 rue de la Poste, 75001 Paris",
+			"category_id": "4b7a536e-7109-4a39-9549-f06f74f2093e",
+		},
+		&[]gin.Param{
+			{
+				Key:   "id",
+				Value: "4b7a536e-7109-4a39-9549-f06f74f2093e",
+			},
+		},
+	)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("UpdateLocation", utils.MockContextMatcher, mock.MatchedBy(func(loc *models.Location) bool {
+			return loc.Tags == nil
+		})).
+		Return(nil)
+
+	server.handleLocationsUpdate(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsByTagsWithSuccess(t *testing.T) {
+	for url, matchAll := range map[string]bool{
+		"/api/v1/locations?tags=Vegan,Favorite":           true,
+		"/api/v1/locations?tags=Vegan,Favorite&match=all": true,
+		"/api/v1/locations?tags=Vegan,Favorite&match=any": false,
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", url, nil, nil)
+
+		server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+			On("FindLocationsByTags", utils.MockContextMatcher, models.NilID, []string{"Favorite", "Vegan"}, matchAll).
+			Return(&models.Locations{}, nil)
+
+		server.handleLocationsGet(ctx)
+
+		assert.Equal(t, http.StatusOK, ctx.Writer.Status(), url)
+	}
+}
+
+func TestV1GetLocationsByTagsWithCategoryNotFound(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(
+		t,
+		"GET",
+		"/api/v1/locations?tags=Favorite&category_id=4b7a536e-7109-4a39-9549-f06f74f2093e",
+		nil,
+		nil,
+	)
+
+	catID, _ := models.ParseID("4b7a536e-7109-4a39-9549-f06f74f2093e")
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("FindLocationsByTags", utils.MockContextMatcher, catID, []string{"Favorite"}, true).
+		Return(nil, usecases.ErrCategoryNotFound)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1GetLocationsByTagsWithInvalidQuery(t *testing.T) {
+	for _, url := range []string{
+		"/api/v1/locations?tags=Favorite&match=some",
+		"/api/v1/locations?tags=Favorite&expand=category",
+		"/api/v1/locations?tags=Favorite&bbox=2.25,48.81,2.42,48.9",
+		"/api/v1/locations?tags=Favorite&category_id=4b7a536e-7109-4a39-9549-f06f74f2093e&include_descendants=true",
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", url, nil, nil)
+
+		server.handleLocationsGet(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), url)
+	}
+}
+
+func TestV1GetLocationsAsNDJSONWithTags(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/locations?tags=Favorite", nil, nil)
+	ctx.Request.Header.Set("Accept", mimeNDJSON)
+
+	server.handleLocationsGet(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status())
+}
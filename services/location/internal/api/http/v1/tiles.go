@@ -0,0 +1,94 @@
+package httpapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/tiles"
+	"github.com/gin-gonic/gin"
+)
+
+// Media type of Mapbox Vector Tiles
+const tileContentType = "application/vnd.mapbox-vector-tile"
+
+// handleTilesGet godoc
+// @Summary Get a vector tile of locations
+// @Description Render user locations covered by a map tile as a Mapbox Vector Tile, in a single "locations" layer.
+// @Description Features are points with the location ID and name and the category ID and name as properties.
+// @Description Tiles are revalidated using weak ETags: a matching If-None-Match header returns 304.
+// @Tags locations
+// @Produce  application/vnd.mapbox-vector-tile
+// @Param z path int true "Zoom level of the tile, from 0 to 22"
+// @Param x path int true "Column of the tile"
+// @Param y path int true "Row of the tile"
+// @Param If-None-Match header string false "ETag of a previously fetched tile"
+// @Success 200 {file} binary "The vector tile"
+// @Success 304 {string} string "Tile not modified"
+// @Failure 400 {object} HTTPError "Bad Request"
+// @Failure 403 {object} HTTPError "Forbidden"
+// @Failure 404 {object} HTTPError "Not Found"
+// @Failure 500 {object} HTTPError "Internal Server Error"
+// @Failure 503 {object} HTTPError "Service Unavailable"
+// @Router /tiles/{z}/{x}/{y}.mvt [get]
+func (s *HTTPServer) handleTilesGet(c *gin.Context) {
+	var query models.GetLocationTile
+	if err := c.ShouldBindUri(&query); err != nil {
+		logger.Errorf("TilesGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	row, err := query.Row()
+	if err != nil {
+		logger.Errorf("TilesGet: invalid query. %v", err)
+		abort(c, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	tile, err := tiles.NewTile(query.Z, query.X, row)
+	if err != nil {
+		abort(c, http.StatusNotFound, "Tile not found")
+		return
+	}
+
+	data, err := s.api.LocationUsecase.GetLocationTile(c.Request.Context(), tile)
+	if err != nil {
+		abortWithError(c, "TilesGet", err, "Failed to get tile")
+		return
+	}
+
+	etag := tileETag(data)
+	// Tiles are private, and must be revalidated as locations change
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("ETag", etag)
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, tileContentType, data)
+}
+
+// tileETag returns a weak entity tag of tile data. The tag must be weak because the
+// compression middleware may send the same tile with different content encodings.
+func tileETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches tells whether If-None-Match header value matches etag, using weak comparison
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
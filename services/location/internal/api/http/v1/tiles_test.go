@@ -0,0 +1,126 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/api"
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/tiles"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTileParams(z, x, y string) *[]gin.Param {
+	return &[]gin.Param{
+		{Key: "z", Value: z},
+		{Key: "x", Value: x},
+		{Key: "y.mvt", Value: y},
+	}
+}
+
+func TestV1TilesGetWithInvalidQuery(t *testing.T) {
+	for _, params := range []*[]gin.Param{
+		newTileParams("abc", "518", "352.mvt"),
+		newTileParams("23", "0", "0.mvt"),
+		newTileParams("10", "-1", "352.mvt"),
+		newTileParams("10", "518", "352.png"),
+		newTileParams("10", "518", "abc.mvt"),
+	} {
+		ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/tiles", nil, params)
+
+		server.handleTilesGet(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, ctx.Writer.Status(), *params)
+	}
+}
+
+func TestV1TilesGetWithTileOutOfRange(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/tiles/2/4/0.mvt", nil, newTileParams("2", "4", "0.mvt"))
+
+	server.handleTilesGet(ctx)
+
+	assert.Equal(t, http.StatusNotFound, ctx.Writer.Status())
+}
+
+func TestV1TilesGetWithError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/tiles/10/518/352.mvt", nil, newTileParams("10", "518", "352.mvt"))
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocationTile", utils.MockContextMatcher, tiles.Tile{Z: 10, X: 518, Y: 352}).
+		Return(nil, errors.New("failed"))
+
+	server.handleTilesGet(ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, ctx.Writer.Status())
+}
+
+func TestV1TilesGetWithOverloadedError(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/tiles/10/518/352.mvt", nil, newTileParams("10", "518", "352.mvt"))
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocationTile", utils.MockContextMatcher, tiles.Tile{Z: 10, X: 518, Y: 352}).
+		Return(nil, api.ErrOverloaded)
+
+	server.handleTilesGet(ctx)
+
+	assert.Equal(t, http.StatusServiceUnavailable, ctx.Writer.Status())
+}
+
+func TestV1TilesGetWithSuccess(t *testing.T) {
+	ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/tiles/10/518/352.mvt", nil, newTileParams("10", "518", "352.mvt"))
+
+	data := []byte{0x1a, 0x00}
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocationTile", utils.MockContextMatcher, tiles.Tile{Z: 10, X: 518, Y: 352}).
+		Return(data, nil)
+
+	server.handleTilesGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Equal(t, "application/vnd.mapbox-vector-tile", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "private, no-cache", resp.Header().Get("Cache-Control"))
+	assert.Equal(t, tileETag(data), resp.Header().Get("ETag"))
+	assert.True(t, strings.HasPrefix(resp.Header().Get("ETag"), `W/"`))
+	assert.Equal(t, data, resp.Body.Bytes())
+}
+
+func TestV1TilesGetWithMatchingETag(t *testing.T) {
+	data := []byte{0x1a, 0x00}
+	for _, header := range []string{
+		tileETag(data),
+		`"other", ` + tileETag(data),
+		strings.TrimPrefix(tileETag(data), "W/"),
+		"*",
+	} {
+		ctx, resp, server := newHandlerTestContext(t, "GET", "/api/v1/tiles/10/518/352.mvt", nil, newTileParams("10", "518", "352.mvt"))
+		ctx.Request.Header.Set("If-None-Match", header)
+
+		server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+			On("GetLocationTile", utils.MockContextMatcher, tiles.Tile{Z: 10, X: 518, Y: 352}).
+			Return(data, nil)
+
+		server.handleTilesGet(ctx)
+		ctx.Writer.WriteHeaderNow()
+
+		assert.Equal(t, http.StatusNotModified, resp.Code, header)
+		assert.Equal(t, tileETag(data), resp.Header().Get("ETag"))
+		assert.Empty(t, resp.Body.Bytes())
+	}
+}
+
+func TestV1TilesGetWithStaleETag(t *testing.T) {
+	ctx, _, server := newHandlerTestContext(t, "GET", "/api/v1/tiles/10/518/352.mvt", nil, newTileParams("10", "518", "352.mvt"))
+	ctx.Request.Header.Set("If-None-Match", `"stale"`)
+
+	server.api.LocationUsecase.(*mocks.LocationUsecaseMock).
+		On("GetLocationTile", utils.MockContextMatcher, tiles.Tile{Z: 10, X: 518, Y: 352}).
+		Return([]byte{0x1a, 0x00}, nil)
+
+	server.handleTilesGet(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+}
@@ -0,0 +1,49 @@
+package httpapi
+
+import (
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/pluscodes"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	registerValidators()
+}
+
+// registerValidators adds custom validation tags, used in models binding, to gin validator
+func registerValidators() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		logger.Fatal("Unexpected gin validator engine")
+	}
+
+	if err := v.RegisterValidation("coordinate_precision", validateCoordinatePrecision); err != nil {
+		logger.Fatalf("Failed to register coordinate_precision validator. %v", err)
+	}
+	if err := v.RegisterValidation("plus_code", validatePlusCode); err != nil {
+		logger.Fatalf("Failed to register plus_code validator. %v", err)
+	}
+
+	v.RegisterStructValidation(validateCoordinatesPair, models.CreateLocation{}, models.UpdateLocation{})
+}
+
+func validateCoordinatePrecision(fl validator.FieldLevel) bool {
+	return models.ValidCoordinatePrecision(fl.Field().Float())
+}
+
+// validatePlusCode ensures that field holds a full or short plus code, optionally followed by a locality
+func validatePlusCode(fl validator.FieldLevel) bool {
+	code, _ := pluscodes.Split(fl.Field().String())
+	return pluscodes.CheckValid(code) == nil
+}
+
+// validateCoordinatesPair ensures that latitude and longitude are either both set or both empty
+func validateCoordinatesPair(sl validator.StructLevel) {
+	latitude := sl.Current().FieldByName("Latitude")
+	longitude := sl.Current().FieldByName("Longitude")
+
+	if latitude.IsNil() != longitude.IsNil() {
+		sl.ReportError(latitude.Interface(), "Latitude", "latitude", "coordinates_pair", "")
+	}
+}
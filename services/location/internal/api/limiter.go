@@ -0,0 +1,752 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/apperrors"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/pluscodes"
+	"github.com/edebernis/social-life-manager/services/location/internal/tiles"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// ErrOverloaded is returned when a request has been shed by the concurrency limiter,
+	// either because too many requests are already in flight or because its deadline
+	// cannot be met given the currently observed latency.
+	ErrOverloaded = apperrors.New(apperrors.KindUnavailable, "", "service overloaded")
+)
+
+// Priority of a request going through the concurrency limiter
+type Priority int
+
+const (
+	// PriorityWrite is used for requests modifying data. They are shed first.
+	PriorityWrite Priority = iota
+	// PriorityRead is used for requests only reading data.
+	PriorityRead
+)
+
+func (p Priority) String() string {
+	if p == PriorityRead {
+		return "read"
+	}
+	return "write"
+}
+
+// LimiterConfig holds adaptive concurrency limiter settings
+type LimiterConfig struct {
+	// Number of concurrent requests allowed at startup.
+	InitialLimit int
+	// Lower and upper bounds of the concurrency limit.
+	MinLimit int
+	MaxLimit int
+	// Requests taking longer than this threshold are considered as a congestion signal.
+	LatencyThreshold time.Duration
+	// Multiplicative factor applied to the limit on congestion. Must be between 0 and 1.
+	BackoffRatio float64
+	// Share of the limit that write requests may use. The remaining is reserved for reads.
+	WriteRatio float64
+}
+
+// AdaptiveLimiter limits the number of concurrent requests using an AIMD algorithm.
+// The limit grows additively while requests complete quickly and shrinks
+// multiplicatively as soon as latency goes above the configured threshold.
+type AdaptiveLimiter struct {
+	config  *LimiterConfig
+	metrics *limiterMetrics
+
+	mu       sync.Mutex
+	limit    float64
+	inflight int
+	// Exponentially weighted moving average of request latencies, in seconds
+	avgLatency float64
+}
+
+// NewAdaptiveLimiter creates a new AdaptiveLimiter
+func NewAdaptiveLimiter(config *LimiterConfig, registry prometheus.Registerer) *AdaptiveLimiter {
+	l := &AdaptiveLimiter{
+		config:  config,
+		metrics: newLimiterMetrics(registry),
+		limit:   float64(config.InitialLimit),
+	}
+	l.metrics.limit.Set(l.limit)
+
+	return l
+}
+
+// Acquire reserves a slot for a request of specified priority. It never blocks:
+// ErrOverloaded is returned straight away when no slot is available. On success,
+// the returned function must be called with the request result once it completes.
+func (l *AdaptiveLimiter) Acquire(ctx context.Context, p Priority) (func(error), error) {
+	if err := l.reserve(ctx, p); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	return func(err error) {
+		l.release(time.Since(start), err)
+	}, nil
+}
+
+// AcquireStream reserves a read slot for a streaming request. Streams last as long as
+// the client keeps reading, so their duration is not a latency sample: the returned
+// function frees the slot without adjusting the limit.
+func (l *AdaptiveLimiter) AcquireStream(ctx context.Context) (func(), error) {
+	if err := l.reserve(ctx, PriorityRead); err != nil {
+		return nil, err
+	}
+
+	return l.free, nil
+}
+
+// Limit returns the current concurrency limit
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.limit)
+}
+
+// canMeetDeadline checks that enough time remains before the request deadline
+// to process it, based on the average latency observed so far.
+func (l *AdaptiveLimiter) canMeetDeadline(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return true
+	}
+
+	return time.Until(deadline).Seconds() > l.avgLatency
+}
+
+// capacity returns how many requests of specified priority may be in flight
+func (l *AdaptiveLimiter) capacity(p Priority) float64 {
+	if p == PriorityRead {
+		return l.limit
+	}
+
+	return math.Max(1, l.limit*l.config.WriteRatio)
+}
+
+func (l *AdaptiveLimiter) reserve(ctx context.Context, p Priority) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.canMeetDeadline(ctx) || float64(l.inflight) >= l.capacity(p) {
+		l.metrics.shedCount.WithLabelValues(p.String()).Inc()
+		return ErrOverloaded
+	}
+
+	l.inflight++
+	l.metrics.inflight.Set(float64(l.inflight))
+
+	return nil
+}
+
+func (l *AdaptiveLimiter) free() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inflight--
+	l.metrics.inflight.Set(float64(l.inflight))
+}
+
+func (l *AdaptiveLimiter) release(latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch {
+	case latency > l.config.LatencyThreshold || errors.Is(err, context.DeadlineExceeded):
+		l.limit = math.Max(float64(l.config.MinLimit), l.limit*l.config.BackoffRatio)
+	case float64(l.inflight)*2 >= l.limit:
+		// Only grow the limit when it is actually being used, so that it does not
+		// drift to the maximum during quiet periods.
+		l.limit = math.Min(float64(l.config.MaxLimit), l.limit+1/l.limit)
+	}
+
+	if l.avgLatency == 0 {
+		l.avgLatency = latency.Seconds()
+	} else {
+		l.avgLatency = 0.9*l.avgLatency + 0.1*latency.Seconds()
+	}
+
+	l.inflight--
+	l.metrics.inflight.Set(float64(l.inflight))
+	l.metrics.limit.Set(l.limit)
+}
+
+type limiterMetrics struct {
+	namespace string
+	subsystem string
+
+	limit     prometheus.Gauge
+	inflight  prometheus.Gauge
+	shedCount *prometheus.CounterVec
+}
+
+func newLimiterMetrics(registry prometheus.Registerer) *limiterMetrics {
+	metrics := &limiterMetrics{
+		namespace: "api",
+		subsystem: "limiter",
+	}
+
+	metrics.limit = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metrics.namespace,
+			Subsystem: metrics.subsystem,
+			Name:      "limit",
+			Help:      "Current number of concurrent requests allowed.",
+		},
+	)
+	registry.MustRegister(metrics.limit)
+
+	metrics.inflight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metrics.namespace,
+			Subsystem: metrics.subsystem,
+			Name:      "inflight_requests",
+			Help:      "Current number of requests being processed.",
+		},
+	)
+	registry.MustRegister(metrics.inflight)
+
+	metrics.shedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.namespace,
+			Subsystem: metrics.subsystem,
+			Name:      "shed_requests_total",
+			Help:      "How many requests have been rejected by the limiter, partitioned by priority.",
+		},
+		[]string{"priority"},
+	)
+	registry.MustRegister(metrics.shedCount)
+
+	return metrics
+}
+
+// LimitedLocationUsecase protects a location usecase with an adaptive concurrency limiter.
+// It implements the ILocationUsecase interface so that it is used the same way by every transport.
+type LimitedLocationUsecase struct {
+	usecase ILocationUsecase
+	limiter *AdaptiveLimiter
+}
+
+// NewLimitedLocationUsecase creates a new LimitedLocationUsecase
+func NewLimitedLocationUsecase(usecase ILocationUsecase, limiter *AdaptiveLimiter) *LimitedLocationUsecase {
+	return &LimitedLocationUsecase{usecase, limiter}
+}
+
+// CreateCategory stores a new location category
+func (u *LimitedLocationUsecase) CreateCategory(ctx context.Context, cat *models.Category) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.CreateCategory(ctx, cat)
+}
+
+// GetCategories returns all categories
+func (u *LimitedLocationUsecase) GetCategories(ctx context.Context) (_ *models.Categories, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetCategories(ctx)
+}
+
+// FindCategoryByID returns category matching specified ID
+func (u *LimitedLocationUsecase) FindCategoryByID(ctx context.Context, id models.ID) (_ *models.Category, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindCategoryByID(ctx, id)
+}
+
+// UpdateCategory updates specified category
+func (u *LimitedLocationUsecase) UpdateCategory(ctx context.Context, cat *models.Category) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.UpdateCategory(ctx, cat)
+}
+
+// DeleteCategory deletes specified category
+func (u *LimitedLocationUsecase) DeleteCategory(ctx context.Context, id models.ID, deletion models.CategoryDeletion) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.DeleteCategory(ctx, id, deletion)
+}
+
+// MergeCategories merges source categories into target category
+func (u *LimitedLocationUsecase) MergeCategories(ctx context.Context, targetID models.ID, sourceIDs []models.ID) (_ *models.BulkReport, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.MergeCategories(ctx, targetID, sourceIDs)
+}
+
+// CreateLocation stores a new user location
+func (u *LimitedLocationUsecase) CreateLocation(ctx context.Context, loc *models.Location) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.CreateLocation(ctx, loc)
+}
+
+// GetLocations returns all locations of a specific user
+func (u *LimitedLocationUsecase) GetLocations(ctx context.Context) (_ *models.Locations, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetLocations(ctx)
+}
+
+// FindLocationByID returns location matching specified ID
+func (u *LimitedLocationUsecase) FindLocationByID(ctx context.Context, id models.ID) (_ *models.Location, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindLocationByID(ctx, id)
+}
+
+// FindLocationsByCategory returns locations matching specified category
+func (u *LimitedLocationUsecase) FindLocationsByCategory(ctx context.Context, id models.ID, descendants bool) (_ *models.Locations, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindLocationsByCategory(ctx, id, descendants)
+}
+
+// GetExpandedLocations returns locations with their category embedded, filtered by category unless id is NilID
+func (u *LimitedLocationUsecase) GetExpandedLocations(ctx context.Context, id models.ID) (_ *models.Locations, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetExpandedLocations(ctx, id)
+}
+
+// FindExpandedLocationByID returns location matching specified ID with its category embedded
+func (u *LimitedLocationUsecase) FindExpandedLocationByID(ctx context.Context, id models.ID) (_ *models.Location, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindExpandedLocationByID(ctx, id)
+}
+
+// StreamLocations calls fn for each location, filtered by category unless id is NilID
+func (u *LimitedLocationUsecase) StreamLocations(ctx context.Context, id models.ID, fn func(*models.Location) error) error {
+	release, err := u.limiter.AcquireStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return u.usecase.StreamLocations(ctx, id, fn)
+}
+
+// FindLocationsNear returns locations within radius meters of center, filtered by category unless id is NilID
+func (u *LimitedLocationUsecase) FindLocationsNear(ctx context.Context, id models.ID, center models.Point, radius float64) (_ *models.Locations, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindLocationsNear(ctx, id, center, radius)
+}
+
+// FindLocationsInBoundingBox returns locations inside bbox, filtered by category unless id is NilID
+func (u *LimitedLocationUsecase) FindLocationsInBoundingBox(ctx context.Context, id models.ID, bbox models.BoundingBox) (_ *models.Locations, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindLocationsInBoundingBox(ctx, id, bbox)
+}
+
+// GetLocationClusters returns clusters of locations inside bbox, sized for zoom level
+func (u *LimitedLocationUsecase) GetLocationClusters(ctx context.Context, id models.ID, bbox models.BoundingBox, zoom int) (_ *models.Clusters, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetLocationClusters(ctx, id, bbox, zoom)
+}
+
+// GetLocationTile renders locations covered by tile as a Mapbox Vector Tile
+func (u *LimitedLocationUsecase) GetLocationTile(ctx context.Context, tile tiles.Tile) (_ []byte, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetLocationTile(ctx, tile)
+}
+
+// ResolvePlusCode returns the area covered by a plus code
+func (u *LimitedLocationUsecase) ResolvePlusCode(ctx context.Context, code string, referenceID models.ID) (_ *pluscodes.CodeArea, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.ResolvePlusCode(ctx, code, referenceID)
+}
+
+// UpdateLocation updates specified location
+func (u *LimitedLocationUsecase) UpdateLocation(ctx context.Context, loc *models.Location) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.UpdateLocation(ctx, loc)
+}
+
+// DeleteLocation deletes specified location
+func (u *LimitedLocationUsecase) DeleteLocation(ctx context.Context, id models.ID) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.DeleteLocation(ctx, id)
+}
+
+// MoveLocations moves user locations to specified category at once
+func (u *LimitedLocationUsecase) MoveLocations(ctx context.Context, ids []models.ID, catID models.ID) (_ *models.BulkReport, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.MoveLocations(ctx, ids, catID)
+}
+
+// CreateTag stores a new user tag
+func (u *LimitedLocationUsecase) CreateTag(ctx context.Context, tag *models.Tag) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.CreateTag(ctx, tag)
+}
+
+// GetTags returns all user tags
+func (u *LimitedLocationUsecase) GetTags(ctx context.Context) (_ *models.Tags, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetTags(ctx)
+}
+
+// FindTagByID returns tag matching specified ID
+func (u *LimitedLocationUsecase) FindTagByID(ctx context.Context, id models.ID) (_ *models.Tag, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindTagByID(ctx, id)
+}
+
+// UpdateTag renames specified tag
+func (u *LimitedLocationUsecase) UpdateTag(ctx context.Context, tag *models.Tag) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.UpdateTag(ctx, tag)
+}
+
+// DeleteTag deletes specified tag
+func (u *LimitedLocationUsecase) DeleteTag(ctx context.Context, id models.ID) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.DeleteTag(ctx, id)
+}
+
+// FindLocationsByTags returns locations having all or any of specified tags, filtered by category unless id is NilID
+func (u *LimitedLocationUsecase) FindLocationsByTags(ctx context.Context, id models.ID, names []string, matchAll bool) (_ *models.Locations, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindLocationsByTags(ctx, id, names, matchAll)
+}
+
+// ReverseGeocode returns the place found at specified coordinates
+func (u *LimitedLocationUsecase) ReverseGeocode(ctx context.Context, latitude, longitude float64) (_ *models.Place, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.ReverseGeocode(ctx, latitude, longitude)
+}
+
+// GetLocationShares returns the roles granted on a location to other users
+func (u *LimitedLocationUsecase) GetLocationShares(ctx context.Context, id models.ID) (_ *models.Shares, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetLocationShares(ctx, id)
+}
+
+// ShareLocation grants a role on a location to another user
+func (u *LimitedLocationUsecase) ShareLocation(ctx context.Context, id models.ID, share *models.Share) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.ShareLocation(ctx, id, share)
+}
+
+// UnshareLocation revokes the role granted on a location to a user
+func (u *LimitedLocationUsecase) UnshareLocation(ctx context.Context, id models.ID, user models.ID) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.UnshareLocation(ctx, id, user)
+}
+
+// GetCategoryShares returns the roles granted on a category to other users
+func (u *LimitedLocationUsecase) GetCategoryShares(ctx context.Context, id models.ID) (_ *models.Shares, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetCategoryShares(ctx, id)
+}
+
+// ShareCategory grants a role on all locations of a category to another user
+func (u *LimitedLocationUsecase) ShareCategory(ctx context.Context, id models.ID, share *models.Share) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.ShareCategory(ctx, id, share)
+}
+
+// UnshareCategory revokes the role granted on a category to a user
+func (u *LimitedLocationUsecase) UnshareCategory(ctx context.Context, id models.ID, user models.ID) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.UnshareCategory(ctx, id, user)
+}
+
+// CreateGroup stores a new group, owned by the user
+func (u *LimitedLocationUsecase) CreateGroup(ctx context.Context, group *models.Group) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.CreateGroup(ctx, group)
+}
+
+// GetGroups returns all groups the user is a member of
+func (u *LimitedLocationUsecase) GetGroups(ctx context.Context) (_ *models.Groups, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetGroups(ctx)
+}
+
+// FindGroupByID returns group of the user matching specified ID
+func (u *LimitedLocationUsecase) FindGroupByID(ctx context.Context, id models.ID) (_ *models.Group, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.FindGroupByID(ctx, id)
+}
+
+// UpdateGroup renames specified group
+func (u *LimitedLocationUsecase) UpdateGroup(ctx context.Context, group *models.Group) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.UpdateGroup(ctx, group)
+}
+
+// DeleteGroup deletes specified group
+func (u *LimitedLocationUsecase) DeleteGroup(ctx context.Context, id models.ID) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.DeleteGroup(ctx, id)
+}
+
+// GetGroupMembers returns the members of a group
+func (u *LimitedLocationUsecase) GetGroupMembers(ctx context.Context, id models.ID) (_ *models.GroupMembers, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetGroupMembers(ctx, id)
+}
+
+// SetGroupMember adds a member to a group, or changes the role of a member
+func (u *LimitedLocationUsecase) SetGroupMember(ctx context.Context, id models.ID, member *models.GroupMember) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.SetGroupMember(ctx, id, member)
+}
+
+// RemoveGroupMember removes a member from a group
+func (u *LimitedLocationUsecase) RemoveGroupMember(ctx context.Context, id models.ID, user models.ID) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.RemoveGroupMember(ctx, id, user)
+}
+
+// CreateShareLink stores a new share link to a location
+func (u *LimitedLocationUsecase) CreateShareLink(ctx context.Context, link *models.ShareLink) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.CreateShareLink(ctx, link)
+}
+
+// GetShareLinks returns the share links of a location
+func (u *LimitedLocationUsecase) GetShareLinks(ctx context.Context, id models.ID) (_ *models.ShareLinks, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetShareLinks(ctx, id)
+}
+
+// RevokeShareLink deletes a share link of a location
+func (u *LimitedLocationUsecase) RevokeShareLink(ctx context.Context, id models.ID, link models.ID) (err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityWrite)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.RevokeShareLink(ctx, id, link)
+}
+
+// GetPublicLocation returns the location shared by a share link token.
+// Counting the access is part of reading the location, so it is run as a read.
+func (u *LimitedLocationUsecase) GetPublicLocation(ctx context.Context, token string) (_ *models.PublicLocation, err error) {
+	release, err := u.limiter.Acquire(ctx, PriorityRead)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	return u.usecase.GetPublicLocation(ctx, token)
+}
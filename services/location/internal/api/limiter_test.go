@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/api/mocks"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/pkg/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAdaptiveLimiter(initialLimit int) *AdaptiveLimiter {
+	return NewAdaptiveLimiter(&LimiterConfig{
+		InitialLimit:     initialLimit,
+		MinLimit:         1,
+		MaxLimit:         10,
+		LatencyThreshold: time.Second,
+		BackoffRatio:     0.5,
+		WriteRatio:       0.5,
+	}, prometheus.NewRegistry())
+}
+
+func TestLimiterShedsRequestsAboveLimit(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(2)
+
+	_, err := limiter.Acquire(context.Background(), PriorityRead)
+	assert.NoError(t, err)
+	_, err = limiter.Acquire(context.Background(), PriorityRead)
+	assert.NoError(t, err)
+
+	_, err = limiter.Acquire(context.Background(), PriorityRead)
+	assert.Equal(t, ErrOverloaded, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(limiter.metrics.shedCount.WithLabelValues("read")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(limiter.metrics.inflight))
+}
+
+func TestLimiterReleasesSlot(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(1)
+
+	release, err := limiter.Acquire(context.Background(), PriorityRead)
+	assert.NoError(t, err)
+	release(nil)
+
+	_, err = limiter.Acquire(context.Background(), PriorityRead)
+	assert.NoError(t, err)
+}
+
+func TestLimiterPrioritizesReadsOverWrites(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(4)
+
+	for i := 0; i < 2; i++ {
+		_, err := limiter.Acquire(context.Background(), PriorityWrite)
+		assert.NoError(t, err)
+	}
+
+	_, err := limiter.Acquire(context.Background(), PriorityWrite)
+	assert.Equal(t, ErrOverloaded, err)
+
+	_, err = limiter.Acquire(context.Background(), PriorityRead)
+	assert.NoError(t, err)
+}
+
+func TestLimiterShedsRequestsWithExpiredDeadline(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	_, err := limiter.Acquire(ctx, PriorityRead)
+	assert.Equal(t, ErrOverloaded, err)
+}
+
+func TestLimiterShedsRequestsWithUnreachableDeadline(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(2)
+	limiter.inflight++
+	limiter.release(500*time.Millisecond, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := limiter.Acquire(ctx, PriorityRead)
+	assert.Equal(t, ErrOverloaded, err)
+}
+
+func TestLimiterDecreasesLimitOnHighLatency(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(8)
+	limiter.inflight++
+	limiter.release(2*time.Second, nil)
+
+	assert.Equal(t, 4, limiter.Limit())
+	assert.Equal(t, 4.0, testutil.ToFloat64(limiter.metrics.limit))
+}
+
+func TestLimiterDecreasesLimitOnDeadlineExceeded(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(8)
+	limiter.inflight++
+	limiter.release(time.Millisecond, context.DeadlineExceeded)
+
+	assert.Equal(t, 4, limiter.Limit())
+}
+
+func TestLimiterIncreasesLimitWhenSaturated(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(2)
+	limiter.inflight = 2
+	limiter.release(time.Millisecond, nil)
+
+	assert.Equal(t, 2.5, limiter.limit)
+}
+
+func TestLimiterKeepsLimitWhenIdle(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(8)
+	limiter.inflight++
+	limiter.release(time.Millisecond, nil)
+
+	assert.Equal(t, 8, limiter.Limit())
+}
+
+func TestLimiterStreamKeepsLimitAndLatency(t *testing.T) {
+	limiter := newTestAdaptiveLimiter(1)
+
+	release, err := limiter.AcquireStream(context.Background())
+	assert.NoError(t, err)
+	_, err = limiter.Acquire(context.Background(), PriorityRead)
+	assert.Equal(t, ErrOverloaded, err)
+
+	time.Sleep(10 * time.Millisecond)
+	release()
+
+	assert.Equal(t, 1.0, limiter.limit)
+	assert.Equal(t, 0.0, limiter.avgLatency)
+	assert.Equal(t, 0.0, testutil.ToFloat64(limiter.metrics.inflight))
+}
+
+func TestLimitedUsecaseWithOverloadedError(t *testing.T) {
+	usecase := new(mocks.LocationUsecaseMock)
+	limited := NewLimitedLocationUsecase(usecase, newTestAdaptiveLimiter(0))
+
+	_, err := limited.GetLocations(context.Background())
+	assert.Equal(t, ErrOverloaded, err)
+	usecase.AssertNotCalled(t, "GetLocations", utils.MockContextMatcher)
+}
+
+func TestLimitedUsecaseWithSuccess(t *testing.T) {
+	usecase := new(mocks.LocationUsecaseMock)
+	limiter := newTestAdaptiveLimiter(1)
+	limited := NewLimitedLocationUsecase(usecase, limiter)
+
+	locs := models.Locations{}
+	usecase.On("GetLocations", utils.MockContextMatcher).Return(&locs, nil)
+	usecase.On("DeleteLocation", utils.MockContextMatcher, models.NilID).Return(errors.New("failed"))
+
+	returnedLocs, err := limited.GetLocations(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &locs, returnedLocs)
+
+	err = limited.DeleteLocation(context.Background(), models.NilID)
+	assert.Error(t, err)
+	assert.Equal(t, 0.0, testutil.ToFloat64(limiter.metrics.inflight))
+}
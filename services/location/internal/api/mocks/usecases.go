@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/pluscodes"
+	"github.com/edebernis/social-life-manager/services/location/internal/tiles"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -45,11 +47,21 @@ func (u *LocationUsecaseMock) UpdateCategory(ctx context.Context, cat *models.Ca
 }
 
 // DeleteCategory deletes specified category
-func (u *LocationUsecaseMock) DeleteCategory(ctx context.Context, id models.ID) error {
-	args := u.Called(ctx, id)
+func (u *LocationUsecaseMock) DeleteCategory(ctx context.Context, id models.ID, deletion models.CategoryDeletion) error {
+	args := u.Called(ctx, id, deletion)
 	return args.Error(0)
 }
 
+// MergeCategories merges source categories into target category
+func (u *LocationUsecaseMock) MergeCategories(ctx context.Context, targetID models.ID, sourceIDs []models.ID) (*models.BulkReport, error) {
+	args := u.Called(ctx, targetID, sourceIDs)
+	report := args.Get(0)
+	if report == nil {
+		return nil, args.Error(1)
+	}
+	return report.(*models.BulkReport), args.Error(1)
+}
+
 // CreateLocation creates a new user location
 func (u *LocationUsecaseMock) CreateLocation(ctx context.Context, loc *models.Location) error {
 	args := u.Called(ctx, loc)
@@ -77,7 +89,17 @@ func (u *LocationUsecaseMock) FindLocationByID(ctx context.Context, id models.ID
 }
 
 // FindLocationsByCategory returns locations matching specified category or nil
-func (u *LocationUsecaseMock) FindLocationsByCategory(ctx context.Context, id models.ID) (*models.Locations, error) {
+func (u *LocationUsecaseMock) FindLocationsByCategory(ctx context.Context, id models.ID, descendants bool) (*models.Locations, error) {
+	args := u.Called(ctx, id, descendants)
+	locs := args.Get(0)
+	if locs == nil {
+		return nil, args.Error(1)
+	}
+	return locs.(*models.Locations), args.Error(1)
+}
+
+// GetExpandedLocations returns locations with their category embedded, filtered by category unless id is NilID
+func (u *LocationUsecaseMock) GetExpandedLocations(ctx context.Context, id models.ID) (*models.Locations, error) {
 	args := u.Called(ctx, id)
 	locs := args.Get(0)
 	if locs == nil {
@@ -86,6 +108,72 @@ func (u *LocationUsecaseMock) FindLocationsByCategory(ctx context.Context, id mo
 	return locs.(*models.Locations), args.Error(1)
 }
 
+// FindExpandedLocationByID returns location matching specified ID with its category embedded
+func (u *LocationUsecaseMock) FindExpandedLocationByID(ctx context.Context, id models.ID) (*models.Location, error) {
+	args := u.Called(ctx, id)
+	loc := args.Get(0)
+	if loc == nil {
+		return nil, args.Error(1)
+	}
+	return loc.(*models.Location), args.Error(1)
+}
+
+// StreamLocations calls fn for each location, filtered by category unless id is NilID
+func (u *LocationUsecaseMock) StreamLocations(ctx context.Context, id models.ID, fn func(*models.Location) error) error {
+	args := u.Called(ctx, id, fn)
+	return args.Error(0)
+}
+
+// FindLocationsNear returns locations within radius meters of center
+func (u *LocationUsecaseMock) FindLocationsNear(ctx context.Context, id models.ID, center models.Point, radius float64) (*models.Locations, error) {
+	args := u.Called(ctx, id, center, radius)
+	locs := args.Get(0)
+	if locs == nil {
+		return nil, args.Error(1)
+	}
+	return locs.(*models.Locations), args.Error(1)
+}
+
+// FindLocationsInBoundingBox returns locations inside bbox
+func (u *LocationUsecaseMock) FindLocationsInBoundingBox(ctx context.Context, id models.ID, bbox models.BoundingBox) (*models.Locations, error) {
+	args := u.Called(ctx, id, bbox)
+	locs := args.Get(0)
+	if locs == nil {
+		return nil, args.Error(1)
+	}
+	return locs.(*models.Locations), args.Error(1)
+}
+
+// GetLocationClusters returns clusters of locations inside bbox
+func (u *LocationUsecaseMock) GetLocationClusters(ctx context.Context, id models.ID, bbox models.BoundingBox, zoom int) (*models.Clusters, error) {
+	args := u.Called(ctx, id, bbox, zoom)
+	clusters := args.Get(0)
+	if clusters == nil {
+		return nil, args.Error(1)
+	}
+	return clusters.(*models.Clusters), args.Error(1)
+}
+
+// GetLocationTile renders locations covered by tile
+func (u *LocationUsecaseMock) GetLocationTile(ctx context.Context, tile tiles.Tile) ([]byte, error) {
+	args := u.Called(ctx, tile)
+	data := args.Get(0)
+	if data == nil {
+		return nil, args.Error(1)
+	}
+	return data.([]byte), args.Error(1)
+}
+
+// ResolvePlusCode returns the area covered by a plus code
+func (u *LocationUsecaseMock) ResolvePlusCode(ctx context.Context, code string, referenceID models.ID) (*pluscodes.CodeArea, error) {
+	args := u.Called(ctx, code, referenceID)
+	area := args.Get(0)
+	if area == nil {
+		return nil, args.Error(1)
+	}
+	return area.(*pluscodes.CodeArea), args.Error(1)
+}
+
 // UpdateLocation update specified location
 func (u *LocationUsecaseMock) UpdateLocation(ctx context.Context, loc *models.Location) error {
 	args := u.Called(ctx, loc)
@@ -97,3 +185,207 @@ func (u *LocationUsecaseMock) DeleteLocation(ctx context.Context, id models.ID)
 	args := u.Called(ctx, id)
 	return args.Error(0)
 }
+
+// MoveLocations moves user locations to specified category
+func (u *LocationUsecaseMock) MoveLocations(ctx context.Context, ids []models.ID, catID models.ID) (*models.BulkReport, error) {
+	args := u.Called(ctx, ids, catID)
+	report := args.Get(0)
+	if report == nil {
+		return nil, args.Error(1)
+	}
+	return report.(*models.BulkReport), args.Error(1)
+}
+
+// CreateTag creates a new tag
+func (u *LocationUsecaseMock) CreateTag(ctx context.Context, tag *models.Tag) error {
+	args := u.Called(ctx, tag)
+	return args.Error(0)
+}
+
+// GetTags returns all user tags
+func (u *LocationUsecaseMock) GetTags(ctx context.Context) (*models.Tags, error) {
+	args := u.Called(ctx)
+	tags := args.Get(0)
+	if tags == nil {
+		return nil, args.Error(1)
+	}
+	return tags.(*models.Tags), args.Error(1)
+}
+
+// FindTagByID returns tag matching specified ID
+func (u *LocationUsecaseMock) FindTagByID(ctx context.Context, id models.ID) (*models.Tag, error) {
+	args := u.Called(ctx, id)
+	tag := args.Get(0)
+	if tag == nil {
+		return nil, args.Error(1)
+	}
+	return tag.(*models.Tag), args.Error(1)
+}
+
+// UpdateTag renames specified tag
+func (u *LocationUsecaseMock) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	args := u.Called(ctx, tag)
+	return args.Error(0)
+}
+
+// DeleteTag deletes specified tag
+func (u *LocationUsecaseMock) DeleteTag(ctx context.Context, id models.ID) error {
+	args := u.Called(ctx, id)
+	return args.Error(0)
+}
+
+// FindLocationsByTags returns locations matching specified tags
+func (u *LocationUsecaseMock) FindLocationsByTags(ctx context.Context, id models.ID, names []string, matchAll bool) (*models.Locations, error) {
+	args := u.Called(ctx, id, names, matchAll)
+	locs := args.Get(0)
+	if locs == nil {
+		return nil, args.Error(1)
+	}
+	return locs.(*models.Locations), args.Error(1)
+}
+
+// ReverseGeocode returns the place found at specified coordinates
+func (u *LocationUsecaseMock) ReverseGeocode(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	args := u.Called(ctx, latitude, longitude)
+	place := args.Get(0)
+	if place == nil {
+		return nil, args.Error(1)
+	}
+	return place.(*models.Place), args.Error(1)
+}
+
+// GetLocationShares returns the roles granted on a location
+func (u *LocationUsecaseMock) GetLocationShares(ctx context.Context, id models.ID) (*models.Shares, error) {
+	args := u.Called(ctx, id)
+	shares := args.Get(0)
+	if shares == nil {
+		return nil, args.Error(1)
+	}
+	return shares.(*models.Shares), args.Error(1)
+}
+
+// ShareLocation grants a role on a location
+func (u *LocationUsecaseMock) ShareLocation(ctx context.Context, id models.ID, share *models.Share) error {
+	args := u.Called(ctx, id, share)
+	return args.Error(0)
+}
+
+// UnshareLocation revokes the role granted on a location
+func (u *LocationUsecaseMock) UnshareLocation(ctx context.Context, id models.ID, user models.ID) error {
+	args := u.Called(ctx, id, user)
+	return args.Error(0)
+}
+
+// GetCategoryShares returns the roles granted on a category
+func (u *LocationUsecaseMock) GetCategoryShares(ctx context.Context, id models.ID) (*models.Shares, error) {
+	args := u.Called(ctx, id)
+	shares := args.Get(0)
+	if shares == nil {
+		return nil, args.Error(1)
+	}
+	return shares.(*models.Shares), args.Error(1)
+}
+
+// ShareCategory grants a role on a category
+func (u *LocationUsecaseMock) ShareCategory(ctx context.Context, id models.ID, share *models.Share) error {
+	args := u.Called(ctx, id, share)
+	return args.Error(0)
+}
+
+// UnshareCategory revokes the role granted on a category
+func (u *LocationUsecaseMock) UnshareCategory(ctx context.Context, id models.ID, user models.ID) error {
+	args := u.Called(ctx, id, user)
+	return args.Error(0)
+}
+
+// CreateGroup stores a new group, owned by the user
+func (u *LocationUsecaseMock) CreateGroup(ctx context.Context, group *models.Group) error {
+	args := u.Called(ctx, group)
+	return args.Error(0)
+}
+
+// GetGroups returns all groups the user is a member of
+func (u *LocationUsecaseMock) GetGroups(ctx context.Context) (*models.Groups, error) {
+	args := u.Called(ctx)
+	groups := args.Get(0)
+	if groups == nil {
+		return nil, args.Error(1)
+	}
+	return groups.(*models.Groups), args.Error(1)
+}
+
+// FindGroupByID returns group of the user matching specified ID
+func (u *LocationUsecaseMock) FindGroupByID(ctx context.Context, id models.ID) (*models.Group, error) {
+	args := u.Called(ctx, id)
+	group := args.Get(0)
+	if group == nil {
+		return nil, args.Error(1)
+	}
+	return group.(*models.Group), args.Error(1)
+}
+
+// UpdateGroup renames specified group
+func (u *LocationUsecaseMock) UpdateGroup(ctx context.Context, group *models.Group) error {
+	args := u.Called(ctx, group)
+	return args.Error(0)
+}
+
+// DeleteGroup deletes specified group
+func (u *LocationUsecaseMock) DeleteGroup(ctx context.Context, id models.ID) error {
+	args := u.Called(ctx, id)
+	return args.Error(0)
+}
+
+// GetGroupMembers returns the members of a group
+func (u *LocationUsecaseMock) GetGroupMembers(ctx context.Context, id models.ID) (*models.GroupMembers, error) {
+	args := u.Called(ctx, id)
+	members := args.Get(0)
+	if members == nil {
+		return nil, args.Error(1)
+	}
+	return members.(*models.GroupMembers), args.Error(1)
+}
+
+// SetGroupMember adds a member to a group, or changes the role of a member
+func (u *LocationUsecaseMock) SetGroupMember(ctx context.Context, id models.ID, member *models.GroupMember) error {
+	args := u.Called(ctx, id, member)
+	return args.Error(0)
+}
+
+// RemoveGroupMember removes a member from a group
+func (u *LocationUsecaseMock) RemoveGroupMember(ctx context.Context, id models.ID, user models.ID) error {
+	args := u.Called(ctx, id, user)
+	return args.Error(0)
+}
+
+// CreateShareLink stores a new share link to a location
+func (u *LocationUsecaseMock) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	args := u.Called(ctx, link)
+	return args.Error(0)
+}
+
+// GetShareLinks returns the share links of a location
+func (u *LocationUsecaseMock) GetShareLinks(ctx context.Context, id models.ID) (*models.ShareLinks, error) {
+	args := u.Called(ctx, id)
+	links := args.Get(0)
+	if links == nil {
+		return nil, args.Error(1)
+	}
+	return links.(*models.ShareLinks), args.Error(1)
+}
+
+// RevokeShareLink deletes a share link of a location
+func (u *LocationUsecaseMock) RevokeShareLink(ctx context.Context, id models.ID, link models.ID) error {
+	args := u.Called(ctx, id, link)
+	return args.Error(0)
+}
+
+// GetPublicLocation returns the location shared by a share link token
+func (u *LocationUsecaseMock) GetPublicLocation(ctx context.Context, token string) (*models.PublicLocation, error) {
+	args := u.Called(ctx, token)
+	loc := args.Get(0)
+	if loc == nil {
+		return nil, args.Error(1)
+	}
+	return loc.(*models.PublicLocation), args.Error(1)
+}
@@ -0,0 +1,117 @@
+// Package apperrors defines domain errors shared by usecases and APIs, and translates them
+// to HTTP and gRPC statuses so that both servers report errors the same way.
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Kind of error, telling callers what went wrong regardless of the resource involved
+type Kind int
+
+const (
+	// KindInternal is used for unexpected errors. Their details must not be exposed.
+	KindInternal Kind = iota
+	// KindInvalid is used when a request cannot be processed as is
+	KindInvalid
+	// KindNotFound is used when a resource does not exist
+	KindNotFound
+	// KindAlreadyExists is used when a resource conflicts with an existing one
+	KindAlreadyExists
+	// KindConflict is used when the current state of a resource prevents an operation
+	KindConflict
+	// KindForbidden is used when an operation is not allowed on a resource
+	KindForbidden
+	// KindUnavailable is used when the service cannot handle a request for now
+	KindUnavailable
+)
+
+// Error is a domain error. Errors derived from one another, with WithField or WithMessage,
+// keep matching their origin with errors.Is.
+type Error struct {
+	// Kind of the error.
+	Kind Kind
+	// Resource involved, like "location" or "category". Empty when not specific to a resource.
+	Resource string
+	// Request field the error is about. Empty when not specific to a field.
+	Field string
+	// Whether the same request may succeed later.
+	Retryable bool
+	// Message describing the error, safe to show to clients.
+	Message string
+
+	parent error
+}
+
+// New creates a new domain error. Unavailable errors are retryable.
+func New(kind Kind, resource, message string) *Error {
+	return &Error{
+		Kind:      kind,
+		Resource:  resource,
+		Message:   message,
+		Retryable: kind == KindUnavailable,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error this one has been derived from, if any
+func (e *Error) Unwrap() error {
+	return e.parent
+}
+
+// WithField returns a copy of the error about a specific request field
+func (e *Error) WithField(field string) *Error {
+	derived := *e
+	derived.Field = field
+	derived.parent = e
+	return &derived
+}
+
+// WithMessage returns a copy of the error with a more specific message
+func (e *Error) WithMessage(message string) *Error {
+	derived := *e
+	derived.Message = message
+	derived.parent = e
+	return &derived
+}
+
+// Translation of a kind of error for API clients
+type Translation struct {
+	// HTTP status code.
+	HTTPStatus int
+	// Problem type, identifying the kind of error in HTTP responses.
+	ProblemType string
+	// gRPC status code.
+	GRPCCode codes.Code
+}
+
+var translations = map[Kind]Translation{
+	KindInternal:      {http.StatusInternalServerError, "internal", codes.Internal},
+	KindInvalid:       {http.StatusBadRequest, "invalid", codes.InvalidArgument},
+	KindNotFound:      {http.StatusNotFound, "not-found", codes.NotFound},
+	KindAlreadyExists: {http.StatusBadRequest, "already-exists", codes.AlreadyExists}, // 400 rather than 409, as clients expect
+	KindConflict:      {http.StatusConflict, "conflict", codes.FailedPrecondition},
+	KindForbidden:     {http.StatusForbidden, "forbidden", codes.PermissionDenied},
+	KindUnavailable:   {http.StatusServiceUnavailable, "unavailable", codes.Unavailable},
+}
+
+// Translate returns the outermost domain error of the chain along with its translation.
+// The error is nil for errors outside of the domain, which are translated as internal ones.
+func Translate(err error) (*Error, Translation) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil, translations[KindInternal]
+	}
+
+	t, ok := translations[e.Kind]
+	if !ok {
+		t = translations[KindInternal]
+	}
+	return e, t
+}
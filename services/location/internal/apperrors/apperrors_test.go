@@ -0,0 +1,74 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewRetryable(t *testing.T) {
+	assert.False(t, New(KindNotFound, "location", "location not found").Retryable)
+	assert.True(t, New(KindUnavailable, "", "service overloaded").Retryable)
+}
+
+func TestDerivedErrorsMatchOrigin(t *testing.T) {
+	errNotFound := New(KindNotFound, "category", "category not found")
+	errOther := New(KindNotFound, "category", "category not found")
+
+	withField := errNotFound.WithField("category_id")
+	assert.True(t, errors.Is(withField, errNotFound))
+	assert.False(t, errors.Is(withField, errOther))
+	assert.Equal(t, "category_id", withField.Field)
+	assert.Empty(t, errNotFound.Field)
+
+	withMessage := withField.WithMessage("parent category not found")
+	assert.True(t, errors.Is(withMessage, errNotFound))
+	assert.True(t, errors.Is(withMessage, withField))
+	assert.Equal(t, "parent category not found", withMessage.Error())
+	assert.Equal(t, "category_id", withMessage.Field)
+}
+
+func TestTranslateWrappedError(t *testing.T) {
+	errInUse := New(KindConflict, "category", "category still has locations")
+
+	e, translation := Translate(fmt.Errorf("DeleteCategory: failed. %w", errInUse.WithMessage("category still has 3 locations")))
+	assert.Equal(t, "category still has 3 locations", e.Message)
+	assert.Equal(t, http.StatusConflict, translation.HTTPStatus)
+	assert.Equal(t, "conflict", translation.ProblemType)
+	assert.Equal(t, codes.FailedPrecondition, translation.GRPCCode)
+}
+
+func TestTranslateKinds(t *testing.T) {
+	tests := []struct {
+		kind       Kind
+		httpStatus int
+		grpcCode   codes.Code
+	}{
+		{KindInternal, http.StatusInternalServerError, codes.Internal},
+		{KindInvalid, http.StatusBadRequest, codes.InvalidArgument},
+		{KindNotFound, http.StatusNotFound, codes.NotFound},
+		{KindAlreadyExists, http.StatusBadRequest, codes.AlreadyExists},
+		{KindConflict, http.StatusConflict, codes.FailedPrecondition},
+		{KindForbidden, http.StatusForbidden, codes.PermissionDenied},
+		{KindUnavailable, http.StatusServiceUnavailable, codes.Unavailable},
+	}
+
+	for _, test := range tests {
+		e, translation := Translate(New(test.kind, "location", "failed"))
+		assert.NotNil(t, e, test.kind)
+		assert.Equal(t, test.httpStatus, translation.HTTPStatus, test.kind)
+		assert.Equal(t, test.grpcCode, translation.GRPCCode, test.kind)
+		assert.NotEmpty(t, translation.ProblemType, test.kind)
+	}
+}
+
+func TestTranslateNonDomainError(t *testing.T) {
+	e, translation := Translate(errors.New("connection refused"))
+	assert.Nil(t, e)
+	assert.Equal(t, http.StatusInternalServerError, translation.HTTPStatus)
+	assert.Equal(t, codes.Internal, translation.GRPCCode)
+}
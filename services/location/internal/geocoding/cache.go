@@ -0,0 +1,176 @@
+package geocoding
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CachedGeocoder keeps geocoding results in memory, so that the same address is not
+// resolved twice by the provider. Addresses that could not be found are cached too.
+// Least recently used entries are evicted once the cache is full.
+type CachedGeocoder struct {
+	geocoder Geocoder
+	size     int
+	ttl      time.Duration
+	metrics  *cacheMetrics
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// Cache entries, most recently used first
+	lru *list.List
+}
+
+type cacheEntry struct {
+	key       string
+	place     *models.Place
+	expiresAt time.Time
+}
+
+// NewCachedGeocoder creates a new CachedGeocoder holding up to size results for ttl
+func NewCachedGeocoder(geocoder Geocoder, size int, ttl time.Duration, registry prometheus.Registerer) *CachedGeocoder {
+	return &CachedGeocoder{
+		geocoder: geocoder,
+		size:     size,
+		ttl:      ttl,
+		metrics:  newCacheMetrics(registry),
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Geocode returns cached result for specified address, or resolves it using underlying geocoder.
+// Errors are not cached.
+func (g *CachedGeocoder) Geocode(ctx context.Context, address string) (*models.Place, error) {
+	key := cacheKey(address)
+
+	if place, ok := g.get(key); ok {
+		g.metrics.requests.WithLabelValues("hit").Inc()
+		return place, nil
+	}
+	g.metrics.requests.WithLabelValues("miss").Inc()
+
+	place, err := g.geocoder.Geocode(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	g.set(key, place)
+	return place, nil
+}
+
+// Reverse returns cached result for specified coordinates, or resolves them using underlying geocoder.
+// Errors are not cached.
+func (g *CachedGeocoder) Reverse(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	key := reverseCacheKey(latitude, longitude)
+
+	if place, ok := g.get(key); ok {
+		g.metrics.requests.WithLabelValues("hit").Inc()
+		return place, nil
+	}
+	g.metrics.requests.WithLabelValues("miss").Inc()
+
+	place, err := g.geocoder.Reverse(ctx, latitude, longitude)
+	if err != nil {
+		return nil, err
+	}
+
+	g.set(key, place)
+	return place, nil
+}
+
+// cacheKey normalizes address so that insignificant differences share the same cache entry
+func cacheKey(address string) string {
+	return strings.ToLower(strings.Join(strings.Fields(address), " "))
+}
+
+// reverseCacheKey rounds coordinates to the precision stored with locations. The prefix
+// prevents any collision with address keys.
+func reverseCacheKey(latitude, longitude float64) string {
+	return fmt.Sprintf("reverse:%.*f,%.*f", models.CoordinatePrecision, latitude, models.CoordinatePrecision, longitude)
+}
+
+func (g *CachedGeocoder) get(key string) (*models.Place, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	elem, ok := g.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		g.remove(elem)
+		return nil, false
+	}
+
+	g.lru.MoveToFront(elem)
+	return entry.place, true
+}
+
+func (g *CachedGeocoder) set(key string, place *models.Place) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if elem, ok := g.entries[key]; ok {
+		g.remove(elem)
+	}
+
+	g.entries[key] = g.lru.PushFront(&cacheEntry{key, place, time.Now().Add(g.ttl)})
+
+	for g.lru.Len() > g.size {
+		g.remove(g.lru.Back())
+	}
+	g.metrics.entries.Set(float64(g.lru.Len()))
+}
+
+func (g *CachedGeocoder) remove(elem *list.Element) {
+	g.lru.Remove(elem)
+	delete(g.entries, elem.Value.(*cacheEntry).key)
+	g.metrics.entries.Set(float64(g.lru.Len()))
+}
+
+type cacheMetrics struct {
+	namespace string
+	subsystem string
+
+	requests *prometheus.CounterVec
+	entries  prometheus.Gauge
+}
+
+func newCacheMetrics(registry prometheus.Registerer) *cacheMetrics {
+	metrics := &cacheMetrics{
+		namespace: "geocoding",
+		subsystem: "cache",
+	}
+
+	metrics.requests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.namespace,
+			Subsystem: metrics.subsystem,
+			Name:      "requests_total",
+			Help:      "How many geocoding requests went through the cache, partitioned by result.",
+		},
+		[]string{"result"},
+	)
+	registry.MustRegister(metrics.requests)
+
+	metrics.entries = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metrics.namespace,
+			Subsystem: metrics.subsystem,
+			Name:      "entries",
+			Help:      "Current number of cached geocoding results.",
+		},
+	)
+	registry.MustRegister(metrics.entries)
+
+	return metrics
+}
@@ -0,0 +1,68 @@
+package geocoding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedGeocoderWithHit(t *testing.T) {
+	provider := &countingGeocoder{place: models.NewPlace("Paris, France", 48.8566, 2.3522)}
+	geocoder := NewCachedGeocoder(provider, 10, time.Hour, prometheus.NewRegistry())
+
+	place, err := geocoder.Geocode(context.Background(), "Paris")
+	assert.NoError(t, err)
+	assert.Equal(t, provider.place, place)
+
+	place, err = geocoder.Geocode(context.Background(), "  paris ")
+	assert.NoError(t, err)
+	assert.Equal(t, provider.place, place)
+
+	assert.Equal(t, 1, provider.calls)
+	assert.Equal(t, 1.0, testutil.ToFloat64(geocoder.metrics.requests.WithLabelValues("hit")))
+}
+
+func TestCachedGeocoderReverseWithHit(t *testing.T) {
+	provider := &countingGeocoder{place: models.NewPlace("1, Rue de la Poste, Paris, France", 48.8606111, 2.3376)}
+	geocoder := NewCachedGeocoder(provider, 10, time.Hour, prometheus.NewRegistry())
+
+	place, err := geocoder.Reverse(context.Background(), 48.8606111, 2.3376)
+	assert.NoError(t, err)
+	assert.Equal(t, provider.place, place)
+
+	place, err = geocoder.Reverse(context.Background(), 48.86061110001, 2.3376)
+	assert.NoError(t, err)
+	assert.Equal(t, provider.place, place)
+
+	_, _ = geocoder.Geocode(context.Background(), "48.8606111,2.3376")
+
+	assert.Equal(t, 2, provider.calls)
+}
+
+func TestCachedGeocoderWithExpiredEntry(t *testing.T) {
+	provider := &countingGeocoder{}
+	geocoder := NewCachedGeocoder(provider, 10, -time.Second, prometheus.NewRegistry())
+
+	_, _ = geocoder.Geocode(context.Background(), "Paris")
+	_, _ = geocoder.Geocode(context.Background(), "Paris")
+
+	assert.Equal(t, 2, provider.calls)
+}
+
+func TestCachedGeocoderEvictsLeastRecentlyUsed(t *testing.T) {
+	provider := &countingGeocoder{}
+	geocoder := NewCachedGeocoder(provider, 2, time.Hour, prometheus.NewRegistry())
+
+	for _, address := range []string{"Paris", "Lyon", "Paris", "Marseille", "Paris", "Lyon"} {
+		_, _ = geocoder.Geocode(context.Background(), address)
+	}
+
+	// Lyon has been evicted by Marseille, Paris stayed in cache
+	assert.Equal(t, 4, provider.calls)
+	assert.Equal(t, 2.0, testutil.ToFloat64(geocoder.metrics.entries))
+}
@@ -0,0 +1,81 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"golang.org/x/time/rate"
+)
+
+// Geocoder resolves addresses into places, and coordinates back into addresses.
+// It is implemented by every provider of this package.
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (*models.Place, error)
+	Reverse(ctx context.Context, latitude, longitude float64) (*models.Place, error)
+}
+
+// NoopGeocoder is used when geocoding is disabled. It never resolves any address.
+type NoopGeocoder struct{}
+
+// NewNoopGeocoder creates a new NoopGeocoder
+func NewNoopGeocoder() *NoopGeocoder {
+	return &NoopGeocoder{}
+}
+
+// Geocode always returns nil, as if the address could not be found
+func (g *NoopGeocoder) Geocode(ctx context.Context, address string) (*models.Place, error) {
+	return nil, nil
+}
+
+// Reverse always returns nil, as if no address could be found at these coordinates
+func (g *NoopGeocoder) Reverse(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	return nil, nil
+}
+
+// RateLimitedGeocoder throttles requests sent to a geocoding provider, so that its usage policy is respected.
+// Requests wait for their turn at most maxWait, so that a burst of requests does not hold callers
+// for ever: those that cannot get a turn in time fail, and are left to be resolved later.
+type RateLimitedGeocoder struct {
+	geocoder Geocoder
+	limiter  *rate.Limiter
+	maxWait  time.Duration
+}
+
+// NewRateLimitedGeocoder creates a new RateLimitedGeocoder allowing requestsPerSecond requests per second,
+// with requests waiting at most maxWait for their turn
+func NewRateLimitedGeocoder(geocoder Geocoder, requestsPerSecond float64, maxWait time.Duration) *RateLimitedGeocoder {
+	return &RateLimitedGeocoder{
+		geocoder,
+		rate.NewLimiter(rate.Limit(requestsPerSecond), 1),
+		maxWait,
+	}
+}
+
+// Geocode waits for the rate limiter before resolving specified address
+func (g *RateLimitedGeocoder) Geocode(ctx context.Context, address string) (*models.Place, error) {
+	if err := g.wait(ctx); err != nil {
+		return nil, fmt.Errorf("Geocode: failed to wait for rate limiter. %w", err)
+	}
+
+	return g.geocoder.Geocode(ctx, address)
+}
+
+// Reverse waits for the rate limiter before resolving specified coordinates
+func (g *RateLimitedGeocoder) Reverse(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	if err := g.wait(ctx); err != nil {
+		return nil, fmt.Errorf("Reverse: failed to wait for rate limiter. %w", err)
+	}
+
+	return g.geocoder.Reverse(ctx, latitude, longitude)
+}
+
+// wait blocks until the rate limiter lets a request through. It fails straight away
+// when the turn of the request would come after maxWait or after ctx deadline.
+func (g *RateLimitedGeocoder) wait(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, g.maxWait)
+	defer cancel()
+
+	return g.limiter.Wait(ctx)
+}
@@ -0,0 +1,65 @@
+package geocoding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingGeocoder resolves every address and coordinates to the same place and counts calls
+type countingGeocoder struct {
+	calls int
+	place *models.Place
+}
+
+func (g *countingGeocoder) Geocode(ctx context.Context, address string) (*models.Place, error) {
+	g.calls++
+	return g.place, nil
+}
+
+func (g *countingGeocoder) Reverse(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	g.calls++
+	return g.place, nil
+}
+
+func TestNoopGeocoder(t *testing.T) {
+	place, err := NewNoopGeocoder().Geocode(context.Background(), "1 rue de la Poste, Paris")
+	assert.NoError(t, err)
+	assert.Nil(t, place)
+
+	place, err = NewNoopGeocoder().Reverse(context.Background(), 48.8606111, 2.3376)
+	assert.NoError(t, err)
+	assert.Nil(t, place)
+}
+
+func TestRateLimitedGeocoderWithExpiredContext(t *testing.T) {
+	provider := &countingGeocoder{}
+	geocoder := NewRateLimitedGeocoder(provider, 1, time.Minute)
+
+	_, err := geocoder.Geocode(context.Background(), "Paris")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = geocoder.Geocode(ctx, "Paris")
+	assert.Error(t, err)
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestRateLimitedGeocoderWithWaitExceeded(t *testing.T) {
+	provider := &countingGeocoder{}
+	geocoder := NewRateLimitedGeocoder(provider, 1, 10*time.Millisecond)
+
+	_, err := geocoder.Reverse(context.Background(), 48.8606111, 2.3376)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	_, err = geocoder.Reverse(context.Background(), 48.8606111, 2.3376)
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, 1, provider.calls)
+}
@@ -0,0 +1,195 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NominatimConfig holds settings of a Nominatim compatible geocoding provider
+type NominatimConfig struct {
+	// Base URL of the API, like "https://nominatim.openstreetmap.org".
+	BaseURL string
+	// Identifies the application, as required by the Nominatim usage policy.
+	UserAgent string
+	// Optional contact email sent along with requests.
+	Email string
+	// Preferred language of returned addresses, like "fr" or "en".
+	Language string
+	// Maximum duration of one request to the API.
+	Timeout time.Duration
+}
+
+// NominatimGeocoder resolves addresses using an API compatible with Nominatim search and reverse endpoints
+type NominatimGeocoder struct {
+	config  *NominatimConfig
+	client  *http.Client
+	metrics *nominatimMetrics
+}
+
+// NewNominatimGeocoder creates a new NominatimGeocoder
+func NewNominatimGeocoder(config *NominatimConfig, registry prometheus.Registerer) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		config,
+		&http.Client{Timeout: config.Timeout},
+		newNominatimMetrics(registry),
+	}
+}
+
+type nominatimPlace struct {
+	Latitude    string `json:"lat"`
+	Longitude   string `json:"lon"`
+	DisplayName string `json:"display_name"`
+	// Set by reverse endpoint when nothing was found at requested coordinates
+	Error string `json:"error"`
+}
+
+// Geocode returns the best place matching specified address, or nil if none was found
+func (g *NominatimGeocoder) Geocode(ctx context.Context, address string) (*models.Place, error) {
+	query := url.Values{}
+	query.Set("q", address)
+	query.Set("format", "jsonv2")
+	query.Set("limit", "1")
+
+	var places []nominatimPlace
+	if err := g.get(ctx, "/search", query, &places); err != nil {
+		return nil, fmt.Errorf("Geocode: failed to search address %s. %w", address, err)
+	}
+
+	if len(places) == 0 {
+		return nil, nil
+	}
+
+	place, err := places[0].toModel()
+	if err != nil {
+		return nil, fmt.Errorf("Geocode: invalid place returned for address %s. %w", address, err)
+	}
+
+	return place, nil
+}
+
+// Reverse returns the place found at specified coordinates, or nil if none was found
+func (g *NominatimGeocoder) Reverse(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	query := url.Values{}
+	query.Set("lat", strconv.FormatFloat(latitude, 'f', -1, 64))
+	query.Set("lon", strconv.FormatFloat(longitude, 'f', -1, 64))
+	query.Set("format", "jsonv2")
+
+	var place nominatimPlace
+	if err := g.get(ctx, "/reverse", query, &place); err != nil {
+		return nil, fmt.Errorf("Reverse: failed to reverse coordinates %f,%f. %w", latitude, longitude, err)
+	}
+
+	if place.Error != "" {
+		return nil, nil
+	}
+
+	result, err := place.toModel()
+	if err != nil {
+		return nil, fmt.Errorf("Reverse: invalid place returned for coordinates %f,%f. %w", latitude, longitude, err)
+	}
+
+	return result, nil
+}
+
+func (g *NominatimGeocoder) get(ctx context.Context, path string, query url.Values, result interface{}) (err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		g.metrics.requestCount.WithLabelValues(status).Inc()
+		g.metrics.requestDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	if g.config.Email != "" {
+		query.Set("email", g.config.Email)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.config.BaseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request. %w", err)
+	}
+	req.Header.Set("User-Agent", g.config.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if g.config.Language != "" {
+		req.Header.Set("Accept-Language", g.config.Language)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed. %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status : %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response. %w", err)
+	}
+
+	return nil
+}
+
+func (p *nominatimPlace) toModel() (*models.Place, error) {
+	latitude, err := strconv.ParseFloat(p.Latitude, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude : %s. %w", p.Latitude, err)
+	}
+
+	longitude, err := strconv.ParseFloat(p.Longitude, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude : %s. %w", p.Longitude, err)
+	}
+
+	return models.NewPlace(p.DisplayName, latitude, longitude), nil
+}
+
+type nominatimMetrics struct {
+	namespace string
+	subsystem string
+
+	requestCount    *prometheus.CounterVec
+	requestDuration prometheus.Histogram
+}
+
+func newNominatimMetrics(registry prometheus.Registerer) *nominatimMetrics {
+	metrics := &nominatimMetrics{
+		namespace: "geocoding",
+		subsystem: "nominatim",
+	}
+
+	metrics.requestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.namespace,
+			Subsystem: metrics.subsystem,
+			Name:      "requests_total",
+			Help:      "How many requests were sent to the Nominatim API, partitioned by status.",
+		},
+		[]string{"status"},
+	)
+	registry.MustRegister(metrics.requestCount)
+
+	metrics.requestDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: metrics.namespace,
+			Subsystem: metrics.subsystem,
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10},
+			Name:      "request_duration_seconds",
+			Help:      "The Nominatim API latency bucket",
+		},
+	)
+	registry.MustRegister(metrics.requestDuration)
+
+	return metrics
+}
@@ -0,0 +1,109 @@
+package geocoding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNominatimGeocoder(handler http.HandlerFunc) (*NominatimGeocoder, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	return NewNominatimGeocoder(&NominatimConfig{
+		BaseURL:   server.URL,
+		UserAgent: "location-service-test",
+		Email:     "test@no-reply.com",
+		Timeout:   time.Second,
+	}, prometheus.NewRegistry()), server
+}
+
+func TestNominatimGeocodeWithSuccess(t *testing.T) {
+	geocoder, server := newTestNominatimGeocoder(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search", r.URL.Path)
+		assert.Equal(t, "1 rue de la Poste, Paris", r.URL.Query().Get("q"))
+		assert.Equal(t, "jsonv2", r.URL.Query().Get("format"))
+		assert.Equal(t, "test@no-reply.com", r.URL.Query().Get("email"))
+		assert.Equal(t, "location-service-test", r.UserAgent())
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"lat": "48.8606111", "lon": "2.3376", "display_name": "1, Rue de la Poste, Paris, France"}]`))
+	})
+	defer server.Close()
+
+	place, err := geocoder.Geocode(context.Background(), "1 rue de la Poste, Paris")
+	if assert.NoError(t, err) && assert.NotNil(t, place) {
+		assert.Equal(t, "1, Rue de la Poste, Paris, France", place.Address)
+		assert.Equal(t, 48.8606111, place.Latitude)
+		assert.Equal(t, 2.3376, place.Longitude)
+	}
+	assert.Equal(t, 1.0, testutil.ToFloat64(geocoder.metrics.requestCount.WithLabelValues("success")))
+}
+
+func TestNominatimGeocodeWithAddressNotFound(t *testing.T) {
+	geocoder, server := newTestNominatimGeocoder(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	defer server.Close()
+
+	place, err := geocoder.Geocode(context.Background(), "nowhere")
+	assert.NoError(t, err)
+	assert.Nil(t, place)
+}
+
+func TestNominatimGeocodeWithErrorStatus(t *testing.T) {
+	geocoder, server := newTestNominatimGeocoder(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+
+	_, err := geocoder.Geocode(context.Background(), "1 rue de la Poste, Paris")
+	assert.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(geocoder.metrics.requestCount.WithLabelValues("error")))
+}
+
+func TestNominatimGeocodeWithInvalidResponse(t *testing.T) {
+	geocoder, server := newTestNominatimGeocoder(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"lat": "north", "lon": "2.3376", "display_name": "Paris"}]`))
+	})
+	defer server.Close()
+
+	_, err := geocoder.Geocode(context.Background(), "Paris")
+	assert.Error(t, err)
+}
+
+func TestNominatimReverseWithSuccess(t *testing.T) {
+	geocoder, server := newTestNominatimGeocoder(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reverse", r.URL.Path)
+		assert.Equal(t, "48.8606111", r.URL.Query().Get("lat"))
+		assert.Equal(t, "2.3376", r.URL.Query().Get("lon"))
+		assert.Equal(t, "jsonv2", r.URL.Query().Get("format"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"lat": "48.8606", "lon": "2.3375", "display_name": "1, Rue de la Poste, Paris, France"}`))
+	})
+	defer server.Close()
+
+	place, err := geocoder.Reverse(context.Background(), 48.8606111, 2.3376)
+	if assert.NoError(t, err) && assert.NotNil(t, place) {
+		assert.Equal(t, "1, Rue de la Poste, Paris, France", place.Address)
+		assert.Equal(t, 48.8606, place.Latitude)
+		assert.Equal(t, 2.3375, place.Longitude)
+	}
+}
+
+func TestNominatimReverseWithPlaceNotFound(t *testing.T) {
+	geocoder, server := newTestNominatimGeocoder(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"error": "Unable to geocode"}`))
+	})
+	defer server.Close()
+
+	place, err := geocoder.Reverse(context.Background(), 0, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, place)
+}
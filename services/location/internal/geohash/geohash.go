@@ -0,0 +1,127 @@
+// Package geohash encodes coordinates into geohashes, and computes the geohash
+// prefixes covering a circle so that proximity searches can use prefix indexes.
+package geohash
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	// MaxPrecision is the length of geohashes stored with locations, accurate to a few centimeters
+	MaxPrecision = 12
+
+	base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+	// Mean length of one degree of latitude, in meters
+	metersPerDegree = 111320
+)
+
+// Encode returns the geohash of specified coordinates, with precision characters
+func Encode(latitude, longitude float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	var hash strings.Builder
+	hash.Grow(precision)
+
+	even := true
+	bit, ch := 0, 0
+	for hash.Len() < precision {
+		if even {
+			ch = ch<<1 | bisect(&lngRange, longitude)
+		} else {
+			ch = ch<<1 | bisect(&latRange, latitude)
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			hash.WriteByte(base32[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return hash.String()
+}
+
+// bisect halves r, keeping the half containing value. It returns 1 when the upper half is kept.
+func bisect(r *[2]float64, value float64) int {
+	mid := (r[0] + r[1]) / 2
+	if value >= mid {
+		r[0] = mid
+		return 1
+	}
+	r[1] = mid
+	return 0
+}
+
+// CellSize returns height and width, in degrees, of geohash cells of specified precision
+func CellSize(precision int) (height, width float64) {
+	bits := precision * 5
+	latBits := bits / 2
+	lngBits := bits - latBits
+
+	return 180 / math.Pow(2, float64(latBits)), 360 / math.Pow(2, float64(lngBits))
+}
+
+// PrecisionForZoom returns the geohash precision used to cluster points displayed on a web map
+// at specified zoom level. Cells are about a quarter of a 256 pixels tile wide, so that a few
+// clusters are displayed on each tile.
+func PrecisionForZoom(zoom int) int {
+	precision := 1
+	for p := 2; p <= MaxPrecision; p++ {
+		// Number of bits used to encode longitude
+		if (p*5+1)/2 > zoom+3 {
+			break
+		}
+		precision = p
+	}
+
+	return precision
+}
+
+// CoveringPrefixes returns the geohashes of the cell containing specified center and of its
+// neighbours, using the longest precision whose cells are larger than radius, in meters.
+// Locations within radius of center all start with one of these prefixes.
+// Nil is returned when radius is too large to be covered this way.
+func CoveringPrefixes(latitude, longitude, radius float64) []string {
+	// Cells get narrower toward the poles: size them at the latitude of the circle
+	// edge closest to a pole.
+	edgeLatitude := math.Min(math.Abs(latitude)+radius/metersPerDegree, 90)
+	lngScale := math.Cos(edgeLatitude * math.Pi / 180)
+
+	precision := 0
+	for p := 1; p <= MaxPrecision; p++ {
+		height, width := CellSize(p)
+		if height*metersPerDegree < radius || width*metersPerDegree*lngScale < radius {
+			break
+		}
+		precision = p
+	}
+	if precision == 0 {
+		return nil
+	}
+
+	height, width := CellSize(precision)
+	prefixes := make([]string, 0, 9)
+	seen := make(map[string]bool, 9)
+	for _, dLat := range []float64{0, height, -height} {
+		for _, dLng := range []float64{0, width, -width} {
+			lat := latitude + dLat
+			if lat > 90 || lat < -90 {
+				continue
+			}
+			hash := Encode(lat, wrapLongitude(longitude+dLng), precision)
+			if !seen[hash] {
+				seen[hash] = true
+				prefixes = append(prefixes, hash)
+			}
+		}
+	}
+
+	return prefixes
+}
+
+// wrapLongitude brings longitude back in [-180, 180) across the antimeridian
+func wrapLongitude(longitude float64) float64 {
+	return math.Mod(math.Mod(longitude+180, 360)+360, 360) - 180
+}
@@ -0,0 +1,67 @@
+package geohash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "u09tvny1wn0f", Encode(48.8606111, 2.3376, MaxPrecision))
+	assert.Equal(t, "u09tv", Encode(48.8606111, 2.3376, 5))
+	assert.Equal(t, "ezs42", Encode(42.605, -5.603, 5))
+	assert.Equal(t, "s0000", Encode(0, 0, 5))
+}
+
+func TestCellSize(t *testing.T) {
+	height, width := CellSize(5)
+	assert.InDelta(t, 0.0439453125, height, 1e-12)
+	assert.InDelta(t, 0.0439453125, width, 1e-12)
+
+	height, width = CellSize(6)
+	assert.InDelta(t, 0.0054931640625, height, 1e-12)
+	assert.InDelta(t, 0.010986328125, width, 1e-12)
+}
+
+func TestPrecisionForZoom(t *testing.T) {
+	assert.Equal(t, 1, PrecisionForZoom(0))
+	assert.Equal(t, 2, PrecisionForZoom(2))
+	assert.Equal(t, 5, PrecisionForZoom(10))
+	assert.Equal(t, 9, PrecisionForZoom(20))
+	assert.Equal(t, 10, PrecisionForZoom(22))
+}
+
+func TestCoveringPrefixes(t *testing.T) {
+	// Precision 6 cells are about 800 meters wide in Paris, too narrow for this radius
+	prefixes := CoveringPrefixes(48.8606111, 2.3376, 1000)
+	if assert.Len(t, prefixes, 9) {
+		assert.Equal(t, "u09tv", prefixes[0])
+		for _, prefix := range prefixes {
+			assert.Len(t, prefix, 5)
+		}
+	}
+
+	// A point 900 meters east of center is covered
+	hash := Encode(48.8606111, 2.3376+900/(metersPerDegree*0.658), MaxPrecision)
+	assert.True(t, hasAnyPrefix(hash, prefixes), hash)
+}
+
+func TestCoveringPrefixesAcrossAntimeridian(t *testing.T) {
+	prefixes := CoveringPrefixes(0, 179.999, 500)
+
+	assert.True(t, hasAnyPrefix(Encode(0, -179.999, MaxPrecision), prefixes))
+}
+
+func TestCoveringPrefixesWithLargeRadius(t *testing.T) {
+	assert.Nil(t, CoveringPrefixes(48.8606111, 2.3376, 6000000))
+}
+
+func hasAnyPrefix(hash string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package models
+
+// AddressComponents splits a location address into its postal components
+type AddressComponents struct {
+	// Street name, without house number.
+	Street string `json:"street" example:"Rue de la Poste" binding:"max=255" extensions:"x-order=1"`
+	// House number, including suffixes like "bis" or "B".
+	HouseNumber string `json:"house_number" example:"1" binding:"max=20" extensions:"x-order=2"`
+	// Postal code, formatted as in the country it belongs to.
+	PostalCode string `json:"postal_code" example:"75001" binding:"max=20" extensions:"x-order=3"`
+	// City, town or village.
+	Locality string `json:"locality" example:"Paris" binding:"max=100" extensions:"x-order=4"`
+	// State, province or administrative region.
+	Region string `json:"region" example:"Île-de-France" binding:"max=100" extensions:"x-order=5"`
+	// ISO 3166-1 alpha-2 country code.
+	CountryCode string `json:"country_code" example:"FR" binding:"omitempty,iso3166_1_alpha2" extensions:"x-order=6"`
+}
+
+// IsZero tells whether no address component is set
+func (c AddressComponents) IsZero() bool {
+	return c == AddressComponents{}
+}
@@ -0,0 +1,51 @@
+package models
+
+// BulkReport model. Outcome of an operation on many items, which may succeed for some of them only.
+type BulkReport struct {
+	// IDs of the items the operation succeeded for.
+	Succeeded []ID `json:"succeeded" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=1"`
+	// Items the operation failed for, along with the reason.
+	Failed []*BulkFailure `json:"failed" extensions:"x-order=2"`
+}
+
+// BulkFailure model. Item a bulk operation failed for.
+type BulkFailure struct {
+	// ID of the item.
+	ID ID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=1"`
+	// Reason of the failure.
+	Error string `json:"error" example:"location not found" extensions:"x-order=2"`
+}
+
+// NewBulkReport creates a new empty report
+func NewBulkReport() *BulkReport {
+	return &BulkReport{
+		make([]ID, 0),
+		make([]*BulkFailure, 0),
+	}
+}
+
+// Succeed reports that the operation succeeded for specified item
+func (r *BulkReport) Succeed(id ID) {
+	r.Succeeded = append(r.Succeeded, id)
+}
+
+// Fail reports that the operation failed for specified item
+func (r *BulkReport) Fail(id ID, err error) {
+	r.Failed = append(r.Failed, &BulkFailure{id, err.Error()})
+}
+
+// Has tells whether the outcome of the operation has already been reported for specified item
+func (r *BulkReport) Has(id ID) bool {
+	for _, succeeded := range r.Succeeded {
+		if succeeded == id {
+			return true
+		}
+	}
+	for _, failure := range r.Failed {
+		if failure.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
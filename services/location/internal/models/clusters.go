@@ -0,0 +1,39 @@
+package models
+
+// Cluster groups nearby locations, so that maps display one marker instead of many overlapping ones
+type Cluster struct {
+	// Geohash of the area covered by the cluster.
+	Geohash string `json:"geohash" example:"u09tv" extensions:"x-order=1"`
+	// Number of locations in the cluster.
+	Count int `json:"count" example:"12" extensions:"x-order=2"`
+	// Latitude of the cluster centroid in decimal degrees (WGS 84).
+	Latitude float64 `json:"latitude" example:"48.8606111" extensions:"x-order=3"`
+	// Longitude of the cluster centroid in decimal degrees (WGS 84).
+	Longitude float64 `json:"longitude" example:"2.3376" extensions:"x-order=4"`
+	// Only location of the cluster, set when count is 1.
+	Location *Location `json:"location,omitempty" extensions:"x-order=5"`
+}
+
+// Clusters is an array of clusters
+type Clusters []*Cluster
+
+// NewCluster creates a new cluster
+func NewCluster(geohash string, count int, latitude, longitude float64) *Cluster {
+	return &Cluster{
+		geohash,
+		count,
+		latitude,
+		longitude,
+		nil,
+	}
+}
+
+// GetLocationClusters validates user input to get clusters of locations
+type GetLocationClusters struct {
+	// Location category foreign key.
+	Category string `form:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"omitempty,uuid" extensions:"x-order=1"`
+	// Area of the clustered locations, formatted as "west,south,east,north".
+	BBox string `form:"bbox" example:"2.25,48.81,2.42,48.9" binding:"required" extensions:"x-order=2"`
+	// Map zoom level, from 0 (whole world) to 22. Clusters get smaller as zoom increases.
+	Zoom *int `form:"zoom" example:"12" binding:"required,min=0,max=22" extensions:"x-order=3"`
+}
@@ -0,0 +1,103 @@
+package models
+
+// GroupRole tells what a member may do within a group
+type GroupRole string
+
+// Roles of group members
+const (
+	// GroupRoleOwner manages the group, its members and its locations
+	GroupRoleOwner GroupRole = "owner"
+	// GroupRoleMember may read and update the locations of the group
+	GroupRoleMember GroupRole = "member"
+)
+
+// Group model. Collection of locations owned by several users together, like a household.
+type Group struct {
+	// Group ID. Must be unique.
+	ID ID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=1"`
+	// Short descriptive name of the group, like "Family".
+	Name string `json:"name" example:"Family" extensions:"x-order=2"`
+	// Role of the user in the group: "owner" or "member".
+	Role GroupRole `json:"role" example:"owner" extensions:"x-order=3"`
+}
+
+// Groups is an array of groups
+type Groups []*Group
+
+// GroupMember model. Membership of a user in a group.
+type GroupMember struct {
+	// ID of the member.
+	User ID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=1"`
+	// Role of the member: "owner" or "member".
+	Role GroupRole `json:"role" example:"member" extensions:"x-order=2"`
+}
+
+// GroupMembers is an array of group members
+type GroupMembers []*GroupMember
+
+// CreateGroup validates user input to create a new group. The user becomes its owner.
+type CreateGroup struct {
+	// Short descriptive name of the group, like "Family".
+	Name string `json:"name" example:"Family" binding:"required,max=50" extensions:"x-order=1"`
+}
+
+// GetGroupByID validates user input to get a specific group using its id
+type GetGroupByID struct {
+	// ID of the group.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// UpdateGroupQuery validates query user input to update an existing group
+type UpdateGroupQuery struct {
+	// ID of the group.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// UpdateGroup validates body user input to update an existing group
+type UpdateGroup struct {
+	// New name of the group.
+	Name string `json:"name" example:"Family" binding:"required,max=50" extensions:"x-order=1"`
+}
+
+// DeleteGroup validates user input to delete an existing group. Its locations are given back to the users who created them.
+type DeleteGroup struct {
+	// ID of the group.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// GetGroupMembers validates user input to get the members of a group
+type GetGroupMembers struct {
+	// ID of the group.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// GroupMemberQuery validates query user input to add, update or remove a group member
+type GroupMemberQuery struct {
+	// ID of the group.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+	// ID of the member.
+	User string `uri:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=2"`
+}
+
+// SetGroupMember validates body user input to add a member to a group, or to change the role of a member
+type SetGroupMember struct {
+	// Role of the member: "owner" or "member".
+	Role string `json:"role" example:"member" binding:"required,oneof=owner member" extensions:"x-order=1"`
+}
+
+// NewGroup creates a new group
+func NewGroup(id ID, name string) *Group {
+	return &Group{
+		id,
+		name,
+		"",
+	}
+}
+
+// NewGroupMember creates a new membership of user in a group
+func NewGroupMember(user ID, role GroupRole) *GroupMember {
+	return &GroupMember{
+		user,
+		role,
+	}
+}
@@ -1,5 +1,18 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/pluscodes"
+)
+
+// CoordinatePrecision is the maximum number of decimal places of latitudes and longitudes.
+// Seven decimal places are accurate to about one centimeter.
+const CoordinatePrecision = 7
+
 // Location model. Describes a physical place in the world.
 type Location struct {
 	// Location ID. Must be unique.
@@ -8,29 +21,238 @@ type Location struct {
 	Name string `json:"name" example:"Home" extensions:"x-order=2"`
 	// Full address of the location. Should contains at least street, postal code and city.
 	Address string `json:"address" example:"1 rue de la Poste, 75001 Paris" extensions:"x-order=3"`
+	// Components of the address, parsed from it unless explicitly set.
+	Components AddressComponents `json:"address_components" extensions:"x-order=4"`
+	// Latitude of the location in decimal degrees (WGS 84). Empty until resolved.
+	Latitude *float64 `json:"latitude,omitempty" example:"48.8606111" extensions:"x-order=5"`
+	// Longitude of the location in decimal degrees (WGS 84). Empty until resolved.
+	Longitude *float64 `json:"longitude,omitempty" example:"2.3376" extensions:"x-order=6"`
+	// Open Location Code of the location, derived from its coordinates. Empty until resolved.
+	PlusCode string `json:"plus_code,omitempty" example:"8FW4V86Q+62" extensions:"x-order=7"`
 	// Location category foreign key.
-	Category ID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=4"`
+	Category ID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=8"`
+	// Names of location tags, sorted.
+	Tags []string `json:"tags" example:"Favorite,Vegan" extensions:"x-order=9"`
 	// User ID. Owner of the location.
-	User ID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=5"`
+	User ID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=10"`
+	// Distance in meters from the searched point, only set by proximity searches.
+	Distance *float64 `json:"distance,omitempty" example:"1250.5" extensions:"x-order=11"`
+	// Location category, only embedded on request.
+	ExpandedCategory *Category `json:"category,omitempty" extensions:"x-order=12"`
+	// Role of the user on the location: "owner" of their own locations, or the role granted on shared ones.
+	// Only set when listing locations or getting a location by ID.
+	Role ShareRole `json:"role,omitempty" example:"owner" extensions:"x-order=13"`
+	// Group owning the location instead of the user who created it. Empty for user locations.
+	Group *ID `json:"group_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=14"`
+}
+
+// MarshalJSON encodes location with its plus code, which is derived from its coordinates
+// rather than stored
+func (l Location) MarshalJSON() ([]byte, error) {
+	// Alias type drops methods, so that marshalling does not recurse
+	type location Location
+	view := location(l)
+	view.PlusCode = l.EncodePlusCode()
+
+	return json.Marshal(view)
 }
 
+// LocationFields lists location fields, by JSON name, that may be selected in sparse fieldsets
+var LocationFields = []string{"id", "name", "address", "address_components", "latitude", "longitude", "plus_code", "category_id", "tags", "user_id", "distance", "role", "group_id"}
+
 // Locations is an array of locations
 type Locations []*Location
 
+// SelectFields returns a sparse representation of the location, limited to specified fields.
+// Embedded objects are always kept.
+func (l *Location) SelectFields(fields []string) map[string]interface{} {
+	view := make(map[string]interface{}, len(fields)+1)
+	for _, field := range fields {
+		switch field {
+		case "id":
+			view[field] = l.ID
+		case "name":
+			view[field] = l.Name
+		case "address":
+			view[field] = l.Address
+		case "address_components":
+			view[field] = l.Components
+		case "latitude":
+			if l.Latitude != nil {
+				view[field] = l.Latitude
+			}
+		case "longitude":
+			if l.Longitude != nil {
+				view[field] = l.Longitude
+			}
+		case "plus_code":
+			if code := l.EncodePlusCode(); code != "" {
+				view[field] = code
+			}
+		case "category_id":
+			view[field] = l.Category
+		case "tags":
+			view[field] = l.Tags
+		case "user_id":
+			view[field] = l.User
+		case "distance":
+			if l.Distance != nil {
+				view[field] = l.Distance
+			}
+		case "role":
+			if l.Role != "" {
+				view[field] = l.Role
+			}
+		case "group_id":
+			if l.Group != nil {
+				view[field] = l.Group
+			}
+		}
+	}
+
+	if l.ExpandedCategory != nil {
+		view["category"] = l.ExpandedCategory
+	}
+
+	return view
+}
+
 // CreateLocation validates user input to create a new location
 type CreateLocation struct {
 	// Short descriptive name of the location, like "Home" or "Work".
 	Name string `json:"name" example:"Home" binding:"required" extensions:"x-order=1"`
 	// Full address of the location. Should contains at least street, postal code and city.
-	Address string `json:"address" example:"1 rue de la Poste, 75001 Paris" binding:"required" extensions:"x-order=2"`
+	// May be omitted when address components, coordinates or plus code are set, in which case it is derived from them.
+	Address string `json:"address" example:"1 rue de la Poste, 75001 Paris" binding:"required_without_all=Components Latitude PlusCode" extensions:"x-order=2"`
+	// Components of the address. Parsed from address when omitted.
+	Components *AddressComponents `json:"address_components" extensions:"x-order=3"`
+	// Latitude of the location in decimal degrees (WGS 84). Must be set along with longitude.
+	Latitude *float64 `json:"latitude" example:"48.8606111" binding:"omitempty,min=-90,max=90,coordinate_precision" extensions:"x-order=4"`
+	// Longitude of the location in decimal degrees (WGS 84). Must be set along with latitude.
+	Longitude *float64 `json:"longitude" example:"2.3376" binding:"omitempty,min=-180,max=180,coordinate_precision" extensions:"x-order=5"`
+	// Open Location Code of the location, like "8FW4V86Q+62", used instead of coordinates. Short codes,
+	// like "V86Q+62", must be followed by a locality, like "V86Q+62 Paris", or come with a reference location.
+	PlusCode string `json:"plus_code" example:"8FW4V86Q+62" binding:"omitempty,excluded_with=Latitude,plus_code" extensions:"x-order=6"`
+	// ID of a location near the place, used to recover short plus code.
+	PlusCodeReference ID `json:"plus_code_reference" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=7"`
 	// Location category foreign key.
-	Category ID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required" extensions:"x-order=3"`
+	Category ID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required" extensions:"x-order=8"`
+	// Names of location tags. Tags that do not exist yet are created.
+	Tags []string `json:"tags" example:"Favorite,Vegan" binding:"omitempty,max=20,dive,required,max=50,excludesall=0x2C" extensions:"x-order=9"`
+	// ID of the group owning the location, which the user must be a member of. Location is owned by the user when omitted.
+	Group ID `json:"group_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=10"`
 }
 
 // GetLocations validates user input to get locations
 type GetLocations struct {
+	LocationView
+
 	// Location category foreign key.
 	Category string `form:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"omitempty,uuid" extensions:"x-order=1"`
+	// Also return locations of all descendant categories of the category.
+	IncludeDescendants bool `form:"include_descendants" example:"true" extensions:"x-order=2"`
+	// Only return locations near this point, formatted as "latitude,longitude". Nearest locations come first.
+	Near string `form:"near" example:"48.8606111,2.3376" binding:"omitempty" extensions:"x-order=3"`
+	// Search radius around near point, in meters.
+	Radius float64 `form:"radius" example:"5000" binding:"required_with=Near,omitempty,gt=0,max=200000" extensions:"x-order=4"`
+	// Only return locations inside this area, formatted as "west,south,east,north".
+	BBox string `form:"bbox" example:"2.25,48.81,2.42,48.9" binding:"omitempty" extensions:"x-order=5"`
+	// Only return locations inside the area of this Open Location Code. Short codes must be followed
+	// by a locality or come with a reference location. "+" must be encoded as "%2B".
+	PlusCode string `form:"plus_code" example:"8FW4V800+" binding:"omitempty,excluded_with=BBox,plus_code" extensions:"x-order=6"`
+	// ID of a location near the area, used to recover short plus code.
+	PlusCodeReference string `form:"plus_code_reference" example:"550e8400-e29b-41d4-a716-446655440000" binding:"omitempty,uuid" extensions:"x-order=7"`
+	// Only return locations with these tags, formatted as a comma separated list of tag names.
+	Tags string `form:"tags" example:"Favorite,Vegan" binding:"omitempty" extensions:"x-order=8"`
+	// Whether locations must have all requested tags, or any of them. Defaults to all.
+	Match string `form:"match" example:"any" binding:"omitempty,oneof=all any" extensions:"x-order=9"`
+}
+
+// TagNames returns the names of requested tags, or nil when locations are not filtered by tags
+func (q *GetLocations) TagNames() []string {
+	if q.Tags == "" {
+		return nil
+	}
+
+	names := NormalizeTagNames(strings.Split(q.Tags, ","))
+	if len(names) == 0 {
+		return nil
+	}
+
+	return names
+}
+
+// MatchAllTags tells whether locations must have all requested tags, rather than any of them
+func (q *GetLocations) MatchAllTags() bool {
+	return q.Match != "any"
+}
+
+// BoundingBox returns the area locations are searched in, or nil when no bounding box is requested
+func (q *GetLocations) BoundingBox() (*BoundingBox, error) {
+	if q.BBox == "" {
+		return nil, nil
+	}
+
+	bbox, err := ParseBoundingBox(q.BBox)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bbox, nil
+}
+
+// NearPoint returns the point around which locations are searched, or nil when no proximity search is requested
+func (q *GetLocations) NearPoint() (*Point, error) {
+	if q.Near == "" {
+		return nil, nil
+	}
+
+	point, err := ParsePoint(q.Near)
+	if err != nil {
+		return nil, err
+	}
+
+	return &point, nil
+}
+
+// LocationView validates user input shaping returned locations
+type LocationView struct {
+	// Comma separated list of location fields to return. All fields are returned when empty.
+	Fields string `form:"fields" example:"id,name" binding:"omitempty" extensions:"x-order=1"`
+	// Related object to embed in returned locations. Only "category" is supported.
+	Expand string `form:"expand" example:"category" binding:"omitempty,oneof=category" extensions:"x-order=2"`
+}
+
+// SelectedFields returns requested location fields, or nil when all fields must be returned
+func (v *LocationView) SelectedFields() ([]string, error) {
+	if v.Fields == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(v.Fields, ",")
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+		if !isLocationField(fields[i]) {
+			return nil, fmt.Errorf("unknown location field : %s", fields[i])
+		}
+	}
+
+	return fields, nil
+}
+
+// ExpandCategory tells whether location category must be embedded
+func (v *LocationView) ExpandCategory() bool {
+	return v.Expand == "category"
+}
+
+func isLocationField(name string) bool {
+	for _, field := range LocationFields {
+		if field == name {
+			return true
+		}
+	}
+
+	return false
 }
 
 // GetLocationByID validates user input to get a specific location using its id
@@ -53,8 +275,16 @@ type UpdateLocation struct {
 	Name string `json:"name" example:"Home" binding:"required_without_all" extensions:"x-order=1"`
 	// Full address of the location. Should contains at least street, postal code and city.
 	Address string `json:"address" example:"1 rue de la Poste, 75001 Paris" binding:"required_without_all" extensions:"x-order=2"`
+	// Components of the address. Parsed from address when omitted while address changes.
+	Components *AddressComponents `json:"address_components" extensions:"x-order=3"`
+	// Latitude of the location in decimal degrees (WGS 84). Must be set along with longitude.
+	Latitude *float64 `json:"latitude" example:"48.8606111" binding:"omitempty,min=-90,max=90,coordinate_precision" extensions:"x-order=4"`
+	// Longitude of the location in decimal degrees (WGS 84). Must be set along with latitude.
+	Longitude *float64 `json:"longitude" example:"2.3376" binding:"omitempty,min=-180,max=180,coordinate_precision" extensions:"x-order=5"`
 	// Location category foreign key.
-	Category ID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required_without_all" extensions:"x-order=3"`
+	Category ID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required_without_all" extensions:"x-order=6"`
+	// Names of location tags, replacing current ones. Tags are left unchanged when omitted, and removed when empty.
+	Tags []string `json:"tags" example:"Favorite,Vegan" binding:"omitempty,max=20,dive,required,max=50,excludesall=0x2C" extensions:"x-order=7"`
 }
 
 // DeleteLocation validates user input to delete an existing location
@@ -63,15 +293,68 @@ type DeleteLocation struct {
 	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
 }
 
+// MoveLocations validates user input to move many locations to another category at once
+type MoveLocations struct {
+	// IDs of the locations to move.
+	IDs []string `json:"ids" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,min=1,max=100,dive,uuid" extensions:"x-order=1"`
+	// ID of the category to move locations to.
+	Category string `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=2"`
+}
+
 // NewLocation creates a new user location
 func NewLocation(id ID, name, address string, category ID, user ID) *Location {
 	return &Location{
 		id,
 		name,
 		address,
+		AddressComponents{},
+		nil,
+		nil,
+		"",
 		category,
+		nil,
 		user,
+		nil,
+		nil,
+		"",
+		nil,
+	}
+}
+
+// SetGroup gives location to specified group, or back to its user when group is NilID
+func (l *Location) SetGroup(group ID) {
+	if group == NilID {
+		l.Group = nil
+		return
+	}
+	l.Group = &group
+}
+
+// SetCoordinates sets location latitude and longitude
+func (l *Location) SetCoordinates(latitude, longitude float64) {
+	l.Latitude = &latitude
+	l.Longitude = &longitude
+}
+
+// SetTags sets location tag names, normalized. Nil names leave tags unset.
+func (l *Location) SetTags(names []string) {
+	l.Tags = NormalizeTagNames(names)
+}
+
+// EncodePlusCode returns the Open Location Code of location coordinates, or an empty string without coordinates
+func (l *Location) EncodePlusCode() string {
+	if l.Latitude == nil || l.Longitude == nil {
+		return ""
 	}
+
+	return pluscodes.Encode(*l.Latitude, *l.Longitude, pluscodes.DefaultLength)
+}
+
+// ValidCoordinatePrecision checks that coordinate has no more than CoordinatePrecision decimal places
+func ValidCoordinatePrecision(coordinate float64) bool {
+	scaled := coordinate * math.Pow10(CoordinatePrecision)
+	// Tolerate floating point representation errors
+	return math.Abs(scaled-math.Round(scaled)) < 1e-6
 }
 
 // Category model. Allows to describe what the location is used for, such as sport, work, living, etc.
@@ -80,15 +363,54 @@ type Category struct {
 	ID ID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=1"`
 	// Short descriptive name of the category. Like "Homes" or "Tennis Center".
 	Name string `json:"name" example:"Homes" extensions:"x-order=2"`
+	// Parent category, like "Sport" for "Tennis". Empty for top level categories.
+	Parent *ID `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=3"`
+	// Owner of the category. Empty for default categories, which are shared by all users and managed by admins.
+	User *ID `json:"user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=4"`
+	// Child categories. Only returned with category trees.
+	Children Categories `json:"children,omitempty" extensions:"x-order=5"`
 }
 
 // Categories is an array of categories
 type Categories []*Category
 
+// Tree arranges categories as a forest, returning top level categories with their descendants
+// as children. Categories whose parent is missing are returned at top level.
+func (c Categories) Tree() Categories {
+	nodes := make(map[ID]*Category, len(c))
+	for _, cat := range c {
+		node := *cat
+		node.Children = nil
+		nodes[cat.ID] = &node
+	}
+
+	roots := make(Categories, 0)
+	for _, cat := range c {
+		node := nodes[cat.ID]
+		if cat.Parent != nil {
+			if parent, ok := nodes[*cat.Parent]; ok && parent != node {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
+// GetCategories validates user input to get categories
+type GetCategories struct {
+	// Return categories as trees, with their descendants as children.
+	Tree bool `form:"tree" example:"true" extensions:"x-order=1"`
+}
+
 // CreateCategory validates user input to create a new category
 type CreateCategory struct {
 	// Short descriptive name of the category. Like "Homes" or "Tennis Center".
 	Name string `json:"name" example:"Homes" binding:"required" extensions:"x-order=1"`
+	// ID of the parent category, like "Sport" for "Tennis".
+	Parent ID `json:"parent_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=2"`
 }
 
 // GetCategoryByID validates user input to get a specific category using its id
@@ -107,6 +429,8 @@ type UpdateCategoryQuery struct {
 type UpdateCategory struct {
 	// Short descriptive name of the category, like "Homes" or "Sport".
 	Name string `json:"name" example:"Homes" binding:"required_without_all" extensions:"x-order=1"`
+	// ID of the parent category. Category is moved to top level when empty.
+	Parent ID `json:"parent_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=2"`
 }
 
 // DeleteCategory validates user input to delete an existing category
@@ -115,10 +439,59 @@ type DeleteCategory struct {
 	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
 }
 
-// NewCategory creates a new location category
+// DeleteCategoryOptions validates user input on what happens to the locations of a deleted category
+type DeleteCategoryOptions struct {
+	// ID of the category to move locations of the deleted category to.
+	ReassignTo string `form:"reassign_to" example:"550e8400-e29b-41d4-a716-446655440000" binding:"omitempty,uuid,excluded_with=Cascade" extensions:"x-order=1"`
+	// Delete locations of the category along with it.
+	Cascade bool `form:"cascade" example:"true" extensions:"x-order=2"`
+}
+
+// CategoryDeletion tells what happens to the locations of a deleted category. Categories that still
+// have locations are only deleted when their locations are either reassigned or deleted along with them.
+type CategoryDeletion struct {
+	ReassignTo *ID
+	Cascade    bool
+}
+
+// MergeCategoriesQuery validates query user input to merge categories into another one
+type MergeCategoriesQuery struct {
+	// ID of the category to merge categories into.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// MergeCategories validates body user input to merge categories into another one
+type MergeCategories struct {
+	// IDs of the categories to merge. Their locations and child categories move to the target category, then they are deleted.
+	Sources []string `json:"sources" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,min=1,max=50,dive,uuid" extensions:"x-order=1"`
+}
+
+// NewCategory creates a new location category. Categories are default ones until given to a user.
 func NewCategory(id ID, name string) *Category {
 	return &Category{
 		id,
 		name,
+		nil,
+		nil,
+		nil,
+	}
+}
+
+// SetParent moves category under specified parent category, or to top level when parent is NilID
+func (c *Category) SetParent(parent ID) {
+	if parent == NilID {
+		c.Parent = nil
+		return
 	}
+	c.Parent = &parent
+}
+
+// SetUser gives category to specified user
+func (c *Category) SetUser(user ID) {
+	c.User = &user
+}
+
+// IsDefault tells whether category is a default one, shared by all users
+func (c *Category) IsDefault() bool {
+	return c.User == nil
 }
@@ -89,13 +89,17 @@ type User struct {
 	ID ID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	// User email.
 	Email string `json:"email" example:"test@no-reply.com"`
+	// Roles of the user, like "admin".
+	Roles []string `json:"roles,omitempty" example:"admin"`
+	// Scopes the token of the user is restricted to, like "locations:read". Nil when not restricted.
+	Scopes []string `json:"scopes,omitempty" example:"locations:read"`
 }
 
 // NewUser creates a new user
 func NewUser(id ID, email string) *User {
 	return &User{
-		id,
-		email,
+		ID:    id,
+		Email: email,
 	}
 }
 
@@ -109,3 +113,17 @@ func NewUserFromContext(ctx context.Context) (*User, bool) {
 func NewContextWithUser(ctx context.Context, user *User) context.Context {
 	return context.WithValue(ctx, userContextKey, user)
 }
+
+// ParseIDs decodes input strings into IDs using specific ID implementation
+func ParseIDs(s []string) ([]ID, error) {
+	ids := make([]ID, len(s))
+	for i := range s {
+		id, err := ParseID(s[i])
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
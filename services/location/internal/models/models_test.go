@@ -2,6 +2,7 @@ package models
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,6 +23,17 @@ func TestIDStringParsing(t *testing.T) {
 	assert.Equal(t, id, parsedID)
 }
 
+func TestIDsStringParsing(t *testing.T) {
+	ids := []ID{NewID(), NewID()}
+	parsedIDs, err := ParseIDs([]string{ids[0].String(), ids[1].String()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ids, parsedIDs)
+
+	_, err = ParseIDs([]string{ids[0].String(), "invalid"})
+	assert.Error(t, err)
+}
+
 func TestIDJSONMarshalling(t *testing.T) {
 	id := NewID()
 	marshalledID, err := id.MarshalJSON()
@@ -56,3 +68,126 @@ func TestUserFromContext(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, user, userFromContext)
 }
+
+func TestLocationViewSelectedFields(t *testing.T) {
+	view := &LocationView{Fields: "id, name"}
+	fields, err := view.SelectedFields()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "name"}, fields)
+
+	view = &LocationView{}
+	fields, err = view.SelectedFields()
+	assert.NoError(t, err)
+	assert.Nil(t, fields)
+
+	view = &LocationView{Fields: "id,unknown"}
+	_, err = view.SelectedFields()
+	assert.Error(t, err)
+}
+
+func TestCategoriesTree(t *testing.T) {
+	sport := NewCategory(NewID(), "Sport")
+	tennis := NewCategory(NewID(), "Tennis")
+	tennis.SetParent(sport.ID)
+	indoor := NewCategory(NewID(), "Indoor courts")
+	indoor.SetParent(tennis.ID)
+	homes := NewCategory(NewID(), "Homes")
+	orphan := NewCategory(NewID(), "Orphan")
+	orphan.SetParent(NewID())
+
+	tree := Categories{indoor, sport, homes, tennis, orphan}.Tree()
+	if assert.Len(t, tree, 3) {
+		assert.Equal(t, sport.ID, tree[0].ID)
+		if assert.Len(t, tree[0].Children, 1) {
+			assert.Equal(t, tennis.ID, tree[0].Children[0].ID)
+			if assert.Len(t, tree[0].Children[0].Children, 1) {
+				assert.Equal(t, indoor.ID, tree[0].Children[0].Children[0].ID)
+			}
+		}
+		assert.Equal(t, homes.ID, tree[1].ID)
+		assert.Equal(t, orphan.ID, tree[2].ID)
+	}
+	// Source categories are left untouched
+	assert.Nil(t, sport.Children)
+}
+
+func TestLocationSelectFields(t *testing.T) {
+	cat := NewCategory(NewID(), "Test Category")
+	loc := NewLocation(NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, NewID())
+
+	assert.Equal(t, map[string]interface{}{"id": loc.ID, "name": loc.Name}, loc.SelectFields([]string{"id", "name"}))
+
+	loc.ExpandedCategory = cat
+	assert.Equal(t, map[string]interface{}{"name": loc.Name, "category": cat}, loc.SelectFields([]string{"name"}))
+}
+
+func TestLocationPlusCode(t *testing.T) {
+	loc := NewLocation(NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", NewID(), NewID())
+	assert.Equal(t, "", loc.EncodePlusCode())
+	assert.Equal(t, map[string]interface{}{}, loc.SelectFields([]string{"plus_code"}))
+
+	data, err := json.Marshal(loc)
+	if assert.NoError(t, err) {
+		assert.NotContains(t, string(data), "plus_code")
+	}
+
+	loc.SetCoordinates(48.8606111, 2.3376)
+	assert.Equal(t, "8FW4V86Q+62", loc.EncodePlusCode())
+	assert.Equal(t, map[string]interface{}{"plus_code": "8FW4V86Q+62"}, loc.SelectFields([]string{"plus_code"}))
+
+	data, err = json.Marshal(loc)
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(data), `"plus_code":"8FW4V86Q+62"`)
+	}
+}
+
+func TestValidCoordinatePrecision(t *testing.T) {
+	assert.True(t, ValidCoordinatePrecision(48.8606111))
+	assert.True(t, ValidCoordinatePrecision(-179.9999999))
+	assert.True(t, ValidCoordinatePrecision(2))
+	assert.False(t, ValidCoordinatePrecision(48.86061112))
+}
+
+func TestParsePoint(t *testing.T) {
+	point, err := ParsePoint("48.8606111, 2.3376")
+	assert.NoError(t, err)
+	assert.Equal(t, NewPoint(48.8606111, 2.3376), point)
+
+	for _, s := range []string{"", "48.8606111", "48.8606111,2.3376,1", "abc,2.3376", "90.5,2.3376", "48.8606111,-180.5"} {
+		_, err := ParsePoint(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPointDistanceTo(t *testing.T) {
+	paris := NewPoint(48.8566, 2.3522)
+	london := NewPoint(51.5074, -0.1278)
+
+	assert.InDelta(t, 343500, paris.DistanceTo(london), 500)
+	assert.Equal(t, 0.0, paris.DistanceTo(paris))
+}
+
+func TestParseBoundingBox(t *testing.T) {
+	bbox, err := ParseBoundingBox("2.25, 48.81,2.42,48.9")
+	assert.NoError(t, err)
+	assert.Equal(t, BoundingBox{2.25, 48.81, 2.42, 48.9}, bbox)
+
+	for _, s := range []string{"", "2.25,48.81,2.42", "2.25,48.81,2.42,abc", "2.25,48.9,2.42,48.81", "-181,48.81,2.42,48.9", "2.25,-91,2.42,48.9"} {
+		_, err := ParseBoundingBox(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	bbox := BoundingBox{2.25, 48.81, 2.42, 48.9}
+	assert.True(t, bbox.Contains(NewPoint(48.8606111, 2.3376)))
+	assert.True(t, bbox.Contains(NewPoint(48.81, 2.25)))
+	assert.False(t, bbox.Contains(NewPoint(48.8606111, 2.5)))
+	assert.False(t, bbox.Contains(NewPoint(49, 2.3376)))
+
+	pacific := BoundingBox{170, -20, -170, 20}
+	assert.True(t, pacific.CrossesAntimeridian())
+	assert.True(t, pacific.Contains(NewPoint(0, 179)))
+	assert.True(t, pacific.Contains(NewPoint(0, -179)))
+	assert.False(t, pacific.Contains(NewPoint(0, 0)))
+}
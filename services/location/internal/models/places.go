@@ -0,0 +1,37 @@
+package models
+
+import "strconv"
+
+// Place is a geocoding result. It matches a normalized address with its coordinates.
+type Place struct {
+	// Full formatted address of the place.
+	Address string `json:"address" example:"1 Rue de la Poste, 75001 Paris, France" extensions:"x-order=1"`
+	// Latitude of the place in decimal degrees (WGS 84).
+	Latitude float64 `json:"latitude" example:"48.8606111" extensions:"x-order=2"`
+	// Longitude of the place in decimal degrees (WGS 84).
+	Longitude float64 `json:"longitude" example:"2.3376" extensions:"x-order=3"`
+}
+
+// NewPlace creates a new place
+func NewPlace(address string, latitude, longitude float64) *Place {
+	return &Place{
+		address,
+		latitude,
+		longitude,
+	}
+}
+
+// NewPlaceFromCoordinates creates a place whose address is made of its coordinates.
+// It is used when no actual address is known at these coordinates.
+func NewPlaceFromCoordinates(latitude, longitude float64) *Place {
+	address := strconv.FormatFloat(latitude, 'f', -1, 64) + ", " + strconv.FormatFloat(longitude, 'f', -1, 64)
+	return NewPlace(address, latitude, longitude)
+}
+
+// ReverseGeocode validates user input to find the address at specific coordinates
+type ReverseGeocode struct {
+	// Latitude in decimal degrees (WGS 84).
+	Latitude *float64 `form:"lat" binding:"required,min=-90,max=90" extensions:"x-order=1"`
+	// Longitude in decimal degrees (WGS 84).
+	Longitude *float64 `form:"lng" binding:"required,min=-180,max=180" extensions:"x-order=2"`
+}
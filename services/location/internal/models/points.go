@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// EarthRadius is the mean radius of the Earth in meters
+const EarthRadius = 6371008.8
+
+// Point is a position on Earth, in decimal degrees (WGS 84)
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// NewPoint creates a new point
+func NewPoint(latitude, longitude float64) Point {
+	return Point{latitude, longitude}
+}
+
+// ParsePoint parses a point formatted as "latitude,longitude", like "48.8606111,2.3376"
+func ParsePoint(s string) (Point, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return Point{}, fmt.Errorf("invalid point : %s", s)
+	}
+
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return Point{}, fmt.Errorf("invalid latitude : %s", parts[0])
+	}
+
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return Point{}, fmt.Errorf("invalid longitude : %s", parts[1])
+	}
+
+	return NewPoint(latitude, longitude), nil
+}
+
+// DistanceTo returns the great-circle distance to other point in meters, using the haversine formula
+func (p Point) DistanceTo(other Point) float64 {
+	lat1 := p.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (other.Longitude - p.Longitude) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox is a rectangular area delimited by its south-west and north-east corners.
+// It crosses the antimeridian when West is greater than East.
+type BoundingBox struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
+}
+
+// ParseBoundingBox parses a bounding box formatted as "west,south,east,north", like "2.25,48.81,2.42,48.9"
+func ParseBoundingBox(s string) (BoundingBox, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return BoundingBox{}, fmt.Errorf("invalid bounding box : %s", s)
+	}
+
+	var values [4]float64
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return BoundingBox{}, fmt.Errorf("invalid bounding box : %s. %w", s, err)
+		}
+		values[i] = value
+	}
+
+	bbox := BoundingBox{values[0], values[1], values[2], values[3]}
+	if bbox.West < -180 || bbox.West > 180 || bbox.East < -180 || bbox.East > 180 ||
+		bbox.South < -90 || bbox.North > 90 || bbox.South > bbox.North {
+		return BoundingBox{}, fmt.Errorf("invalid bounding box : %s", s)
+	}
+
+	return bbox, nil
+}
+
+// CrossesAntimeridian tells whether bounding box spans over the 180th meridian
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.West > b.East
+}
+
+// Contains tells whether point is inside bounding box, edges included
+func (b BoundingBox) Contains(p Point) bool {
+	if p.Latitude < b.South || p.Latitude > b.North {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return p.Longitude >= b.West || p.Longitude <= b.East
+	}
+	return p.Longitude >= b.West && p.Longitude <= b.East
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// Validity of share links
+const (
+	// DefaultShareLinkValidity is the validity of share links created without explicit validity
+	DefaultShareLinkValidity = 7 * 24 * time.Hour
+	// MaxShareLinkValidity is the longest validity of share links
+	MaxShareLinkValidity = 30 * 24 * time.Hour
+)
+
+// ShareLink model. Public link to a location, given to guests who are not users, until it expires or is revoked.
+type ShareLink struct {
+	// Share link ID. Must be unique.
+	ID ID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=1"`
+	// ID of the shared location.
+	Location ID `json:"location_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=2"`
+	// Signed token giving access to the location through the public API.
+	Token string `json:"token" example:"VQ6EAOKbQdSnFkRmVUQAAAAAAABgPNDg.2JtK0hqHbQ6Hn2r7bS0x8y3Bq8Yx6o9m0kDq3vR6fJc" extensions:"x-order=3"`
+	// Date after which the link cannot be used anymore.
+	ExpiresAt time.Time `json:"expires_at" example:"2021-03-08T12:00:00Z" extensions:"x-order=4"`
+	// Number of times the location has been accessed through the link.
+	Accesses int `json:"accesses" example:"3" extensions:"x-order=5"`
+}
+
+// ShareLinks is an array of share links
+type ShareLinks []*ShareLink
+
+// PublicLocation model. Restricted view of a location, returned to guests holding a share link.
+type PublicLocation struct {
+	// Short descriptive name of the location, like "Home" or "Work".
+	Name string `json:"name" example:"Home" extensions:"x-order=1"`
+	// Full address of the location.
+	Address string `json:"address" example:"1 rue de la Poste, 75001 Paris" extensions:"x-order=2"`
+	// Latitude of the location in decimal degrees (WGS 84). Empty until resolved.
+	Latitude *float64 `json:"latitude,omitempty" example:"48.8606111" extensions:"x-order=3"`
+	// Longitude of the location in decimal degrees (WGS 84). Empty until resolved.
+	Longitude *float64 `json:"longitude,omitempty" example:"2.3376" extensions:"x-order=4"`
+}
+
+// GetShareLinks validates user input to get the share links of a location
+type GetShareLinks struct {
+	// ID of the shared location.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// CreateShareLinkQuery validates query user input to create a share link
+type CreateShareLinkQuery struct {
+	// ID of the location to share.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// CreateShareLink validates body user input to create a share link
+type CreateShareLink struct {
+	// Validity of the link in seconds, 7 days by default and 30 days at most.
+	ExpiresIn int `json:"expires_in" example:"86400" binding:"omitempty,min=60,max=2592000" extensions:"x-order=1"`
+}
+
+// ShareLinkQuery validates user input to revoke a share link
+type ShareLinkQuery struct {
+	// ID of the shared location.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+	// ID of the share link.
+	Link string `uri:"link_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=2"`
+}
+
+// GetPublicLocation validates user input to get a location through a share link
+type GetPublicLocation struct {
+	// Token of the share link.
+	Token string `uri:"token" example:"VQ6EAOKbQdSnFkRmVUQAAAAAAABgPNDg.2JtK0hqHbQ6Hn2r7bS0x8y3Bq8Yx6o9m0kDq3vR6fJc" binding:"required,max=200" extensions:"x-order=1"`
+}
+
+// NewShareLink creates a new share link to location, expiring at expiresAt
+func NewShareLink(id ID, location ID, expiresAt time.Time) *ShareLink {
+	return &ShareLink{
+		id,
+		location,
+		"",
+		expiresAt,
+		0,
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+// ShareRole tells what a user may do with a location shared with them
+type ShareRole string
+
+// Roles granted to users on shared locations and categories
+const (
+	// ShareRoleOwner may update, delete and share locations, like their owner
+	ShareRoleOwner ShareRole = "owner"
+	// ShareRoleEditor may update locations, but not delete or share them
+	ShareRoleEditor ShareRole = "editor"
+	// ShareRoleViewer may only read locations
+	ShareRoleViewer ShareRole = "viewer"
+)
+
+// CanEdit tells whether the role allows to update locations
+func (r ShareRole) CanEdit() bool {
+	return r == ShareRoleOwner || r == ShareRoleEditor
+}
+
+// CanManage tells whether the role allows to delete locations and to share them
+func (r ShareRole) CanManage() bool {
+	return r == ShareRoleOwner
+}
+
+// Share model. Grant of a role on a location, or on all locations of a category, to another user.
+type Share struct {
+	// ID of the user the location or the category is shared with.
+	User ID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=1"`
+	// Role granted to the user: "owner", "editor" or "viewer".
+	Role ShareRole `json:"role" example:"editor" extensions:"x-order=2"`
+}
+
+// Shares is an array of shares
+type Shares []*Share
+
+// GetShares validates user input to get the shares of a location or a category
+type GetShares struct {
+	// ID of the shared location or category.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// ShareQuery validates query user input to grant or revoke a share
+type ShareQuery struct {
+	// ID of the shared location or category.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+	// ID of the user the location or the category is shared with.
+	User string `uri:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=2"`
+}
+
+// GrantShare validates body user input to grant a role on a location or a category. Roles already granted are replaced.
+type GrantShare struct {
+	// Role granted to the user: "owner", "editor" or "viewer".
+	Role string `json:"role" example:"editor" binding:"required,oneof=owner editor viewer" extensions:"x-order=1"`
+}
+
+// NewShare creates a new share of role to user
+func NewShare(user ID, role ShareRole) *Share {
+	return &Share{
+		user,
+		role,
+	}
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tag model. Free-form label given by users to their locations, like "Vegan" or "Favorite".
+// A location may have many tags, and a tag may be given to many locations.
+type Tag struct {
+	// Tag ID. Must be unique.
+	ID ID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=1"`
+	// Name of the tag, unique for each user.
+	Name string `json:"name" example:"Favorite" extensions:"x-order=2"`
+	// User ID. Owner of the tag.
+	User ID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" extensions:"x-order=3"`
+}
+
+// Tags is an array of tags
+type Tags []*Tag
+
+// CreateTag validates user input to create a new tag
+type CreateTag struct {
+	// Name of the tag, like "Favorite". Commas are not allowed.
+	Name string `json:"name" example:"Favorite" binding:"required,max=50,excludesall=0x2C" extensions:"x-order=1"`
+}
+
+// GetTagByID validates user input to get a specific tag using its id
+type GetTagByID struct {
+	// ID of the tag.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// UpdateTagQuery validates query user input to update an existing tag
+type UpdateTagQuery struct {
+	// ID of the tag.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// UpdateTag validates body user input to update an existing tag
+type UpdateTag struct {
+	// New name of the tag. Commas are not allowed.
+	Name string `json:"name" example:"Favorite" binding:"required,max=50,excludesall=0x2C" extensions:"x-order=1"`
+}
+
+// DeleteTag validates user input to delete an existing tag. Tag is removed from all locations.
+type DeleteTag struct {
+	// ID of the tag.
+	ID string `uri:"id" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid" extensions:"x-order=1"`
+}
+
+// NewTag creates a new user tag
+func NewTag(id ID, name string, user ID) *Tag {
+	return &Tag{
+		id,
+		name,
+		user,
+	}
+}
+
+// NormalizeTagNames trims tag names, drops empty and duplicate ones, and sorts them.
+// Nil is returned as is, so that "no tags" and "tags unchanged" can be told apart.
+func NormalizeTagNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	sort.Strings(normalized)
+
+	return normalized
+}
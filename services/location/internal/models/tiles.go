@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TileExtension is the file extension of Mapbox Vector Tiles
+const TileExtension = ".mvt"
+
+// GetLocationTile validates user input to get a vector tile of locations.
+// Gin path parameters span the whole segment, so the tile row comes with its extension.
+type GetLocationTile struct {
+	// Zoom level of the tile, from 0 to 22.
+	Z uint32 `uri:"z" example:"10" binding:"max=22" extensions:"x-order=1"`
+	// Column of the tile.
+	X uint32 `uri:"x" example:"518" extensions:"x-order=2"`
+	// Row of the tile followed by ".mvt", like "352.mvt".
+	Y string `uri:"y.mvt" example:"352.mvt" binding:"required" extensions:"x-order=3"`
+}
+
+// Row returns the tile row, without file extension
+func (q *GetLocationTile) Row() (uint32, error) {
+	if !strings.HasSuffix(q.Y, TileExtension) {
+		return 0, fmt.Errorf("unsupported tile format : %s", q.Y)
+	}
+
+	row, err := strconv.ParseUint(strings.TrimSuffix(q.Y, TileExtension), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tile row : %s", q.Y)
+	}
+
+	return uint32(row), nil
+}
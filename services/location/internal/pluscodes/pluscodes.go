@@ -0,0 +1,313 @@
+// Package pluscodes encodes coordinates into Open Location Codes, also known as plus codes,
+// and decodes them back into the areas they cover. Short codes, like "V75V+8Q", are recovered
+// relative to a reference point.
+// See https://github.com/google/open-location-code/blob/main/docs/specification.md
+package pluscodes
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultLength is the number of digits of plus codes returned with locations, accurate to about 14 meters
+	DefaultLength = 10
+	// MaxLength is the number of digits beyond which extra digits are ignored
+	MaxLength = 15
+
+	// Separator follows the eighth digit of every code
+	Separator = '+'
+	// Padding replaces trailing digits of codes shorter than eight digits
+	Padding = '0'
+
+	alphabet     = "23456789CFGHJMPQRVWX"
+	separatorPos = 8
+	pairLength   = 10
+	gridRows     = 5
+	gridColumns  = 4
+
+	latMax = 90
+	lngMax = 180
+
+	// Integer precision of the last pair digits, then of the last grid digit
+	pairPrecision    = 8000
+	latGridPrecision = 3125 // gridRows^5
+	lngGridPrecision = 1024 // gridColumns^5
+)
+
+var (
+	// ErrInvalidCode is raised when a string is not a valid plus code
+	ErrInvalidCode = errors.New("invalid plus code")
+	// ErrShortCode is raised when a full code is required but a short one is given
+	ErrShortCode = errors.New("short plus code requires a reference")
+)
+
+// Degrees covered by one digit of each pair
+var pairResolutions = [5]float64{20, 1, 0.05, 0.0025, 0.000125}
+
+// CodeArea is the rectangle covered by a plus code
+type CodeArea struct {
+	LatitudeLo, LongitudeLo float64
+	LatitudeHi, LongitudeHi float64
+	// Number of digits of the code
+	Length int
+}
+
+// Center returns the coordinates of the center of the area, clipped to valid coordinates
+func (a CodeArea) Center() (latitude, longitude float64) {
+	latitude = math.Min((a.LatitudeLo+a.LatitudeHi)/2, latMax)
+	longitude = math.Min((a.LongitudeLo+a.LongitudeHi)/2, lngMax)
+	return
+}
+
+// Encode returns the plus code of specified coordinates with length digits, from 2 to MaxLength.
+// Odd lengths under 10 are rounded up, and codes shorter than 8 digits are padded.
+func Encode(latitude, longitude float64, length int) string {
+	switch {
+	case length < 2:
+		length = 2
+	case length > MaxLength:
+		length = MaxLength
+	case length < pairLength && length%2 == 1:
+		length++
+	}
+
+	latVal := latitudeAsInteger(latitude)
+	lngVal := longitudeAsInteger(longitude)
+
+	digits := make([]byte, MaxLength)
+	for i := MaxLength - 1; i >= pairLength; i-- {
+		digits[i] = alphabet[(latVal%gridRows)*gridColumns+lngVal%gridColumns]
+		latVal /= gridRows
+		lngVal /= gridColumns
+	}
+	for i := pairLength - 1; i >= 0; i -= 2 {
+		digits[i] = alphabet[lngVal%20]
+		digits[i-1] = alphabet[latVal%20]
+		latVal /= 20
+		lngVal /= 20
+	}
+
+	var code strings.Builder
+	for i := 0; i < separatorPos; i++ {
+		if i < length {
+			code.WriteByte(digits[i])
+		} else {
+			code.WriteByte(Padding)
+		}
+	}
+	code.WriteByte(Separator)
+	if length > separatorPos {
+		code.Write(digits[separatorPos:length])
+	}
+
+	return code.String()
+}
+
+// latitudeAsInteger converts latitude to an integer count of the smallest grid cells from the south pole
+func latitudeAsInteger(latitude float64) int64 {
+	max := int64(2 * latMax * pairPrecision * latGridPrecision)
+	val := int64(math.Floor(latitude*pairPrecision*latGridPrecision)) + latMax*pairPrecision*latGridPrecision
+	if val < 0 {
+		return 0
+	}
+	if val >= max {
+		return max - 1
+	}
+	return val
+}
+
+// longitudeAsInteger converts longitude to an integer count of the smallest grid cells from the antimeridian
+func longitudeAsInteger(longitude float64) int64 {
+	max := int64(2 * lngMax * pairPrecision * lngGridPrecision)
+	val := int64(math.Floor(longitude*pairPrecision*lngGridPrecision)) + lngMax*pairPrecision*lngGridPrecision
+	val %= max
+	if val < 0 {
+		val += max
+	}
+	return val
+}
+
+// Decode returns the area covered by a full plus code
+func Decode(code string) (CodeArea, error) {
+	if err := CheckFull(code); err != nil {
+		return CodeArea{}, err
+	}
+
+	digits := strings.ToUpper(strings.Replace(code, string(Separator), "", 1))
+	digits = strings.TrimRight(digits, string(Padding))
+	if len(digits) > MaxLength {
+		digits = digits[:MaxLength]
+	}
+
+	area := CodeArea{LatitudeLo: -latMax, LongitudeLo: -lngMax, Length: len(digits)}
+	latResolution, lngResolution := 0.0, 0.0
+	for i := 0; i < len(digits) && i < pairLength; i += 2 {
+		latResolution = pairResolutions[i/2]
+		lngResolution = pairResolutions[i/2]
+		area.LatitudeLo += float64(strings.IndexByte(alphabet, digits[i])) * latResolution
+		area.LongitudeLo += float64(strings.IndexByte(alphabet, digits[i+1])) * lngResolution
+	}
+	for i := pairLength; i < len(digits); i++ {
+		latResolution /= gridRows
+		lngResolution /= gridColumns
+		value := strings.IndexByte(alphabet, digits[i])
+		area.LatitudeLo += float64(value/gridColumns) * latResolution
+		area.LongitudeLo += float64(value%gridColumns) * lngResolution
+	}
+	area.LatitudeHi = area.LatitudeLo + latResolution
+	area.LongitudeHi = area.LongitudeLo + lngResolution
+
+	return area, nil
+}
+
+// CheckValid returns ErrInvalidCode unless code is a valid full or short plus code
+func CheckValid(code string) error {
+	if len(code) < 2 {
+		return ErrInvalidCode
+	}
+
+	sep := strings.IndexByte(code, Separator)
+	if sep < 0 || sep != strings.LastIndexByte(code, Separator) || sep > separatorPos || sep%2 == 1 {
+		return ErrInvalidCode
+	}
+	// A single digit after the separator is not allowed
+	if len(code)-sep-1 == 1 {
+		return ErrInvalidCode
+	}
+
+	if pad := strings.IndexByte(code, Padding); pad >= 0 {
+		// Padding is only allowed in full codes, between an even number of digits and the separator
+		if sep < separatorPos || pad == 0 || pad%2 == 1 {
+			return ErrInvalidCode
+		}
+		if strings.Trim(code[pad:sep], string(Padding)) != "" || sep != len(code)-1 {
+			return ErrInvalidCode
+		}
+		code = code[:pad] + code[sep:]
+	}
+
+	for _, r := range strings.ToUpper(code) {
+		if r != Separator && !strings.ContainsRune(alphabet, r) {
+			return ErrInvalidCode
+		}
+	}
+
+	return nil
+}
+
+// IsShort tells whether code is a valid short plus code, missing its leading digits
+func IsShort(code string) bool {
+	return CheckValid(code) == nil && strings.IndexByte(code, Separator) < separatorPos
+}
+
+// CheckFull returns ErrInvalidCode unless code is a valid full plus code, or ErrShortCode if it is short
+func CheckFull(code string) error {
+	if err := CheckValid(code); err != nil {
+		return err
+	}
+	if IsShort(code) {
+		return ErrShortCode
+	}
+
+	// First digits must not exceed latitude and longitude ranges
+	code = strings.ToUpper(code)
+	if strings.IndexByte(alphabet, code[0])*20 >= 2*latMax || strings.IndexByte(alphabet, code[1])*20 >= 2*lngMax {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
+
+// Shorten removes as many leading digits from a full code as allowed for it to be recovered
+// from a reference point nearby. Codes which cannot be shortened are returned unchanged.
+func Shorten(code string, latitude, longitude float64) (string, error) {
+	area, err := Decode(code)
+	if err != nil {
+		return "", err
+	}
+	if strings.IndexByte(code, Padding) >= 0 {
+		return strings.ToUpper(code), nil
+	}
+
+	centerLat, centerLng := area.Center()
+	distance := math.Max(math.Abs(centerLat-clipLatitude(latitude)), math.Abs(centerLng-normalizeLongitude(longitude)))
+	for i := len(pairResolutions) - 2; i >= 1; i-- {
+		// Leave a safety margin, as the reference point may be anywhere in the area of the recovered code
+		if distance < pairResolutions[i]*0.3 {
+			return strings.ToUpper(code[(i+1)*2:]), nil
+		}
+	}
+
+	return strings.ToUpper(code), nil
+}
+
+// RecoverNearest returns the full code nearest to the reference point whose trailing digits
+// match short code. Full codes are returned unchanged.
+func RecoverNearest(code string, latitude, longitude float64) (string, error) {
+	if err := CheckValid(code); err != nil {
+		return "", err
+	}
+	if !IsShort(code) {
+		if err := CheckFull(code); err != nil {
+			return "", err
+		}
+		return strings.ToUpper(code), nil
+	}
+
+	latitude = clipLatitude(latitude)
+	longitude = normalizeLongitude(longitude)
+
+	missing := separatorPos - strings.IndexByte(code, Separator)
+	resolution := math.Pow(20, float64(2-missing/2))
+	half := resolution / 2
+
+	// Borrow missing digits from the reference point, then move to the nearest matching cell
+	area, err := Decode(Encode(latitude, longitude, pairLength)[:missing] + code)
+	if err != nil {
+		return "", err
+	}
+	centerLat, centerLng := area.Center()
+
+	switch {
+	case latitude+half < centerLat && centerLat-resolution >= -latMax:
+		centerLat -= resolution
+	case latitude-half > centerLat && centerLat+resolution <= latMax:
+		centerLat += resolution
+	}
+	switch {
+	case longitude+half < centerLng:
+		centerLng -= resolution
+	case longitude-half > centerLng:
+		centerLng += resolution
+	}
+
+	return Encode(centerLat, centerLng, area.Length), nil
+}
+
+func clipLatitude(latitude float64) float64 {
+	return math.Max(-latMax, math.Min(latMax, latitude))
+}
+
+func normalizeLongitude(longitude float64) float64 {
+	for longitude < -lngMax {
+		longitude += 2 * lngMax
+	}
+	for longitude >= lngMax {
+		longitude -= 2 * lngMax
+	}
+	return longitude
+}
+
+// Split separates a plus code from the locality following it, like "V86Q+62 Paris",
+// which is used as reference to recover short codes
+func Split(s string) (code, locality string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexFunc(s, unicode.IsSpace); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+
+	return s, ""
+}
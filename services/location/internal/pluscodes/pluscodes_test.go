@@ -0,0 +1,129 @@
+package pluscodes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	for _, test := range []struct {
+		latitude, longitude float64
+		length              int
+		code                string
+	}{
+		{20.375, 2.775, 6, "7FG49Q00+"},
+		{20.3700625, 2.7821875, 10, "7FG49QCJ+2V"},
+		{20.3701125, 2.782234375, 11, "7FG49QCJ+2VX"},
+		{47.0000625, 8.0000625, 10, "8FVC2222+22"},
+		{-41.2730625, 174.7859375, 10, "4VCPPQGP+Q9"},
+		{0.5, -179.5, 4, "62G20000+"},
+		{-89.5, -179.5, 4, "22220000+"},
+		{20.5, 2.5, 4, "7FG40000+"},
+		{-89.9999375, -179.9999375, 10, "22222222+22"},
+		{89.5, 179.5, 4, "CVXX0000+"},
+		{90, 1, 4, "CFX30000+"},
+		{1, 180, 4, "62H20000+"},
+		{1, 181, 4, "62H30000+"},
+		{20.375, 2.775, 5, "7FG49Q00+"},
+	} {
+		assert.Equal(t, test.code, Encode(test.latitude, test.longitude, test.length), test)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	area, err := Decode("7FG49QCJ+2V")
+	if assert.NoError(t, err) {
+		assert.InDelta(t, 20.37, area.LatitudeLo, 1e-9)
+		assert.InDelta(t, 2.782125, area.LongitudeLo, 1e-9)
+		assert.InDelta(t, 20.370125, area.LatitudeHi, 1e-9)
+		assert.InDelta(t, 2.78225, area.LongitudeHi, 1e-9)
+		assert.Equal(t, 10, area.Length)
+	}
+
+	area, err = Decode("7fg49q00+")
+	if assert.NoError(t, err) {
+		assert.InDelta(t, 20.35, area.LatitudeLo, 1e-9)
+		assert.InDelta(t, 2.8, area.LongitudeHi, 1e-9)
+		assert.Equal(t, 6, area.Length)
+	}
+
+	area, err = Decode("7FG49QCJ+2VX")
+	if assert.NoError(t, err) {
+		latitude, longitude := area.Center()
+		assert.Equal(t, "7FG49QCJ+2VX", Encode(latitude, longitude, area.Length))
+	}
+
+	_, err = Decode("V75V+8Q")
+	assert.Equal(t, ErrShortCode, err)
+}
+
+func TestCheckValid(t *testing.T) {
+	for _, code := range []string{"8FWC2345+G6", "8FWC2345+G6G", "8fwc2345+", "8FWCX400+", "WC2345+G6g", "2345+G6", "45+G6", "+G6"} {
+		assert.NoError(t, CheckValid(code), code)
+	}
+	for _, code := range []string{"G+", "+", "8FWC2345+G", "8FWC2_45+G6", "8FWC2η45+G6", "8FWC2345+G6+", "8FWC2345G6+", "8FWC2300+G6", "WC2300+G6g", "WC2345+G", ""} {
+		assert.Equal(t, ErrInvalidCode, CheckValid(code), code)
+	}
+}
+
+func TestCheckFull(t *testing.T) {
+	assert.NoError(t, CheckFull("8FWC2345+G6"))
+	assert.Equal(t, ErrShortCode, CheckFull("WC2345+G6g"))
+	assert.Equal(t, ErrInvalidCode, CheckFull("X2000000+"))
+	assert.Equal(t, ErrInvalidCode, CheckFull("2X000000+"))
+}
+
+func TestShorten(t *testing.T) {
+	for _, test := range []struct {
+		code                string
+		latitude, longitude float64
+		short               string
+	}{
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217765625, "+2VX"},
+		{"9C3W9QCJ+2VX", 51.3708675, -1.217765625, "CJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3901125, -1.217765625, "9QCJ+2VX"},
+		{"9C3W9QCJ+2VX", 53.3701125, -1.217765625, "9C3W9QCJ+2VX"},
+		{"9C3W9Q00+", 51.3701125, -1.217765625, "9C3W9Q00+"},
+	} {
+		short, err := Shorten(test.code, test.latitude, test.longitude)
+		assert.NoError(t, err)
+		assert.Equal(t, test.short, short, test)
+	}
+}
+
+func TestRecoverNearest(t *testing.T) {
+	for _, test := range []struct {
+		short               string
+		latitude, longitude float64
+		code                string
+	}{
+		{"+2VX", 51.3701125, -1.217765625, "9C3W9QCJ+2VX"},
+		{"CJ+2VX", 51.3708675, -1.217765625, "9C3W9QCJ+2VX"},
+		{"9QCJ+2VX", 51.3901125, -1.217765625, "9C3W9QCJ+2VX"},
+		{"v75v+8q", 48.85, 2.35, "8FW4V75V+8Q"},
+		// Reference point on the other side of a cell boundary
+		{"2222+22", 89.6, 0, "CFX22222+22"},
+		{"XXXX+XX", 0, 179.999, "6VFXXXXX+XX"},
+		{"8FW4V75V+8Q", 0, 0, "8FW4V75V+8Q"},
+	} {
+		code, err := RecoverNearest(test.short, test.latitude, test.longitude)
+		assert.NoError(t, err)
+		assert.Equal(t, test.code, code, test)
+	}
+
+	_, err := RecoverNearest("V75V+8", 48.85, 2.35)
+	assert.Equal(t, ErrInvalidCode, err)
+}
+
+func TestSplit(t *testing.T) {
+	for s, expected := range map[string][2]string{
+		"8FW4V86Q+62":             {"8FW4V86Q+62", ""},
+		" V86Q+62 Paris ":         {"V86Q+62", "Paris"},
+		"V86Q+62 Paris, France":   {"V86Q+62", "Paris, France"},
+		"V86Q+62\tSaint-Denis 93": {"V86Q+62", "Saint-Denis 93"},
+	} {
+		code, locality := Split(s)
+		assert.Equal(t, expected, [2]string{code, locality}, s)
+	}
+}
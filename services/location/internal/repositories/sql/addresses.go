@@ -0,0 +1,66 @@
+package sqlrepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// FindLocationsWithoutAddressComponents returns up to limit locations of every user having an address
+// but no address components, with an ID greater than after. Only their ID and address are set.
+// It is used to backfill address components, which are parsed in Go, of locations created before them.
+func (r *SQLRepository) FindLocationsWithoutAddressComponents(ctx context.Context, after models.ID, limit int) (*models.Locations, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT id, address FROM locations " +
+		"WHERE id > $1 AND address <> '' AND street = '' AND house_number = '' AND postal_code = '' AND locality = '' AND region = '' AND country_code = '' " +
+		"ORDER BY id LIMIT $2"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsWithoutAddressComponents: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsWithoutAddressComponents: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	locations := make(models.Locations, 0)
+	for rows.Next() {
+		loc := new(models.Location)
+		if err := rows.Scan(&loc.ID, &loc.Address); err != nil {
+			return nil, fmt.Errorf("FindLocationsWithoutAddressComponents: failed to scan SQL row. %w", err)
+		}
+		locations = append(locations, loc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindLocationsWithoutAddressComponents: rows failed. %w", err)
+	}
+
+	return &locations, nil
+}
+
+// UpdateAddressComponents sets address components of location matching loc ID, whoever its owner is
+func (r *SQLRepository) UpdateAddressComponents(ctx context.Context, loc *models.Location) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "UPDATE locations SET street = $1, house_number = $2, postal_code = $3, locality = $4, region = $5, country_code = $6 WHERE id = $7"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("UpdateAddressComponents: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.ID)
+	if err != nil {
+		return fmt.Errorf("UpdateAddressComponents: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
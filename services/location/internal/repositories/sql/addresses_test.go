@@ -0,0 +1,69 @@
+package sqlrepository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const findLocationsWithoutAddressComponentsQuery = "SELECT id, address FROM locations " +
+	"WHERE id > $1 AND address <> '' AND street = '' AND house_number = '' AND postal_code = '' AND locality = '' AND region = '' AND country_code = '' " +
+	"ORDER BY id LIMIT $2"
+
+func TestFindLocationsWithoutAddressComponentsWithQueryError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	prep := mock.ExpectPrepare(findLocationsWithoutAddressComponentsQuery)
+	prep.ExpectQuery().WithArgs(models.NilID, 100).WillReturnError(errors.New("failed"))
+
+	locations, err := repo.FindLocationsWithoutAddressComponents(newTestContext(), models.NilID, 100)
+	assert.Error(t, err)
+	assert.Nil(t, locations)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsWithoutAddressComponentsWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	after := models.NewID()
+	loc1 := &models.Location{ID: models.NewID(), Address: "1 rue de la Poste, 75001 Paris"}
+	loc2 := &models.Location{ID: models.NewID(), Address: "Hauptstraße 5, 10115 Berlin"}
+
+	rows := sqlmock.NewRows([]string{"id", "address"}).
+		AddRow(loc1.ID, loc1.Address).
+		AddRow(loc2.ID, loc2.Address)
+
+	prep := mock.ExpectPrepare(findLocationsWithoutAddressComponentsQuery)
+	prep.ExpectQuery().WithArgs(after, 100).WillReturnRows(rows)
+
+	locations, err := repo.FindLocationsWithoutAddressComponents(newTestContext(), after, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Locations{loc1, loc2}, locations)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateAddressComponentsWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	loc := &models.Location{
+		ID:      models.NewID(),
+		Address: "1 rue de la Poste, 75001 Paris, France",
+		Components: models.AddressComponents{
+			Street: "rue de la Poste", HouseNumber: "1", PostalCode: "75001", Locality: "Paris", CountryCode: "FR",
+		},
+	}
+
+	query := "UPDATE locations SET street = $1, house_number = $2, postal_code = $3, locality = $4, region = $5, country_code = $6 WHERE id = $7"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs("rue de la Poste", "1", "75001", "Paris", "", "FR", loc.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.UpdateAddressComponents(newTestContext(), loc)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
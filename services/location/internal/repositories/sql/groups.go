@@ -0,0 +1,239 @@
+package sqlrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+)
+
+// CreateGroup creates a new group in repository, owned by the user, in a single transaction
+func (r *SQLRepository) CreateGroup(ctx context.Context, group *models.Group) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("CreateGroup: Failed to get user from context")
+	}
+
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		query := "INSERT INTO groups (id, name) VALUES ($1, $2)"
+		if _, err := r.conn(ctx).ExecContext(ctx, query, group.ID, group.Name); err != nil {
+			return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+		}
+
+		query = "INSERT INTO group_members (group_id, user_id, role) VALUES ($1, $2, $3)"
+		if _, err := r.conn(ctx).ExecContext(ctx, query, group.ID, user.ID, string(models.GroupRoleOwner)); err != nil {
+			return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("CreateGroup: %w", err)
+	}
+
+	return nil
+}
+
+// GetGroups returns the groups the user is a member of, sorted by name, with the role of the user
+func (r *SQLRepository) GetGroups(ctx context.Context) (*models.Groups, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT g.id, g.name, m.role FROM groups g INNER JOIN group_members m ON m.group_id = g.id WHERE m.user_id = $1 ORDER BY g.name"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("GetGroups: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("GetGroups: Failed to get user from context")
+	}
+
+	rows, err := stmt.QueryContext(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("GetGroups: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	groups := make(models.Groups, 0)
+	for rows.Next() {
+		group := new(models.Group)
+		if err := rows.Scan(&group.ID, &group.Name, &group.Role); err != nil {
+			return nil, fmt.Errorf("GetGroups: failed to scan SQL row. %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetGroups: rows failed. %w", err)
+	}
+
+	return &groups, nil
+}
+
+// FindGroupByID returns the group of the user matching specified ID, with the role of the user, or nil
+func (r *SQLRepository) FindGroupByID(ctx context.Context, id models.ID) (*models.Group, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT g.id, g.name, m.role FROM groups g INNER JOIN group_members m ON m.group_id = g.id WHERE g.id = $1 AND m.user_id = $2"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindGroupByID: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindGroupByID: Failed to get user from context")
+	}
+
+	var group models.Group
+	err = stmt.QueryRowContext(ctx, id, user.ID).Scan(&group.ID, &group.Name, &group.Role)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("FindGroupByID: failed to query row for query %s. %w", query, err)
+	default:
+		return &group, nil
+	}
+}
+
+// UpdateGroup renames group in repository
+func (r *SQLRepository) UpdateGroup(ctx context.Context, group *models.Group) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "UPDATE groups SET name = $1 WHERE id = $2"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("UpdateGroup: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, group.Name, group.ID)
+	if err != nil {
+		return fmt.Errorf("UpdateGroup: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// DeleteGroup deletes group in repository, along with its memberships.
+// Locations of the group are given back to the users who created them.
+func (r *SQLRepository) DeleteGroup(ctx context.Context, id models.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "DELETE FROM groups WHERE id = $1"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("DeleteGroup: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteGroup: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// GetGroupMembers returns the members of a group, sorted by user
+func (r *SQLRepository) GetGroupMembers(ctx context.Context, id models.ID) (*models.GroupMembers, error) {
+	query := "SELECT user_id, role FROM group_members WHERE group_id = $1 ORDER BY user_id"
+	return r.queryGroupMembers(ctx, "GetGroupMembers", query, id)
+}
+
+// LockGroupMembers returns the members of a group, sorted by user, and locks them until the end of
+// the transaction of ctx. Roles of members are then checked and changed without concurrent updates.
+func (r *SQLRepository) LockGroupMembers(ctx context.Context, id models.ID) (*models.GroupMembers, error) {
+	query := "SELECT user_id, role FROM group_members WHERE group_id = $1 ORDER BY user_id FOR UPDATE"
+	return r.queryGroupMembers(ctx, "LockGroupMembers", query, id)
+}
+
+func (r *SQLRepository) queryGroupMembers(ctx context.Context, name, query string, id models.ID) (*models.GroupMembers, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to prepare context for query %s. %w", name, query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query context for query %s. %w", name, query, err)
+	}
+	defer rows.Close()
+
+	members := make(models.GroupMembers, 0)
+	for rows.Next() {
+		member := new(models.GroupMember)
+		if err := rows.Scan(&member.User, &member.Role); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan SQL row. %w", name, err)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows failed. %w", name, err)
+	}
+
+	return &members, nil
+}
+
+// SetGroupMember adds a member to a group, or changes the role of a member
+func (r *SQLRepository) SetGroupMember(ctx context.Context, id models.ID, member *models.GroupMember) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "INSERT INTO group_members (group_id, user_id, role) VALUES ($1, $2, $3) " +
+		"ON CONFLICT (group_id, user_id) DO UPDATE SET role = EXCLUDED.role"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("SetGroupMember: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id, member.User, string(member.Role))
+	switch {
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("SetGroupMember: group %s not found. %w", id, usecases.ErrGroupNotFound)
+	case err != nil:
+		return fmt.Errorf("SetGroupMember: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// DeleteGroupMember removes a member from a group
+func (r *SQLRepository) DeleteGroupMember(ctx context.Context, id models.ID, user models.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "DELETE FROM group_members WHERE group_id = $1 AND user_id = $2"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("DeleteGroupMember: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id, user)
+	if err != nil {
+		return fmt.Errorf("DeleteGroupMember: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package sqlrepository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateGroupWithMemberError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	group := models.NewGroup(models.NewID(), "Family")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO groups (id, name) VALUES ($1, $2)").
+		WithArgs(group.ID, group.Name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO group_members (group_id, user_id, role) VALUES ($1, $2, $3)").
+		WithArgs(group.ID, user.ID, "owner").
+		WillReturnError(errors.New("failed"))
+	mock.ExpectRollback()
+
+	err := repo.CreateGroup(ctx, group)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateGroupWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	group := models.NewGroup(models.NewID(), "Family")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO groups (id, name) VALUES ($1, $2)").
+		WithArgs(group.ID, group.Name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO group_members (group_id, user_id, role) VALUES ($1, $2, $3)").
+		WithArgs(group.ID, user.ID, "owner").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.CreateGroup(ctx, group)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetGroupsWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	group1 := models.NewGroup(models.NewID(), "Family")
+	group1.Role = models.GroupRoleOwner
+	group2 := models.NewGroup(models.NewID(), "Friends")
+	group2.Role = models.GroupRoleMember
+
+	rows := sqlmock.NewRows([]string{"id", "name", "role"}).
+		AddRow(group1.ID, group1.Name, "owner").
+		AddRow(group2.ID, group2.Name, "member")
+
+	query := "SELECT g.id, g.name, m.role FROM groups g INNER JOIN group_members m ON m.group_id = g.id WHERE m.user_id = $1 ORDER BY g.name"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
+
+	groups, err := repo.GetGroups(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Groups{group1, group2}, *groups)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindGroupByIDWithNoResult(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	id := models.NewID()
+
+	query := "SELECT g.id, g.name, m.role FROM groups g INNER JOIN group_members m ON m.group_id = g.id WHERE g.id = $1 AND m.user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+
+	group, err := repo.FindGroupByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Nil(t, group)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindGroupByIDWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	group := models.NewGroup(models.NewID(), "Family")
+	group.Role = models.GroupRoleMember
+
+	rows := sqlmock.NewRows([]string{"id", "name", "role"}).AddRow(group.ID, group.Name, "member")
+
+	query := "SELECT g.id, g.name, m.role FROM groups g INNER JOIN group_members m ON m.group_id = g.id WHERE g.id = $1 AND m.user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(group.ID, user.ID).WillReturnRows(rows)
+
+	returned, err := repo.FindGroupByID(ctx, group.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, group, returned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateGroupWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	group := models.NewGroup(models.NewID(), "Family")
+
+	prep := mock.ExpectPrepare("UPDATE groups SET name = $1 WHERE id = $2")
+	prep.ExpectExec().WithArgs(group.Name, group.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.UpdateGroup(newTestContext(), group)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteGroupWithExecError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+
+	prep := mock.ExpectPrepare("DELETE FROM groups WHERE id = $1")
+	prep.ExpectExec().WithArgs(id).WillReturnError(errors.New("failed"))
+
+	err := repo.DeleteGroup(newTestContext(), id)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetGroupMembersWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	member1 := models.NewGroupMember(models.NewID(), models.GroupRoleOwner)
+	member2 := models.NewGroupMember(models.NewID(), models.GroupRoleMember)
+
+	rows := sqlmock.NewRows([]string{"user_id", "role"}).
+		AddRow(member1.User, "owner").
+		AddRow(member2.User, "member")
+
+	prep := mock.ExpectPrepare("SELECT user_id, role FROM group_members WHERE group_id = $1 ORDER BY user_id")
+	prep.ExpectQuery().WithArgs(id).WillReturnRows(rows)
+
+	members, err := repo.GetGroupMembers(newTestContext(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.GroupMembers{member1, member2}, *members)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockGroupMembersWithinTx(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	member := models.NewGroupMember(models.NewID(), models.GroupRoleOwner)
+
+	rows := sqlmock.NewRows([]string{"user_id", "role"}).
+		AddRow(member.User, "owner")
+
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare("SELECT user_id, role FROM group_members WHERE group_id = $1 ORDER BY user_id FOR UPDATE")
+	prep.ExpectQuery().WithArgs(id).WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	var members *models.GroupMembers
+	err := repo.WithinTx(newTestContext(), func(ctx context.Context) (err error) {
+		members, err = repo.LockGroupMembers(ctx, id)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.GroupMembers{member}, *members)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetGroupMemberWithGroupNotFound(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	member := models.NewGroupMember(models.NewID(), models.GroupRoleMember)
+
+	query := "INSERT INTO group_members (group_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT (group_id, user_id) DO UPDATE SET role = EXCLUDED.role"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, member.User, "member").WillReturnError(&pq.Error{Code: "23503"})
+
+	err := repo.SetGroupMember(newTestContext(), id, member)
+	assert.True(t, errors.Is(err, usecases.ErrGroupNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetGroupMemberWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	member := models.NewGroupMember(models.NewID(), models.GroupRoleOwner)
+
+	query := "INSERT INTO group_members (group_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT (group_id, user_id) DO UPDATE SET role = EXCLUDED.role"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, member.User, "owner").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetGroupMember(newTestContext(), id, member)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteGroupMemberWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id, user := models.NewID(), models.NewID()
+
+	prep := mock.ExpectPrepare("DELETE FROM group_members WHERE group_id = $1 AND user_id = $2")
+	prep.ExpectExec().WithArgs(id, user).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.DeleteGroupMember(newTestContext(), id, user)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/lib/pq"
 )
 
 // CreateCategory creates a new category in repository
@@ -14,34 +17,42 @@ func (r *SQLRepository) CreateCategory(ctx context.Context, cat *models.Category
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "INSERT INTO categories (id, name) VALUES ($1, $2)"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "INSERT INTO categories (id, name, parent_id, user_id) VALUES ($1, $2, $3, $4)"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("CreateCategory: failed to prepare context for query %s. %w", query, err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, cat.ID, cat.Name)
-	if err != nil {
+	_, err = stmt.ExecContext(ctx, cat.ID, cat.Name, cat.Parent, cat.User)
+	switch {
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("CreateCategory: parent category %s not found. %w", cat.Parent, usecases.ErrParentCategoryNotFound)
+	case err != nil:
 		return fmt.Errorf("CreateCategory: failed to exec context for query %s. %w", query, err)
 	}
 
 	return nil
 }
 
-// GetCategories fetches all categories in repository
+// GetCategories fetches user categories and default categories in repository
 func (r *SQLRepository) GetCategories(ctx context.Context) (*models.Categories, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "SELECT id, name FROM categories"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE user_id = $1 OR user_id IS NULL"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("GetCategories: failed to prepare context for query %s. %w", query, err)
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx)
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("GetCategories: Failed to get user from context")
+	}
+
+	rows, err := stmt.QueryContext(ctx, user.ID)
 	if err != nil {
 		return nil, fmt.Errorf("GetCategories: failed to query context for query %s. %w", query, err)
 	}
@@ -50,7 +61,7 @@ func (r *SQLRepository) GetCategories(ctx context.Context) (*models.Categories,
 	cats := make(models.Categories, 0)
 	for rows.Next() {
 		cat := new(models.Category)
-		if err := rows.Scan(&cat.ID, &cat.Name); err != nil {
+		if err := rows.Scan(&cat.ID, &cat.Name, &cat.Parent, &cat.User); err != nil {
 			return nil, fmt.Errorf("GetCategories: failed to scan SQL row. %w", err)
 		}
 		cats = append(cats, cat)
@@ -63,20 +74,25 @@ func (r *SQLRepository) GetCategories(ctx context.Context) (*models.Categories,
 	return &cats, nil
 }
 
-// FindCategoryByID returns category matching specified ID or nil
+// FindCategoryByID returns user or default category matching specified ID or nil
 func (r *SQLRepository) FindCategoryByID(ctx context.Context, id models.ID) (*models.Category, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "SELECT id, name FROM categories WHERE id = $1"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL)"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("FindCategoryByID: failed to prepare context for query %s. %w", query, err)
 	}
 	defer stmt.Close()
 
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindCategoryByID: Failed to get user from context")
+	}
+
 	var cat models.Category
-	err = stmt.QueryRowContext(ctx, id).Scan(&cat.ID, &cat.Name)
+	err = stmt.QueryRowContext(ctx, id, user.ID).Scan(&cat.ID, &cat.Name, &cat.Parent, &cat.User)
 	switch {
 	case err == sql.ErrNoRows:
 		return nil, nil
@@ -87,20 +103,57 @@ func (r *SQLRepository) FindCategoryByID(ctx context.Context, id models.ID) (*mo
 	}
 }
 
-// FindCategoryByName returns category matching specified name or nil
+// FindVisibleCategoryByID returns user or default category matching specified ID, or category shared with
+// the user or holding locations visible to them, or nil. Locations may be filtered by any of them.
+func (r *SQLRepository) FindVisibleCategoryByID(ctx context.Context, id models.ID) (*models.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL " +
+		"OR id IN (SELECT category_id FROM category_shares WHERE user_id = $2) " +
+		"OR id IN (SELECT category_id FROM locations WHERE " + visibleLocations("locations", "$2") + "))"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindVisibleCategoryByID: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindVisibleCategoryByID: Failed to get user from context")
+	}
+
+	var cat models.Category
+	err = stmt.QueryRowContext(ctx, id, user.ID).Scan(&cat.ID, &cat.Name, &cat.Parent, &cat.User)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("FindVisibleCategoryByID: failed to query row for query %s. %w", query, err)
+	default:
+		return &cat, nil
+	}
+}
+
+// FindCategoryByName returns user or default category matching specified name or nil
 func (r *SQLRepository) FindCategoryByName(ctx context.Context, name string) (*models.Category, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "SELECT id, name FROM categories WHERE name = $1"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE name = $1 AND (user_id = $2 OR user_id IS NULL)"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("FindCategoryByName: failed to prepare context for query %s. %w", query, err)
 	}
 	defer stmt.Close()
 
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindCategoryByName: Failed to get user from context")
+	}
+
 	var cat models.Category
-	err = stmt.QueryRowContext(ctx, name).Scan(&cat.ID, &cat.Name)
+	err = stmt.QueryRowContext(ctx, name, user.ID).Scan(&cat.ID, &cat.Name, &cat.Parent, &cat.User)
 	switch {
 	case err == sql.ErrNoRows:
 		return nil, nil
@@ -111,41 +164,202 @@ func (r *SQLRepository) FindCategoryByName(ctx context.Context, name string) (*m
 	}
 }
 
-// UpdateCategory updates category in repository
+// UpdateCategory updates user category in repository. Default categories are never updated.
 func (r *SQLRepository) UpdateCategory(ctx context.Context, cat *models.Category) error {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "UPDATE categories SET name = $1 WHERE id = $2"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "UPDATE categories SET name = $1, parent_id = $2 WHERE id = $3 AND user_id = $4"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("UpdateCategory: failed to prepare context for query %s. %w", query, err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, cat.Name, cat.ID)
-	if err != nil {
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("UpdateCategory: Failed to get user from context")
+	}
+
+	_, err = stmt.ExecContext(ctx, cat.Name, cat.Parent, cat.ID, user.ID)
+	switch {
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("UpdateCategory: parent category %s not found. %w", cat.Parent, usecases.ErrParentCategoryNotFound)
+	case err != nil:
 		return fmt.Errorf("UpdateCategory: failed to exec context for query %s. %w", query, err)
 	}
 
 	return nil
 }
 
-// DeleteCategory deletes category in repository
-func (r *SQLRepository) DeleteCategory(ctx context.Context, id models.ID) error {
+// DeleteCategory deletes user category in repository. Default categories are never deleted.
+// Locations of the category are reassigned or deleted in the same transaction, as requested.
+// ErrCategoryInUse is returned when category still has locations.
+func (r *SQLRepository) DeleteCategory(ctx context.Context, id models.ID, deletion models.CategoryDeletion) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("DeleteCategory: Failed to get user from context")
+	}
+
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		switch {
+		case deletion.ReassignTo != nil:
+			query := "UPDATE locations SET category_id = $1 WHERE category_id = $2 AND user_id = $3"
+			if _, err := r.conn(ctx).ExecContext(ctx, query, *deletion.ReassignTo, id, user.ID); err != nil {
+				return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+			}
+		case deletion.Cascade:
+			query := "DELETE FROM locations WHERE category_id = $1 AND user_id = $2"
+			if _, err := r.conn(ctx).ExecContext(ctx, query, id, user.ID); err != nil {
+				return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+			}
+		}
+
+		query := "DELETE FROM categories WHERE id = $1 AND user_id = $2"
+		stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to prepare context for query %s. %w", query, err)
+		}
+		defer stmt.Close()
+
+		_, err = stmt.ExecContext(ctx, id, user.ID)
+		switch {
+		case isForeignKeyViolation(err):
+			return fmt.Errorf("category %s is still referenced. %w", id, usecases.ErrCategoryInUse)
+		case err != nil:
+			return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteCategory: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateDefaultCategory updates default category in repository. User categories are never updated.
+func (r *SQLRepository) UpdateDefaultCategory(ctx context.Context, cat *models.Category) error {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "DELETE FROM categories WHERE id = $1"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "UPDATE categories SET name = $1, parent_id = $2 WHERE id = $3 AND user_id IS NULL"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
-		return fmt.Errorf("DeleteCategory: failed to prepare context for query %s. %w", query, err)
+		return fmt.Errorf("UpdateDefaultCategory: failed to prepare context for query %s. %w", query, err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, id)
+	_, err = stmt.ExecContext(ctx, cat.Name, cat.Parent, cat.ID)
+	switch {
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("UpdateDefaultCategory: parent category %s not found. %w", cat.Parent, usecases.ErrParentCategoryNotFound)
+	case err != nil:
+		return fmt.Errorf("UpdateDefaultCategory: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// DeleteDefaultCategory deletes default category in repository. User categories are never deleted.
+// Locations of all users in the category are reassigned in the same transaction, when requested.
+// ErrCategoryInUse is returned when category still has locations.
+func (r *SQLRepository) DeleteDefaultCategory(ctx context.Context, id models.ID, reassignTo *models.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		if reassignTo != nil {
+			query := "UPDATE locations SET category_id = $1 WHERE category_id = $2"
+			if _, err := r.conn(ctx).ExecContext(ctx, query, *reassignTo, id); err != nil {
+				return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+			}
+		}
+
+		query := "DELETE FROM categories WHERE id = $1 AND user_id IS NULL"
+		stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to prepare context for query %s. %w", query, err)
+		}
+		defer stmt.Close()
+
+		_, err = stmt.ExecContext(ctx, id)
+		switch {
+		case isForeignKeyViolation(err):
+			return fmt.Errorf("category %s is still referenced. %w", id, usecases.ErrCategoryInUse)
+		case err != nil:
+			return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("DeleteCategory: failed to exec context for query %s. %w", query, err)
+		return fmt.Errorf("DeleteDefaultCategory: %w", err)
+	}
+
+	return nil
+}
+
+// CountLocationsByCategory returns how many locations visible to the user belong to specified category
+func (r *SQLRepository) CountLocationsByCategory(ctx context.Context, id models.ID) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT count(*) FROM locations WHERE category_id = $1 AND " + visibleLocations("locations", "$2")
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("CountLocationsByCategory: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return 0, errors.New("CountLocationsByCategory: Failed to get user from context")
+	}
+
+	var count int
+	if err := stmt.QueryRowContext(ctx, id, user.ID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("CountLocationsByCategory: failed to query row for query %s. %w", query, err)
+	}
+
+	return count, nil
+}
+
+// MergeCategory merges user category into target category in repository, in a single transaction.
+// Locations and child categories of the merged category move to the target category, then it is deleted.
+func (r *SQLRepository) MergeCategory(ctx context.Context, id models.ID, target models.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("MergeCategory: Failed to get user from context")
+	}
+
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		query := "UPDATE locations SET category_id = $1 WHERE category_id = $2 AND user_id = $3"
+		if _, err := r.conn(ctx).ExecContext(ctx, query, target, id, user.ID); err != nil {
+			return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+		}
+
+		query = "UPDATE categories SET parent_id = $1 WHERE parent_id = $2 AND user_id = $3"
+		if _, err := r.conn(ctx).ExecContext(ctx, query, target, id, user.ID); err != nil {
+			return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+		}
+
+		query = "DELETE FROM categories WHERE id = $1 AND user_id = $2"
+		if _, err := r.conn(ctx).ExecContext(ctx, query, id, user.ID); err != nil {
+			return fmt.Errorf("failed to exec context for query %s. %w", query, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("MergeCategory: %w", err)
 	}
 
 	return nil
@@ -156,28 +370,38 @@ func (r *SQLRepository) CreateLocation(ctx context.Context, loc *models.Location
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "INSERT INTO locations (id, name, address, category_id, user_id) VALUES ($1, $2, $3, $4, $5)"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "INSERT INTO locations (id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("CreateLocation: failed to prepare context for query %s. %w", query, err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, loc.ID, loc.Name, loc.Address, loc.Category, loc.User)
-	if err != nil {
+	_, err = stmt.ExecContext(ctx, loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group)
+	switch {
+	case isUniqueViolation(err):
+		return fmt.Errorf("CreateLocation: location %s already exists. %w", loc.Name, usecases.ErrLocationAlreadyExists)
+	case isConstraintViolation(err, "FK_locations_group"):
+		return fmt.Errorf("CreateLocation: group %s not found. %w", loc.Group, usecases.ErrGroupNotFound)
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("CreateLocation: category %s not found. %w", loc.Category, usecases.ErrCategoryNotFound)
+	case err != nil:
 		return fmt.Errorf("CreateLocation: failed to exec context for query %s. %w", query, err)
 	}
 
 	return nil
 }
 
-// GetLocations returns all user locations stored in the repository
+// GetLocations returns all user locations stored in the repository, along with locations of the groups of the user
+// and locations shared with them.
+// Locations are annotated with the role of the user.
 func (r *SQLRepository) GetLocations(ctx context.Context) (*models.Locations, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE user_id = $1"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$1") +
+		" FROM locations WHERE " + visibleLocations("locations", "$1")
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("GetLocations: failed to prepare context for query %s. %w", query, err)
 	}
@@ -197,7 +421,7 @@ func (r *SQLRepository) GetLocations(ctx context.Context) (*models.Locations, er
 	locs := make(models.Locations, 0)
 	for rows.Next() {
 		loc := new(models.Location)
-		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Category, &loc.User); err != nil {
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role); err != nil {
 			return nil, fmt.Errorf("GetLocations: failed to scan SQL row. %w", err)
 		}
 		locs = append(locs, loc)
@@ -210,13 +434,16 @@ func (r *SQLRepository) GetLocations(ctx context.Context) (*models.Locations, er
 	return &locs, nil
 }
 
-// FindLocationByID returns the user location that matches the requested ID or nil
+// FindLocationByID returns the user location, or the location of a group of the user or shared with them,
+// that matches the requested ID or nil.
+// Location is annotated with the role of the user.
 func (r *SQLRepository) FindLocationByID(ctx context.Context, id models.ID) (*models.Location, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE id = $1 AND user_id = $2"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$2") +
+		" FROM locations WHERE id = $1 AND " + visibleLocations("locations", "$2")
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("FindLocationByID: failed to prepare context for query %s. %w", query, err)
 	}
@@ -228,7 +455,7 @@ func (r *SQLRepository) FindLocationByID(ctx context.Context, id models.ID) (*mo
 	}
 
 	var loc models.Location
-	err = stmt.QueryRowContext(ctx, id, user.ID).Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Category, &loc.User)
+	err = stmt.QueryRowContext(ctx, id, user.ID).Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role)
 	switch {
 	case err == sql.ErrNoRows:
 		return nil, nil
@@ -244,8 +471,8 @@ func (r *SQLRepository) FindLocationByName(ctx context.Context, name string) (*m
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE name = $1 AND user_id = $2"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, " + locationTags("locations") + " FROM locations WHERE name = $1 AND user_id = $2"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("FindLocationByName: failed to prepare context for query %s. %w", query, err)
 	}
@@ -257,7 +484,7 @@ func (r *SQLRepository) FindLocationByName(ctx context.Context, name string) (*m
 	}
 
 	var loc models.Location
-	err = stmt.QueryRowContext(ctx, name, user.ID).Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Category, &loc.User)
+	err = stmt.QueryRowContext(ctx, name, user.ID).Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, pq.Array(&loc.Tags))
 	switch {
 	case err == sql.ErrNoRows:
 		return nil, nil
@@ -268,13 +495,22 @@ func (r *SQLRepository) FindLocationByName(ctx context.Context, name string) (*m
 	}
 }
 
-// FindLocationsByCategory returns all user locations filtered by specified category
-func (r *SQLRepository) FindLocationsByCategory(ctx context.Context, cat *models.Category) (*models.Locations, error) {
+// FindLocationsByCategory returns all locations visible to the user filtered by specified category. Locations of
+// all descendant categories are included when descendants is true, walking the category tree recursively.
+// Locations are annotated with the role of the user.
+func (r *SQLRepository) FindLocationsByCategory(ctx context.Context, cat *models.Category, descendants bool) (*models.Locations, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE category_id = $1 AND user_id = $2"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$2") +
+		" FROM locations WHERE category_id = $1 AND " + visibleLocations("locations", "$2")
+	if descendants {
+		// UNION rather than UNION ALL stops the walk on cycles
+		query = "WITH RECURSIVE tree (id) AS (SELECT id FROM categories WHERE id = $1 UNION SELECT c.id FROM categories c INNER JOIN tree t ON c.parent_id = t.id) " +
+			"SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$2") +
+			" FROM locations WHERE category_id IN (SELECT id FROM tree) AND " + visibleLocations("locations", "$2")
+	}
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("FindLocationsByCategory: failed to prepare context for query %s. %w", query, err)
 	}
@@ -294,7 +530,7 @@ func (r *SQLRepository) FindLocationsByCategory(ctx context.Context, cat *models
 	locs := make(models.Locations, 0)
 	for rows.Next() {
 		loc := new(models.Location)
-		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Category, &loc.User); err != nil {
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role); err != nil {
 			return nil, fmt.Errorf("FindLocationsByCategory: failed to scan SQL row. %w", err)
 		}
 		locs = append(locs, loc)
@@ -307,33 +543,174 @@ func (r *SQLRepository) FindLocationsByCategory(ctx context.Context, cat *models
 	return &locs, nil
 }
 
+// FindExpandedLocations returns locations visible to the user, filtered by category unless cat is nil,
+// with their category embedded. Categories are fetched by the same query.
+// Locations are annotated with the role of the user.
+func (r *SQLRepository) FindExpandedLocations(ctx context.Context, cat *models.Category) (*models.Locations, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindExpandedLocations: Failed to get user from context")
+	}
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, " + locationTags("l") + ", " + locationRole("l", "$1") +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE " + visibleLocations("l", "$1")
+	args := []interface{}{user.ID}
+	if cat != nil {
+		query += " AND l.category_id = $2"
+		args = append(args, cat.ID)
+	}
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindExpandedLocations: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("FindExpandedLocations: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	locs := make(models.Locations, 0)
+	for rows.Next() {
+		loc := &models.Location{ExpandedCategory: new(models.Category)}
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role, &loc.ExpandedCategory.ID, &loc.ExpandedCategory.Name, &loc.ExpandedCategory.Parent, &loc.ExpandedCategory.User); err != nil {
+			return nil, fmt.Errorf("FindExpandedLocations: failed to scan SQL row. %w", err)
+		}
+		locs = append(locs, loc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindExpandedLocations: rows failed. %w", err)
+	}
+
+	return &locs, nil
+}
+
+// FindExpandedLocationByID returns the location visible to the user that matches the requested ID,
+// with its category embedded, or nil. Location is annotated with the role of the user.
+func (r *SQLRepository) FindExpandedLocationByID(ctx context.Context, id models.ID) (*models.Location, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, " + locationTags("l") + ", " + locationRole("l", "$2") +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE l.id = $1 AND " + visibleLocations("l", "$2")
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindExpandedLocationByID: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindExpandedLocationByID: Failed to get user from context")
+	}
+
+	loc := models.Location{ExpandedCategory: new(models.Category)}
+	err = stmt.QueryRowContext(ctx, id, user.ID).Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role, &loc.ExpandedCategory.ID, &loc.ExpandedCategory.Name, &loc.ExpandedCategory.Parent, &loc.ExpandedCategory.User)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("FindExpandedLocationByID: failed to query row for query %s. %w", query, err)
+	default:
+		return &loc, nil
+	}
+}
+
+// StreamLocations iterates over locations visible to the user, optionally filtered by category, and calls fn
+// for each of them as soon as it is read from the SQL cursor. Iteration stops on the first error returned by fn.
+// Locations are annotated with the role of the user.
+// The location passed to fn is reused between rows and must not be retained.
+// The query timeout only applies until the query starts, since reading rows is paced by fn.
+func (r *SQLRepository) StreamLocations(ctx context.Context, cat *models.Category, fn func(*models.Location) error) error {
+	// Canceling the context closes the rows, so the deadline is dropped once the query has started
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	timeout := time.AfterFunc(r.Config.QueryTimeout, cancel)
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("StreamLocations: Failed to get user from context")
+	}
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$1") +
+		" FROM locations WHERE " + visibleLocations("locations", "$1")
+	args := []interface{}{user.ID}
+	if cat != nil {
+		query += " AND category_id = $2"
+		args = append(args, cat.ID)
+	}
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("StreamLocations: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("StreamLocations: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+	if !timeout.Stop() {
+		return fmt.Errorf("StreamLocations: query timed out for query %s. %w", query, context.DeadlineExceeded)
+	}
+
+	var loc models.Location
+	for rows.Next() {
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role); err != nil {
+			return fmt.Errorf("StreamLocations: failed to scan SQL row. %w", err)
+		}
+		if err := fn(&loc); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("StreamLocations: rows failed. %w", err)
+	}
+
+	return nil
+}
+
 // UpdateLocation updates specified location in repository
 func (r *SQLRepository) UpdateLocation(ctx context.Context, loc *models.Location) error {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "UPDATE locations SET name = $1, address = $2, category_id = $3 WHERE id = $4 AND user_id = $5"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "UPDATE locations SET name = $1, address = $2, street = $3, house_number = $4, postal_code = $5, locality = $6, region = $7, country_code = $8, " +
+		"latitude = $9, longitude = $10, category_id = $11 WHERE id = $12 AND user_id = $13"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("UpdateLocation: failed to prepare context for query %s. %w", query, err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, loc.Name, loc.Address, loc.Category, loc.ID, loc.User)
-	if err != nil {
+	_, err = stmt.ExecContext(ctx, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.ID, loc.User)
+	switch {
+	case isUniqueViolation(err):
+		return fmt.Errorf("UpdateLocation: location %s already exists. %w", loc.Name, usecases.ErrLocationAlreadyExists)
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("UpdateLocation: category %s not found. %w", loc.Category, usecases.ErrCategoryNotFound)
+	case err != nil:
 		return fmt.Errorf("UpdateLocation: failed to exec context for query %s. %w", query, err)
 	}
 
 	return nil
 }
 
-// DeleteLocation deletes location in repository
+// DeleteLocation deletes location in repository, when owned by the user, by a group they own, or shared with them as owner
 func (r *SQLRepository) DeleteLocation(ctx context.Context, id models.ID) error {
 	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
 	defer cancel()
 
-	query := "DELETE FROM locations WHERE id = $1 AND user_id = $2"
-	stmt, err := r.db.PrepareContext(ctx, query)
+	query := "DELETE FROM locations WHERE id = $1 AND " + locationRole("locations", "$2") + " = 'owner'"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("DeleteLocation: failed to prepare context for query %s. %w", query, err)
 	}
@@ -351,3 +728,49 @@ func (r *SQLRepository) DeleteLocation(ctx context.Context, id models.ID) error
 
 	return nil
 }
+
+// MoveLocations moves user locations to specified category in repository, and returns IDs of moved locations.
+// Categories belong to the user who created each location, who must still be allowed to edit it: locations
+// of groups they left are skipped, like locations that cannot be found.
+func (r *SQLRepository) MoveLocations(ctx context.Context, ids []models.ID, cat *models.Category) ([]models.ID, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "UPDATE locations SET category_id = $1 WHERE user_id = $2 AND id = ANY($3::uuid[]) AND " + locationRole("locations", "$2") + " IN ('owner', 'editor') RETURNING id"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("MoveLocations: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("MoveLocations: Failed to get user from context")
+	}
+
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+
+	rows, err := stmt.QueryContext(ctx, cat.ID, user.ID, pq.Array(values))
+	if err != nil {
+		return nil, fmt.Errorf("MoveLocations: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	moved := make([]models.ID, 0, len(ids))
+	for rows.Next() {
+		var id models.ID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("MoveLocations: failed to scan SQL row. %w", err)
+		}
+		moved = append(moved, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("MoveLocations: rows failed. %w", err)
+	}
+
+	return moved, nil
+}
@@ -3,9 +3,12 @@ package sqlrepository
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,8 +17,9 @@ func TestCreateCategoryWithPrepareError(t *testing.T) {
 	defer repo.Close()
 
 	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
 
-	query := "INSERT INTO categories (id, name) VALUES ($1, $2)"
+	query := "INSERT INTO categories (id, name, parent_id, user_id) VALUES ($1, $2, $3, $4)"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	err := repo.CreateCategory(newTestContext(), cat)
@@ -28,10 +32,11 @@ func TestCreateCategoryWithExecError(t *testing.T) {
 	defer repo.Close()
 
 	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
 
-	query := "INSERT INTO categories (id, name) VALUES ($1, $2)"
+	query := "INSERT INTO categories (id, name, parent_id, user_id) VALUES ($1, $2, $3, $4)"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(cat.ID, cat.Name).WillReturnError(errors.New("failed"))
+	prep.ExpectExec().WithArgs(cat.ID, cat.Name, cat.Parent, cat.User).WillReturnError(errors.New("failed"))
 
 	err := repo.CreateCategory(newTestContext(), cat)
 	assert.Error(t, err)
@@ -43,11 +48,12 @@ func TestCreateCategoryWithSuccess(t *testing.T) {
 	defer repo.Close()
 
 	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
 
-	query := "INSERT INTO categories (id, name) VALUES ($1, $2)"
+	query := "INSERT INTO categories (id, name, parent_id, user_id) VALUES ($1, $2, $3, $4)"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(cat.ID, cat.Name).
+		WithArgs(cat.ID, cat.Name, cat.Parent, cat.User).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err := repo.CreateCategory(newTestContext(), cat)
@@ -59,7 +65,7 @@ func TestGetCategoriesWithPrepareError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	query := "SELECT id, name FROM categories"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE user_id = $1 OR user_id IS NULL"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	_, err := repo.GetCategories(newTestContext())
@@ -71,11 +77,14 @@ func TestGetCategoriesWithQueryError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	query := "SELECT id, name FROM categories"
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE user_id = $1 OR user_id IS NULL"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WillReturnError(errors.New("failed"))
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnError(errors.New("failed"))
 
-	_, err := repo.GetCategories(newTestContext())
+	_, err := repo.GetCategories(ctx)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -84,17 +93,20 @@ func TestGetCategoriesWithRowError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	cat := models.NewCategory(models.NewID(), "Test Category 1")
 
-	rows := sqlmock.NewRows([]string{"id", "name"}).
+	rows := sqlmock.NewRows([]string{"id", "name", "parent_id", "user_id"}).
 		RowError(0, errors.New("failed")).
-		AddRow(cat.ID, cat.Name)
+		AddRow(cat.ID, cat.Name, nil, nil)
 
-	query := "SELECT id, name FROM categories"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE user_id = $1 OR user_id IS NULL"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WillReturnRows(rows)
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
 
-	_, err := repo.GetCategories(newTestContext())
+	_, err := repo.GetCategories(ctx)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -103,18 +115,22 @@ func TestGetCategoriesWithSuccess(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	cat1 := models.NewCategory(models.NewID(), "Test Category 1")
 	cat2 := models.NewCategory(models.NewID(), "Test Category 2")
+	cat2.SetUser(user.ID)
 
-	rows := sqlmock.NewRows([]string{"id", "name"}).
-		AddRow(cat1.ID, cat1.Name).
-		AddRow(cat2.ID, cat2.Name)
+	rows := sqlmock.NewRows([]string{"id", "name", "parent_id", "user_id"}).
+		AddRow(cat1.ID, cat1.Name, nil, nil).
+		AddRow(cat2.ID, cat2.Name, nil, user.ID)
 
-	query := "SELECT id, name FROM categories"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE user_id = $1 OR user_id IS NULL"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WillReturnRows(rows)
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
 
-	cats, err := repo.GetCategories(newTestContext())
+	cats, err := repo.GetCategories(ctx)
 	assert.NoError(t, err)
 	assert.ElementsMatch(t, *cats, models.Categories{cat1, cat2})
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -126,7 +142,7 @@ func TestFindCategoryByIDWithPrepareError(t *testing.T) {
 
 	id := models.NewID()
 
-	query := "SELECT id, name FROM categories WHERE id = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL)"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	_, err := repo.FindCategoryByID(newTestContext(), id)
@@ -138,13 +154,16 @@ func TestFindCategoryByIDWithQueryError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	id := models.NewID()
 
-	query := "SELECT id, name FROM categories WHERE id = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL)"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WithArgs(id).WillReturnError(errors.New("failed"))
+	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnError(errors.New("failed"))
 
-	_, err := repo.FindCategoryByID(newTestContext(), id)
+	_, err := repo.FindCategoryByID(ctx, id)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -153,17 +172,20 @@ func TestFindCategoryByIDWithRowError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	cat := models.NewCategory(models.NewID(), "Test Category 1")
 
-	rows := sqlmock.NewRows([]string{"id", "name"}).
+	rows := sqlmock.NewRows([]string{"id", "name", "parent_id", "user_id"}).
 		RowError(0, errors.New("failed")).
-		AddRow(cat.ID, cat.Name)
+		AddRow(cat.ID, cat.Name, nil, nil)
 
-	query := "SELECT id, name FROM categories WHERE id = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL)"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WithArgs(cat.ID).WillReturnRows(rows)
+	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(rows)
 
-	_, err := repo.FindCategoryByID(newTestContext(), cat.ID)
+	_, err := repo.FindCategoryByID(ctx, cat.ID)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -172,13 +194,16 @@ func TestFindCategoryByIDWithNoResult(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	id := models.NewID()
 
-	query := "SELECT id, name FROM categories WHERE id = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL)"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WithArgs(id).WillReturnRows(sqlmock.NewRows(nil))
+	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnRows(sqlmock.NewRows(nil))
 
-	returnedCat, err := repo.FindCategoryByID(newTestContext(), id)
+	returnedCat, err := repo.FindCategoryByID(ctx, id)
 	assert.NoError(t, err)
 	assert.Nil(t, returnedCat)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -188,16 +213,87 @@ func TestFindCategoryByIDWithSuccess(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category 1")
+	cat.SetUser(user.ID)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "parent_id", "user_id"}).
+		AddRow(cat.ID, cat.Name, nil, user.ID)
+
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(rows)
+
+	returnedCat, err := repo.FindCategoryByID(ctx, cat.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, cat, returnedCat)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindVisibleCategoryByIDWithQueryError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL " +
+		"OR id IN (SELECT category_id FROM category_shares WHERE user_id = $2) " +
+		"OR id IN (SELECT category_id FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnError(errors.New("failed"))
+
+	_, err := repo.FindVisibleCategoryByID(ctx, id)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindVisibleCategoryByIDWithNoResult(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL " +
+		"OR id IN (SELECT category_id FROM category_shares WHERE user_id = $2) " +
+		"OR id IN (SELECT category_id FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnRows(sqlmock.NewRows(nil))
+
+	returnedCat, err := repo.FindVisibleCategoryByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Nil(t, returnedCat)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindVisibleCategoryByIDShared(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	// Shared with the user by another user
 	cat := models.NewCategory(models.NewID(), "Test Category 1")
+	cat.SetUser(models.NewID())
 
-	rows := sqlmock.NewRows([]string{"id", "name"}).
-		AddRow(cat.ID, cat.Name)
+	rows := sqlmock.NewRows([]string{"id", "name", "parent_id", "user_id"}).
+		AddRow(cat.ID, cat.Name, nil, *cat.User)
 
-	query := "SELECT id, name FROM categories WHERE id = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE id = $1 AND (user_id = $2 OR user_id IS NULL " +
+		"OR id IN (SELECT category_id FROM category_shares WHERE user_id = $2) " +
+		"OR id IN (SELECT category_id FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))))"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WithArgs(cat.ID).WillReturnRows(rows)
+	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(rows)
 
-	returnedCat, err := repo.FindCategoryByID(newTestContext(), cat.ID)
+	returnedCat, err := repo.FindVisibleCategoryByID(ctx, cat.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, cat, returnedCat)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -207,7 +303,7 @@ func TestFindCategoryByNameWithPrepareError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	query := "SELECT id, name FROM categories WHERE name = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE name = $1 AND (user_id = $2 OR user_id IS NULL)"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	_, err := repo.FindCategoryByName(newTestContext(), "Test Category")
@@ -219,13 +315,16 @@ func TestFindCategoryByNameWithQueryError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	name := "Test Category"
 
-	query := "SELECT id, name FROM categories WHERE name = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE name = $1 AND (user_id = $2 OR user_id IS NULL)"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WithArgs(name).WillReturnError(errors.New("failed"))
+	prep.ExpectQuery().WithArgs(name, user.ID).WillReturnError(errors.New("failed"))
 
-	_, err := repo.FindCategoryByName(newTestContext(), name)
+	_, err := repo.FindCategoryByName(ctx, name)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -234,17 +333,20 @@ func TestFindCategoryByNameWithRowError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	cat := models.NewCategory(models.NewID(), "Test Category 1")
 
-	rows := sqlmock.NewRows([]string{"id", "name"}).
+	rows := sqlmock.NewRows([]string{"id", "name", "parent_id", "user_id"}).
 		RowError(0, errors.New("failed")).
-		AddRow(cat.ID, cat.Name)
+		AddRow(cat.ID, cat.Name, nil, nil)
 
-	query := "SELECT id, name FROM categories WHERE name = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE name = $1 AND (user_id = $2 OR user_id IS NULL)"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WithArgs(cat.Name).WillReturnRows(rows)
+	prep.ExpectQuery().WithArgs(cat.Name, user.ID).WillReturnRows(rows)
 
-	_, err := repo.FindCategoryByName(newTestContext(), cat.Name)
+	_, err := repo.FindCategoryByName(ctx, cat.Name)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -253,13 +355,16 @@ func TestFindCategoryByNameWithNoResult(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	name := "Test Category"
 
-	query := "SELECT id, name FROM categories WHERE name = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE name = $1 AND (user_id = $2 OR user_id IS NULL)"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WithArgs(name).WillReturnRows(sqlmock.NewRows(nil))
+	prep.ExpectQuery().WithArgs(name, user.ID).WillReturnRows(sqlmock.NewRows(nil))
 
-	returnedCat, err := repo.FindCategoryByName(newTestContext(), name)
+	returnedCat, err := repo.FindCategoryByName(ctx, name)
 	assert.NoError(t, err)
 	assert.Nil(t, returnedCat)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -269,16 +374,19 @@ func TestFindCategoryByNameWithSuccess(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	cat := models.NewCategory(models.NewID(), "Test Category 1")
 
-	rows := sqlmock.NewRows([]string{"id", "name"}).
-		AddRow(cat.ID, cat.Name)
+	rows := sqlmock.NewRows([]string{"id", "name", "parent_id", "user_id"}).
+		AddRow(cat.ID, cat.Name, nil, nil)
 
-	query := "SELECT id, name FROM categories WHERE name = $1"
+	query := "SELECT id, name, parent_id, user_id FROM categories WHERE name = $1 AND (user_id = $2 OR user_id IS NULL)"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WithArgs(cat.Name).WillReturnRows(rows)
+	prep.ExpectQuery().WithArgs(cat.Name, user.ID).WillReturnRows(rows)
 
-	returnedCat, err := repo.FindCategoryByName(newTestContext(), cat.Name)
+	returnedCat, err := repo.FindCategoryByName(ctx, cat.Name)
 	assert.NoError(t, err)
 	assert.Equal(t, cat, returnedCat)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -290,7 +398,7 @@ func TestUpdateCategoryWithPrepareError(t *testing.T) {
 
 	cat := models.NewCategory(models.NewID(), "Test Category")
 
-	query := "UPDATE categories SET name = $1 WHERE id = $2"
+	query := "UPDATE categories SET name = $1, parent_id = $2 WHERE id = $3 AND user_id = $4"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	err := repo.UpdateCategory(newTestContext(), cat)
@@ -298,198 +406,859 @@ func TestUpdateCategoryWithPrepareError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestUpdateCategoryWithExecError(t *testing.T) {
+func TestUpdateCategoryWithExecError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+
+	query := "UPDATE categories SET name = $1, parent_id = $2 WHERE id = $3 AND user_id = $4"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(cat.Name, cat.Parent, cat.ID, user.ID).WillReturnError(errors.New("failed"))
+
+	err := repo.UpdateCategory(ctx, cat)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateCategoryWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+
+	query := "UPDATE categories SET name = $1, parent_id = $2 WHERE id = $3 AND user_id = $4"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(cat.Name, cat.Parent, cat.ID, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.UpdateCategory(ctx, cat)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryWithBeginError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	mock.ExpectBegin().WillReturnError(errors.New("failed"))
+
+	err := repo.DeleteCategory(newTestContext(), models.NewID(), models.CategoryDeletion{})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryWithPrepareError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+
+	mock.ExpectBegin()
+	query := "DELETE FROM categories WHERE id = $1 AND user_id = $2"
+	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
+	mock.ExpectRollback()
+
+	err := repo.DeleteCategory(newTestContext(), id, models.CategoryDeletion{})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryWithExecError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+
+	mock.ExpectBegin()
+	query := "DELETE FROM categories WHERE id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, user.ID).WillReturnError(errors.New("failed"))
+	mock.ExpectRollback()
+
+	err := repo.DeleteCategory(ctx, id, models.CategoryDeletion{})
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, usecases.ErrCategoryInUse))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryWithForeignKeyViolation(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+
+	mock.ExpectBegin()
+	query := "DELETE FROM categories WHERE id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, user.ID).WillReturnError(&pq.Error{Code: "23503"})
+	mock.ExpectRollback()
+
+	err := repo.DeleteCategory(ctx, id, models.CategoryDeletion{})
+	assert.True(t, errors.Is(err, usecases.ErrCategoryInUse))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+
+	mock.ExpectBegin()
+	query := "DELETE FROM categories WHERE id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.DeleteCategory(ctx, id, models.CategoryDeletion{})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryWithReassignError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+	target := models.NewID()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE locations SET category_id = $1 WHERE category_id = $2 AND user_id = $3").
+		WithArgs(target, id, user.ID).
+		WillReturnError(errors.New("failed"))
+	mock.ExpectRollback()
+
+	err := repo.DeleteCategory(ctx, id, models.CategoryDeletion{ReassignTo: &target})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryWithReassignSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+	target := models.NewID()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE locations SET category_id = $1 WHERE category_id = $2 AND user_id = $3").
+		WithArgs(target, id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	prep := mock.ExpectPrepare("DELETE FROM categories WHERE id = $1 AND user_id = $2")
+	prep.ExpectExec().
+		WithArgs(id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.DeleteCategory(ctx, id, models.CategoryDeletion{ReassignTo: &target})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryWithCascadeSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM locations WHERE category_id = $1 AND user_id = $2").
+		WithArgs(id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	prep := mock.ExpectPrepare("DELETE FROM categories WHERE id = $1 AND user_id = $2")
+	prep.ExpectExec().
+		WithArgs(id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.DeleteCategory(ctx, id, models.CategoryDeletion{Cascade: true})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateDefaultCategoryWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+
+	query := "UPDATE categories SET name = $1, parent_id = $2 WHERE id = $3 AND user_id IS NULL"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(cat.Name, cat.Parent, cat.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.UpdateDefaultCategory(newTestContext(), cat)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateDefaultCategoryWithForeignKeyViolation(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetParent(models.NewID())
+
+	query := "UPDATE categories SET name = $1, parent_id = $2 WHERE id = $3 AND user_id IS NULL"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(cat.Name, cat.Parent, cat.ID).
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	err := repo.UpdateDefaultCategory(newTestContext(), cat)
+	assert.True(t, errors.Is(err, usecases.ErrParentCategoryNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDefaultCategoryWithForeignKeyViolation(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare("DELETE FROM categories WHERE id = $1 AND user_id IS NULL")
+	prep.ExpectExec().WithArgs(id).WillReturnError(&pq.Error{Code: "23503"})
+	mock.ExpectRollback()
+
+	err := repo.DeleteDefaultCategory(newTestContext(), id, nil)
+	assert.True(t, errors.Is(err, usecases.ErrCategoryInUse))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDefaultCategoryWithReassignSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	target := models.NewID()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE locations SET category_id = $1 WHERE category_id = $2").
+		WithArgs(target, id).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	prep := mock.ExpectPrepare("DELETE FROM categories WHERE id = $1 AND user_id IS NULL")
+	prep.ExpectExec().
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.DeleteDefaultCategory(newTestContext(), id, &target)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountLocationsByCategoryWithQueryError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+
+	prep := mock.ExpectPrepare("SELECT count(*) FROM locations WHERE category_id = $1 AND " +
+		"((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))")
+	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnError(errors.New("failed"))
+
+	_, err := repo.CountLocationsByCategory(ctx, id)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountLocationsByCategoryWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+
+	prep := mock.ExpectPrepare("SELECT count(*) FROM locations WHERE category_id = $1 AND " +
+		"((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))")
+	prep.ExpectQuery().
+		WithArgs(id, user.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountLocationsByCategory(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateLocationWithPrepareError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+
+	query := "INSERT INTO locations (id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)"
+	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
+
+	err := repo.CreateLocation(newTestContext(), loc)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateLocationWithExecError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+
+	query := "INSERT INTO locations (id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group).WillReturnError(errors.New("failed"))
+
+	err := repo.CreateLocation(newTestContext(), loc)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateLocationWithConstraintViolations(t *testing.T) {
+	for code, expected := range map[pq.ErrorCode]error{
+		"23505": usecases.ErrLocationAlreadyExists,
+		"23503": usecases.ErrCategoryNotFound,
+	} {
+		repo, mock := newSQLMock(t)
+
+		loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+
+		query := "INSERT INTO locations (id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)"
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group).WillReturnError(&pq.Error{Code: code})
+
+		err := repo.CreateLocation(newTestContext(), loc)
+		assert.True(t, errors.Is(err, expected), code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		repo.Close()
+	}
+}
+
+func TestCreateLocationWithGroupNotFound(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetGroup(models.NewID())
+
+	query := "INSERT INTO locations (id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, *loc.Group).WillReturnError(&pq.Error{Code: "23503", Constraint: "FK_locations_group"})
+
+	err := repo.CreateLocation(newTestContext(), loc)
+	assert.True(t, errors.Is(err, usecases.ErrGroupNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateLocationWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Components = models.AddressComponents{Street: "rue de la Poste", HouseNumber: "1", PostalCode: "75001", Locality: "Paris"}
+	loc.SetCoordinates(48.8606111, 2.3376)
+
+	query := "INSERT INTO locations (id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.CreateLocation(newTestContext(), loc)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLocationsWithPrepareError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
+	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
+
+	_, err := repo.GetLocations(newTestContext())
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLocationsWithQueryError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WillReturnError(errors.New("failed"))
+
+	_, err := repo.GetLocations(newTestContext())
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLocationsWithRowError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		RowError(0, errors.New("failed")).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WillReturnRows(rows)
+
+	_, err := repo.GetLocations(ctx)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLocationsWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	loc1 := models.NewLocation(models.NewID(), "Test Location 1", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	loc1.Role = models.ShareRoleOwner
+	// Shared by another user
+	loc2 := models.NewLocation(models.NewID(), "Test Location 2", "2 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc2.SetCoordinates(48.8606111, 2.3376)
+	loc2.Role = models.ShareRoleEditor
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc1.ID, loc1.Name, loc1.Address, loc1.Components.Street, loc1.Components.HouseNumber, loc1.Components.PostalCode, loc1.Components.Locality, loc1.Components.Region, loc1.Components.CountryCode, loc1.Latitude, loc1.Longitude, loc1.Category, loc1.User, loc1.Group, nil, "owner").
+		AddRow(loc2.ID, loc2.Name, loc2.Address, loc2.Components.Street, loc2.Components.HouseNumber, loc2.Components.PostalCode, loc2.Components.Locality, loc2.Components.Region, loc2.Components.CountryCode, loc2.Latitude, loc2.Longitude, loc2.Category, loc2.User, loc2.Group, nil, "editor")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WillReturnRows(rows)
+
+	locs, err := repo.GetLocations(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, *locs, models.Locations{loc1, loc2})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindExpandedLocationsWithQueryError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = l.id ORDER BY t.name), " +
+		"CASE WHEN l.group_id IS NULL AND l.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = l.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = l.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = l.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE ((l.group_id IS NULL AND l.user_id = $1) OR l.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR l.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR l.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WillReturnError(errors.New("failed"))
+
+	_, err := repo.FindExpandedLocations(newTestContext(), nil)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindExpandedLocationsWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc.ExpandedCategory = cat
+	loc.Role = models.ShareRoleOwner
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "id", "name", "parent_id", "user_id"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner", cat.ID, cat.Name, nil, nil)
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = l.id ORDER BY t.name), " +
+		"CASE WHEN l.group_id IS NULL AND l.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = l.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = l.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = l.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE ((l.group_id IS NULL AND l.user_id = $1) OR l.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR l.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR l.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND l.category_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, cat.ID).WillReturnRows(rows)
+
+	locs, err := repo.FindExpandedLocations(ctx, cat)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindExpandedLocationsOfGroup(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	cat := models.NewCategory(models.NewID(), "Test Category")
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
 
-	query := "UPDATE categories SET name = $1 WHERE id = $2"
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	// Created by another member of a group of the user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.ExpandedCategory = cat
+	loc.SetGroup(models.NewID())
+	loc.Role = models.ShareRoleEditor
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "id", "name", "parent_id", "user_id"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, *loc.Group, nil, "editor", cat.ID, cat.Name, nil, nil)
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = l.id ORDER BY t.name), " +
+		"CASE WHEN l.group_id IS NULL AND l.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = l.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = l.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = l.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE ((l.group_id IS NULL AND l.user_id = $1) OR l.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR l.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR l.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(cat.Name, cat.ID).WillReturnError(errors.New("failed"))
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
 
-	err := repo.UpdateCategory(newTestContext(), cat)
-	assert.Error(t, err)
+	locs, err := repo.FindExpandedLocations(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestUpdateCategoryWithSuccess(t *testing.T) {
+func TestFindExpandedLocationsShared(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
 	cat := models.NewCategory(models.NewID(), "Test Category")
+	// Shared with the user by another user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.ExpandedCategory = cat
+	loc.Role = models.ShareRoleViewer
 
-	query := "UPDATE categories SET name = $1 WHERE id = $2"
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "id", "name", "parent_id", "user_id"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "viewer", cat.ID, cat.Name, nil, nil)
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = l.id ORDER BY t.name), " +
+		"CASE WHEN l.group_id IS NULL AND l.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = l.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = l.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = l.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE ((l.group_id IS NULL AND l.user_id = $1) OR l.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR l.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR l.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().
-		WithArgs(cat.Name, cat.ID).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
 
-	err := repo.UpdateCategory(newTestContext(), cat)
+	locs, err := repo.FindExpandedLocations(ctx, nil)
 	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestDeleteCategoryWithPrepareError(t *testing.T) {
+func TestFindExpandedLocationByIDWithNoRows(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
 	id := models.NewID()
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "id", "name", "parent_id", "user_id"})
 
-	query := "DELETE FROM categories WHERE id = $1"
-	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = l.id ORDER BY t.name), " +
+		"CASE WHEN l.group_id IS NULL AND l.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = l.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = l.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = l.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE l.id = $1 AND ((l.group_id IS NULL AND l.user_id = $2) OR l.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR l.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR l.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WillReturnRows(rows)
 
-	err := repo.DeleteCategory(newTestContext(), id)
-	assert.Error(t, err)
+	loc, err := repo.FindExpandedLocationByID(newTestContext(), id)
+	assert.NoError(t, err)
+	assert.Nil(t, loc)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestDeleteCategoryWithExecError(t *testing.T) {
+func TestFindExpandedLocationByIDWithSuccess(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	id := models.NewID()
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc.ExpandedCategory = cat
+	loc.Components = models.AddressComponents{Street: "rue de la Poste", HouseNumber: "1", PostalCode: "75001", Locality: "Paris", CountryCode: "FR"}
+	loc.Role = models.ShareRoleOwner
 
-	query := "DELETE FROM categories WHERE id = $1"
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "id", "name", "parent_id", "user_id"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner", cat.ID, cat.Name, nil, nil)
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = l.id ORDER BY t.name), " +
+		"CASE WHEN l.group_id IS NULL AND l.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = l.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = l.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = l.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE l.id = $1 AND ((l.group_id IS NULL AND l.user_id = $2) OR l.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR l.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR l.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(id).WillReturnError(errors.New("failed"))
+	prep.ExpectQuery().WithArgs(loc.ID, user.ID).WillReturnRows(rows)
 
-	err := repo.DeleteCategory(newTestContext(), id)
-	assert.Error(t, err)
+	returnedLoc, err := repo.FindExpandedLocationByID(ctx, loc.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, loc, returnedLoc)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestDeleteCategoryWithSuccess(t *testing.T) {
+func TestFindExpandedLocationByIDOfGroup(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	id := models.NewID()
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
 
-	query := "DELETE FROM categories WHERE id = $1"
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	// Created by another member of a group of the user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.ExpandedCategory = cat
+	loc.SetGroup(models.NewID())
+	loc.Role = models.ShareRoleEditor
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "id", "name", "parent_id", "user_id"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, *loc.Group, nil, "editor", cat.ID, cat.Name, nil, nil)
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = l.id ORDER BY t.name), " +
+		"CASE WHEN l.group_id IS NULL AND l.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = l.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = l.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = l.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE l.id = $1 AND ((l.group_id IS NULL AND l.user_id = $2) OR l.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR l.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR l.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().
-		WithArgs(id).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectQuery().WithArgs(loc.ID, user.ID).WillReturnRows(rows)
 
-	err := repo.DeleteCategory(newTestContext(), id)
+	returnedLoc, err := repo.FindExpandedLocationByID(ctx, loc.ID)
 	assert.NoError(t, err)
+	assert.Equal(t, loc, returnedLoc)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestCreateLocationWithPrepareError(t *testing.T) {
+func TestFindExpandedLocationByIDShared(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
 
-	query := "INSERT INTO locations (id, name, address, category_id, user_id) VALUES ($1, $2, $3, $4, $5)"
-	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	// Shared with the user by another user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.ExpandedCategory = cat
+	loc.Role = models.ShareRoleViewer
 
-	err := repo.CreateLocation(newTestContext(), loc)
-	assert.Error(t, err)
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "id", "name", "parent_id", "user_id"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "viewer", cat.ID, cat.Name, nil, nil)
+
+	query := "SELECT l.id, l.name, l.address, l.street, l.house_number, l.postal_code, l.locality, l.region, l.country_code, l.latitude, l.longitude, l.category_id, l.user_id, l.group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = l.id ORDER BY t.name), " +
+		"CASE WHEN l.group_id IS NULL AND l.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = l.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = l.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = l.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", c.id, c.name, c.parent_id, c.user_id FROM locations l INNER JOIN categories c ON c.id = l.category_id WHERE l.id = $1 AND ((l.group_id IS NULL AND l.user_id = $2) OR l.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR l.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR l.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(loc.ID, user.ID).WillReturnRows(rows)
+
+	returnedLoc, err := repo.FindExpandedLocationByID(ctx, loc.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, loc, returnedLoc)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestCreateLocationWithExecError(t *testing.T) {
+func TestStreamLocationsWithQueryError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
-
-	query := "INSERT INTO locations (id, name, address, category_id, user_id) VALUES ($1, $2, $3, $4, $5)"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(loc.ID, loc.Name, loc.Address, loc.Category, loc.User).WillReturnError(errors.New("failed"))
+	prep.ExpectQuery().WillReturnError(errors.New("failed"))
 
-	err := repo.CreateLocation(newTestContext(), loc)
+	err := repo.StreamLocations(newTestContext(), nil, func(*models.Location) error { return nil })
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestCreateLocationWithSuccess(t *testing.T) {
+func TestStreamLocationsWithCallbackError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
 
-	query := "INSERT INTO locations (id, name, address, category_id, user_id) VALUES ($1, $2, $3, $4, $5)"
-	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().
-		WithArgs(loc.ID, loc.Name, loc.Address, loc.Category, loc.User).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	loc1 := models.NewLocation(models.NewID(), "Test Location 1", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	loc2 := models.NewLocation(models.NewID(), "Test Location 2", "2 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
 
-	err := repo.CreateLocation(newTestContext(), loc)
-	assert.NoError(t, err)
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc1.ID, loc1.Name, loc1.Address, loc1.Components.Street, loc1.Components.HouseNumber, loc1.Components.PostalCode, loc1.Components.Locality, loc1.Components.Region, loc1.Components.CountryCode, loc1.Latitude, loc1.Longitude, loc1.Category, loc1.User, loc1.Group, nil, "owner").
+		AddRow(loc2.ID, loc2.Name, loc2.Address, loc2.Components.Street, loc2.Components.HouseNumber, loc2.Components.PostalCode, loc2.Components.Locality, loc2.Components.Region, loc2.Components.CountryCode, loc2.Latitude, loc2.Longitude, loc2.Category, loc2.User, loc2.Group, nil, "owner")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
+
+	calls := 0
+	failure := errors.New("failed")
+	err := repo.StreamLocations(ctx, nil, func(*models.Location) error {
+		calls++
+		return failure
+	})
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 1, calls)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetLocationsWithPrepareError(t *testing.T) {
+func TestStreamLocationsOutlivingQueryTimeout(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
+	repo.Config.QueryTimeout = 10 * time.Millisecond
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE user_id = $1"
-	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
 
-	_, err := repo.GetLocations(newTestContext())
-	assert.Error(t, err)
+	loc1 := models.NewLocation(models.NewID(), "Test Location 1", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	loc2 := models.NewLocation(models.NewID(), "Test Location 2", "2 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc1.ID, loc1.Name, loc1.Address, loc1.Components.Street, loc1.Components.HouseNumber, loc1.Components.PostalCode, loc1.Components.Locality, loc1.Components.Region, loc1.Components.CountryCode, loc1.Latitude, loc1.Longitude, loc1.Category, loc1.User, loc1.Group, nil, "owner").
+		AddRow(loc2.ID, loc2.Name, loc2.Address, loc2.Components.Street, loc2.Components.HouseNumber, loc2.Components.PostalCode, loc2.Components.Locality, loc2.Components.Region, loc2.Components.CountryCode, loc2.Latitude, loc2.Longitude, loc2.Category, loc2.User, loc2.Group, nil, "owner")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
+
+	// Slow consumers keep reading rows after the query timeout
+	calls := 0
+	err := repo.StreamLocations(ctx, nil, func(*models.Location) error {
+		calls++
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetLocationsWithQueryError(t *testing.T) {
+func TestStreamLocationsWithSuccess(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE user_id = $1"
-	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WillReturnError(errors.New("failed"))
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
 
-	_, err := repo.GetLocations(newTestContext())
-	assert.Error(t, err)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc1 := models.NewLocation(models.NewID(), "Test Location 1", "1 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc1.Role = models.ShareRoleOwner
+	loc2 := models.NewLocation(models.NewID(), "Test Location 2", "2 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc2.Role = models.ShareRoleOwner
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc1.ID, loc1.Name, loc1.Address, loc1.Components.Street, loc1.Components.HouseNumber, loc1.Components.PostalCode, loc1.Components.Locality, loc1.Components.Region, loc1.Components.CountryCode, loc1.Latitude, loc1.Longitude, loc1.Category, loc1.User, loc1.Group, nil, "owner").
+		AddRow(loc2.ID, loc2.Name, loc2.Address, loc2.Components.Street, loc2.Components.HouseNumber, loc2.Components.PostalCode, loc2.Components.Locality, loc2.Components.Region, loc2.Components.CountryCode, loc2.Latitude, loc2.Longitude, loc2.Category, loc2.User, loc2.Group, nil, "owner")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND category_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, cat.ID).WillReturnRows(rows)
+
+	var locs models.Locations
+	err := repo.StreamLocations(ctx, cat, func(loc *models.Location) error {
+		l := *loc
+		locs = append(locs, &l)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc1, loc2}, locs)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetLocationsWithRowError(t *testing.T) {
+func TestStreamLocationsOfGroup(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
 	ctx := newTestContext()
 	user, _ := models.NewUserFromContext(ctx)
 
-	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	// Created by another member of a group of the user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetGroup(models.NewID())
+	loc.Role = models.ShareRoleEditor
 
-	rows := sqlmock.NewRows([]string{"id", "name", "address", "category_id", "user_id"}).
-		RowError(0, errors.New("failed")).
-		AddRow(loc.ID, loc.Name, loc.Address, loc.Category, loc.User)
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, *loc.Group, nil, "editor")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE user_id = $1"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WillReturnRows(rows)
-
-	_, err := repo.GetLocations(ctx)
-	assert.Error(t, err)
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
+
+	var locs models.Locations
+	err := repo.StreamLocations(ctx, nil, func(loc *models.Location) error {
+		l := *loc
+		locs = append(locs, &l)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, locs)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetLocationsWithSuccess(t *testing.T) {
+func TestStreamLocationsShared(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
 	ctx := newTestContext()
 	user, _ := models.NewUserFromContext(ctx)
 
-	loc1 := models.NewLocation(models.NewID(), "Test Location 1", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
-	loc2 := models.NewLocation(models.NewID(), "Test Location 2", "2 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	// Shared with the user by another user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Role = models.ShareRoleViewer
 
-	rows := sqlmock.NewRows([]string{"id", "name", "address", "category_id", "user_id"}).
-		AddRow(loc1.ID, loc1.Name, loc1.Address, loc1.Category, loc1.User).
-		AddRow(loc2.ID, loc2.Name, loc2.Address, loc2.Category, loc2.User)
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "viewer")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE user_id = $1"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1))"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectQuery().WillReturnRows(rows)
-
-	locs, err := repo.GetLocations(ctx)
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
+
+	var locs models.Locations
+	err := repo.StreamLocations(ctx, nil, func(loc *models.Location) error {
+		l := *loc
+		locs = append(locs, &l)
+		return nil
+	})
 	assert.NoError(t, err)
-	assert.ElementsMatch(t, *locs, models.Locations{loc1, loc2})
+	assert.Equal(t, models.Locations{loc}, locs)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -499,7 +1268,9 @@ func TestFindLocationByIDWithPrepareError(t *testing.T) {
 
 	id := models.NewID()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	_, err := repo.FindLocationByID(newTestContext(), id)
@@ -515,7 +1286,9 @@ func TestFindLocationByIDWithQueryError(t *testing.T) {
 	user, _ := models.NewUserFromContext(ctx)
 	id := models.NewID()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnError(errors.New("failed"))
 
@@ -533,11 +1306,13 @@ func TestFindLocationByIDWithRowError(t *testing.T) {
 
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "address", "category_id", "user_id"}).
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
 		RowError(0, errors.New("failed")).
-		AddRow(loc.ID, loc.Name, loc.Address, loc.Category, loc.User)
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(loc.ID, user.ID).WillReturnRows(rows)
 
@@ -554,7 +1329,9 @@ func TestFindLocationByIDWithNoResult(t *testing.T) {
 	user, _ := models.NewUserFromContext(ctx)
 	id := models.NewID()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnRows(sqlmock.NewRows(nil))
 
@@ -572,11 +1349,66 @@ func TestFindLocationByIDWithSuccess(t *testing.T) {
 	user, _ := models.NewUserFromContext(ctx)
 
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	loc.SetTags([]string{"Favorite", "Vegan"})
+	loc.Role = models.ShareRoleOwner
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, "{Favorite,Vegan}", "owner")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(loc.ID, user.ID).WillReturnRows(rows)
+
+	returnedLoc, err := repo.FindLocationByID(ctx, loc.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, loc, returnedLoc)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "address", "category_id", "user_id"}).
-		AddRow(loc.ID, loc.Name, loc.Address, loc.Category, loc.User)
+func TestFindLocationByIDSharedWithUser(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Role = models.ShareRoleViewer
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "viewer")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(loc.ID, user.ID).WillReturnRows(rows)
+
+	returnedLoc, err := repo.FindLocationByID(ctx, loc.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, loc, returnedLoc)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationByIDOfGroup(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetGroup(models.NewID())
+	loc.Role = models.ShareRoleEditor
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, *loc.Group, nil, "editor")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(loc.ID, user.ID).WillReturnRows(rows)
 
@@ -590,7 +1422,7 @@ func TestFindLocationByNameWithPrepareError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE name = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name) FROM locations WHERE name = $1 AND user_id = $2"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	_, err := repo.FindLocationByName(newTestContext(), "test")
@@ -606,7 +1438,7 @@ func TestFindLocationByNameWithQueryError(t *testing.T) {
 	user, _ := models.NewUserFromContext(ctx)
 	name := "test"
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE name = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name) FROM locations WHERE name = $1 AND user_id = $2"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(name, user.ID).WillReturnError(errors.New("failed"))
 
@@ -624,11 +1456,11 @@ func TestFindLocationByNameWithRowError(t *testing.T) {
 
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "address", "category_id", "user_id"}).
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "tags"}).
 		RowError(0, errors.New("failed")).
-		AddRow(loc.ID, loc.Name, loc.Address, loc.Category, loc.User)
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, nil)
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE name = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name) FROM locations WHERE name = $1 AND user_id = $2"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(loc.Name, user.ID).WillReturnRows(rows)
 
@@ -645,7 +1477,7 @@ func TestFindLocationByNameWithNoResult(t *testing.T) {
 	user, _ := models.NewUserFromContext(ctx)
 	name := "test"
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE name = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name) FROM locations WHERE name = $1 AND user_id = $2"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(name, user.ID).WillReturnRows(sqlmock.NewRows(nil))
 
@@ -664,10 +1496,10 @@ func TestFindLocationByNameWithSuccess(t *testing.T) {
 
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "address", "category_id", "user_id"}).
-		AddRow(loc.ID, loc.Name, loc.Address, loc.Category, loc.User)
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "tags"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, nil)
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE name = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name) FROM locations WHERE name = $1 AND user_id = $2"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(loc.Name, user.ID).WillReturnRows(rows)
 
@@ -683,10 +1515,12 @@ func TestFindLocationsByCategoryWithPrepareError(t *testing.T) {
 
 	cat := models.NewCategory(models.NewID(), "Test Category")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE category_id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE category_id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
-	_, err := repo.FindLocationsByCategory(newTestContext(), cat)
+	_, err := repo.FindLocationsByCategory(newTestContext(), cat, false)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -700,11 +1534,13 @@ func TestFindLocationsByCategoryWithQueryError(t *testing.T) {
 
 	cat := models.NewCategory(models.NewID(), "Test Category")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE category_id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE category_id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnError(errors.New("failed"))
 
-	_, err := repo.FindLocationsByCategory(ctx, cat)
+	_, err := repo.FindLocationsByCategory(ctx, cat, false)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -719,15 +1555,17 @@ func TestFindLocationsByCategoryWithRowError(t *testing.T) {
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "address", "category_id", "user_id"}).
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
 		RowError(0, errors.New("failed")).
-		AddRow(loc.ID, loc.Name, loc.Address, loc.Category, loc.User)
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE category_id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE category_id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(rows)
 
-	_, err := repo.FindLocationsByCategory(ctx, cat)
+	_, err := repo.FindLocationsByCategory(ctx, cat, false)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -741,11 +1579,13 @@ func TestFindLocationsByCategoryWithNoResult(t *testing.T) {
 
 	cat := models.NewCategory(models.NewID(), "Test Category")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE category_id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE category_id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(sqlmock.NewRows(nil))
 
-	locs, err := repo.FindLocationsByCategory(ctx, cat)
+	locs, err := repo.FindLocationsByCategory(ctx, cat, false)
 	assert.NoError(t, err)
 	assert.Empty(t, locs)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -760,22 +1600,110 @@ func TestFindLocationsByCategoryWithSuccess(t *testing.T) {
 
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc1 := models.NewLocation(models.NewID(), "Test Location 1", "1 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc1.Role = models.ShareRoleOwner
 	loc2 := models.NewLocation(models.NewID(), "Test Location 2", "2 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc2.Role = models.ShareRoleOwner
 
-	rows := sqlmock.NewRows([]string{"id", "name", "address", "category_id", "user_id"}).
-		AddRow(loc1.ID, loc1.Name, loc1.Address, loc1.Category, loc1.User).
-		AddRow(loc2.ID, loc2.Name, loc2.Address, loc2.Category, loc2.User)
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc1.ID, loc1.Name, loc1.Address, loc1.Components.Street, loc1.Components.HouseNumber, loc1.Components.PostalCode, loc1.Components.Locality, loc1.Components.Region, loc1.Components.CountryCode, loc1.Latitude, loc1.Longitude, loc1.Category, loc1.User, loc1.Group, nil, "owner").
+		AddRow(loc2.ID, loc2.Name, loc2.Address, loc2.Components.Street, loc2.Components.HouseNumber, loc2.Components.PostalCode, loc2.Components.Locality, loc2.Components.Region, loc2.Components.CountryCode, loc2.Latitude, loc2.Longitude, loc2.Category, loc2.User, loc2.Group, nil, "owner")
 
-	query := "SELECT id, name, address, category_id, user_id FROM locations WHERE category_id = $1 AND user_id = $2"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE category_id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(rows)
 
-	locs, err := repo.FindLocationsByCategory(ctx, cat)
+	locs, err := repo.FindLocationsByCategory(ctx, cat, false)
 	assert.NoError(t, err)
 	assert.ElementsMatch(t, *locs, models.Locations{loc1, loc2})
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestFindLocationsByCategoryWithDescendants(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Sport")
+	child := models.NewCategory(models.NewID(), "Tennis")
+	child.SetParent(cat.ID)
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", child.ID, user.ID)
+	loc.Role = models.ShareRoleOwner
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner")
+
+	query := "WITH RECURSIVE tree (id) AS (SELECT id FROM categories WHERE id = $1 UNION SELECT c.id FROM categories c INNER JOIN tree t ON c.parent_id = t.id) " +
+		"SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE category_id IN (SELECT id FROM tree) AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsByCategory(ctx, cat, true)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsByCategoryOfGroup(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	// Created by another member of a group of the user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.SetGroup(models.NewID())
+	loc.Role = models.ShareRoleEditor
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, *loc.Group, nil, "editor")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE category_id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsByCategory(ctx, cat, false)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsByCategoryShared(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	// In a category shared with the user by another user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.Role = models.ShareRoleViewer
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, nil, "viewer")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE category_id = $1 AND ((locations.group_id IS NULL AND locations.user_id = $2) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $2) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $2) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(cat.ID, user.ID).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsByCategory(ctx, cat, false)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUpdateLocationWithPrepareError(t *testing.T) {
 	repo, mock := newSQLMock(t)
 	defer repo.Close()
@@ -785,7 +1713,8 @@ func TestUpdateLocationWithPrepareError(t *testing.T) {
 
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
 
-	query := "UPDATE locations SET name = $1, address = $2, category_id = $3 WHERE id = $4 AND user_id = $5"
+	query := "UPDATE locations SET name = $1, address = $2, street = $3, house_number = $4, postal_code = $5, locality = $6, region = $7, country_code = $8, " +
+		"latitude = $9, longitude = $10, category_id = $11 WHERE id = $12 AND user_id = $13"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	err := repo.UpdateLocation(ctx, loc)
@@ -802,10 +1731,11 @@ func TestUpdateLocationWithExecError(t *testing.T) {
 
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
 
-	query := "UPDATE locations SET name = $1, address = $2, category_id = $3 WHERE id = $4 AND user_id = $5"
+	query := "UPDATE locations SET name = $1, address = $2, street = $3, house_number = $4, postal_code = $5, locality = $6, region = $7, country_code = $8, " +
+		"latitude = $9, longitude = $10, category_id = $11 WHERE id = $12 AND user_id = $13"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(loc.Name, loc.Address, loc.Category, loc.ID, user.ID).
+		WithArgs(loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.ID, user.ID).
 		WillReturnError(errors.New("failed"))
 
 	err := repo.UpdateLocation(ctx, loc)
@@ -822,10 +1752,11 @@ func TestUpdateLocationWithSuccess(t *testing.T) {
 
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
 
-	query := "UPDATE locations SET name = $1, address = $2, category_id = $3 WHERE id = $4 AND user_id = $5"
+	query := "UPDATE locations SET name = $1, address = $2, street = $3, house_number = $4, postal_code = $5, locality = $6, region = $7, country_code = $8, " +
+		"latitude = $9, longitude = $10, category_id = $11 WHERE id = $12 AND user_id = $13"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(loc.Name, loc.Address, loc.Category, loc.ID, user.ID).
+		WithArgs(loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.ID, user.ID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err := repo.UpdateLocation(ctx, loc)
@@ -839,7 +1770,8 @@ func TestDeleteLocationWithPrepareError(t *testing.T) {
 
 	id := models.NewID()
 
-	query := "DELETE FROM locations WHERE id = $1 AND user_id = $2"
+	query := "DELETE FROM locations WHERE id = $1 AND " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END = 'owner'"
 	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
 
 	err := repo.DeleteLocation(newTestContext(), id)
@@ -856,7 +1788,8 @@ func TestDeleteLocationWithExecError(t *testing.T) {
 
 	id := models.NewID()
 
-	query := "DELETE FROM locations WHERE id = $1 AND user_id = $2"
+	query := "DELETE FROM locations WHERE id = $1 AND " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END = 'owner'"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
 		WithArgs(id, user.ID).
@@ -876,7 +1809,8 @@ func TestDeleteLocationWithSuccess(t *testing.T) {
 
 	id := models.NewID()
 
-	query := "DELETE FROM locations WHERE id = $1 AND user_id = $2"
+	query := "DELETE FROM locations WHERE id = $1 AND " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END = 'owner'"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
 		WithArgs(id, user.ID).
@@ -886,3 +1820,98 @@ func TestDeleteLocationWithSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestMergeCategoryWithExecError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+	target := models.NewID()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE locations SET category_id = $1 WHERE category_id = $2 AND user_id = $3").
+		WithArgs(target, id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE categories SET parent_id = $1 WHERE parent_id = $2 AND user_id = $3").
+		WithArgs(target, id, user.ID).
+		WillReturnError(errors.New("failed"))
+	mock.ExpectRollback()
+
+	err := repo.MergeCategory(ctx, id, target)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeCategoryWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	id := models.NewID()
+	target := models.NewID()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE locations SET category_id = $1 WHERE category_id = $2 AND user_id = $3").
+		WithArgs(target, id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE categories SET parent_id = $1 WHERE parent_id = $2 AND user_id = $3").
+		WithArgs(target, id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM categories WHERE id = $1 AND user_id = $2").
+		WithArgs(id, user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.MergeCategory(ctx, id, target)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMoveLocationsWithQueryError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	ids := []models.ID{models.NewID()}
+
+	prep := mock.ExpectPrepare("UPDATE locations SET category_id = $1 WHERE user_id = $2 AND id = ANY($3::uuid[]) AND " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END IN ('owner', 'editor') RETURNING id")
+	prep.ExpectQuery().
+		WithArgs(cat.ID, user.ID, pq.Array([]string{ids[0].String()})).
+		WillReturnError(errors.New("failed"))
+
+	_, err := repo.MoveLocations(ctx, ids, cat)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMoveLocationsWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	// The first location belongs to a group the user has left
+	ids := []models.ID{models.NewID(), models.NewID()}
+
+	prep := mock.ExpectPrepare("UPDATE locations SET category_id = $1 WHERE user_id = $2 AND id = ANY($3::uuid[]) AND " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $2 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $2 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $2 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $2) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END IN ('owner', 'editor') RETURNING id")
+	prep.ExpectQuery().
+		WithArgs(cat.ID, user.ID, pq.Array([]string{ids[0].String(), ids[1].String()})).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(ids[1]))
+
+	moved, err := repo.MoveLocations(ctx, ids, cat)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ID{ids[1]}, moved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE "locations"
+ DROP CONSTRAINT "CHK_locations_coordinates",
+ DROP CONSTRAINT "CHK_locations_longitude",
+ DROP CONSTRAINT "CHK_locations_latitude",
+ DROP COLUMN "longitude",
+ DROP COLUMN "latitude";
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE "locations"
+ ADD COLUMN "latitude"  double precision,
+ ADD COLUMN "longitude" double precision,
+ ADD CONSTRAINT "CHK_locations_latitude" CHECK ( "latitude" BETWEEN -90 AND 90 ),
+ ADD CONSTRAINT "CHK_locations_longitude" CHECK ( "longitude" BETWEEN -180 AND 180 ),
+ ADD CONSTRAINT "CHK_locations_coordinates" CHECK ( ( "latitude" IS NULL ) = ( "longitude" IS NULL ) );
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "idx_locations_geography";
+DROP INDEX "idx_locations_geohash";
+
+ALTER TABLE "locations"
+ DROP COLUMN "geohash";
+
+DROP FUNCTION "geohash_encode";
+
+COMMIT;
@@ -0,0 +1,76 @@
+BEGIN;
+
+-- Same encoding as the geohash package. Used to index locations for proximity searches
+-- when PostGIS is not available.
+CREATE FUNCTION "geohash_encode" ( "latitude" double precision, "longitude" double precision, "precision" integer )
+ RETURNS varchar
+ LANGUAGE plpgsql
+ IMMUTABLE STRICT PARALLEL SAFE
+AS $$
+DECLARE
+ base32   CONSTANT text := '0123456789bcdefghjkmnpqrstuvwxyz';
+ lat_min  double precision := -90;
+ lat_max  double precision := 90;
+ lng_min  double precision := -180;
+ lng_max  double precision := 180;
+ mid      double precision;
+ hash     text := '';
+ even     boolean := true;
+ nbits    integer := 0;
+ ch       integer := 0;
+BEGIN
+ WHILE length(hash) < "precision" LOOP
+  IF even THEN
+   mid := (lng_min + lng_max) / 2;
+   IF "longitude" >= mid THEN
+    ch := ch * 2 + 1;
+    lng_min := mid;
+   ELSE
+    ch := ch * 2;
+    lng_max := mid;
+   END IF;
+  ELSE
+   mid := (lat_min + lat_max) / 2;
+   IF "latitude" >= mid THEN
+    ch := ch * 2 + 1;
+    lat_min := mid;
+   ELSE
+    ch := ch * 2;
+    lat_max := mid;
+   END IF;
+  END IF;
+  even := NOT even;
+
+  nbits := nbits + 1;
+  IF nbits = 5 THEN
+   hash := hash || substr(base32, ch + 1, 1);
+   nbits := 0;
+   ch := 0;
+  END IF;
+ END LOOP;
+
+ RETURN hash;
+END;
+$$;
+
+ALTER TABLE "locations"
+ ADD COLUMN "geohash" varchar(12) GENERATED ALWAYS AS ( geohash_encode("latitude", "longitude", 12) ) STORED;
+
+CREATE INDEX "idx_locations_geohash" ON "locations"
+(
+    "user_id", "geohash" varchar_pattern_ops
+);
+
+-- Index locations geography when PostGIS is installed, so that it is used instead of geohashes.
+DO $$
+BEGIN
+ IF EXISTS ( SELECT 1 FROM pg_extension WHERE extname = 'postgis' ) THEN
+  CREATE INDEX "idx_locations_geography" ON "locations" USING GIST
+  (
+      ( geography(ST_SetSRID(ST_MakePoint("longitude", "latitude"), 4326)) )
+  );
+ END IF;
+END
+$$;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX "idx_locations_coordinates";
+
+COMMIT;
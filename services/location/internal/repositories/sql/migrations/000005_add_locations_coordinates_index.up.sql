@@ -0,0 +1,8 @@
+BEGIN;
+
+CREATE INDEX "idx_locations_coordinates" ON "locations"
+(
+    "user_id", "latitude", "longitude"
+);
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE "locations"
+ DROP COLUMN "country_code",
+ DROP COLUMN "region",
+ DROP COLUMN "locality",
+ DROP COLUMN "postal_code",
+ DROP COLUMN "house_number",
+ DROP COLUMN "street";
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE "locations"
+ ADD COLUMN "street"       varchar(255) NOT NULL DEFAULT '',
+ ADD COLUMN "house_number" varchar(20) NOT NULL DEFAULT '',
+ ADD COLUMN "postal_code"  varchar(20) NOT NULL DEFAULT '',
+ ADD COLUMN "locality"     varchar(100) NOT NULL DEFAULT '',
+ ADD COLUMN "region"       varchar(100) NOT NULL DEFAULT '',
+ ADD COLUMN "country_code" varchar(2) NOT NULL DEFAULT '';
+
+-- Components of existing locations are parsed in Go, by the backfill-addresses command.
+
+COMMIT;
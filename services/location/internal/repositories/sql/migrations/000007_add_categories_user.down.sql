@@ -0,0 +1,27 @@
+BEGIN;
+
+-- Categories sharing the same name are merged back into a single category,
+-- preferring the default one, and their locations are moved to it.
+CREATE TEMPORARY TABLE "category_merges" ON COMMIT DROP AS
+SELECT c."id", kept."id" AS "kept_id"
+FROM "categories" c
+INNER JOIN
+(
+ SELECT "name", ( array_agg("id" ORDER BY "user_id" NULLS FIRST, "id") )[1] AS "id"
+ FROM "categories"
+ GROUP BY "name"
+) AS kept ON kept."name" = c."name" AND kept."id" <> c."id";
+
+UPDATE "locations" l SET "category_id" = m."kept_id"
+FROM "category_merges" m
+WHERE m."id" = l."category_id";
+
+DELETE FROM "categories" c
+USING "category_merges" m
+WHERE m."id" = c."id";
+
+DROP INDEX "idx_categories_user";
+
+ALTER TABLE "categories" DROP COLUMN "user_id";
+
+COMMIT;
@@ -0,0 +1,83 @@
+BEGIN;
+
+-- gen_random_uuid() is built into PostgreSQL from version 13 only, and is provided by pgcrypto before.
+-- It is used by this migration and by the repository to create tags.
+CREATE EXTENSION IF NOT EXISTS "pgcrypto";
+
+-- Categories are owned by users. Default categories, shared by all users and managed by admins, have no owner.
+ALTER TABLE "categories" ADD COLUMN "user_id" uuid;
+
+-- Default categories are a curated list. Categories bearing one of their names stay shared by all users,
+-- merged into a single category per name. Missing default categories are created.
+CREATE TEMPORARY TABLE "default_categories" ON COMMIT DROP AS
+SELECT "name"
+FROM
+(
+ VALUES ('Home'), ('Work'), ('Restaurant'), ('Bar'), ('Shop'), ('Park'), ('Museum'), ('Hotel'), ('Sport'), ('Health')
+) AS d ( "name" );
+
+CREATE TEMPORARY TABLE "default_category_merges" ON COMMIT DROP AS
+SELECT c."id", kept."id" AS "kept_id"
+FROM "categories" c
+INNER JOIN
+(
+ SELECT "name", ( array_agg("id" ORDER BY "id") )[1] AS "id"
+ FROM "categories"
+ WHERE "name" IN ( SELECT "name" FROM "default_categories" )
+ GROUP BY "name"
+) AS kept ON kept."name" = c."name" AND kept."id" <> c."id";
+
+UPDATE "locations" l SET "category_id" = m."kept_id"
+FROM "default_category_merges" m
+WHERE m."id" = l."category_id";
+
+DELETE FROM "categories" c
+USING "default_category_merges" m
+WHERE m."id" = c."id";
+
+INSERT INTO "categories" ( "id", "name" )
+SELECT gen_random_uuid(), d."name"
+FROM "default_categories" d
+WHERE NOT EXISTS ( SELECT 1 FROM "categories" c WHERE c."name" = d."name" );
+
+-- Other categories used by a single user are given to that user. Categories used by several users are
+-- given to the first of them, and copied for the others, whose locations are moved to their copy.
+CREATE TEMPORARY TABLE "category_users" ON COMMIT DROP AS
+SELECT "category_id", "user_id", CASE WHEN "rank" > 1 THEN gen_random_uuid() END AS "copy_id"
+FROM
+(
+ SELECT "category_id", "user_id", row_number() OVER ( PARTITION BY "category_id" ORDER BY "user_id" ) AS "rank"
+ FROM
+ (
+  SELECT DISTINCT l."category_id", l."user_id"
+  FROM "locations" l
+  INNER JOIN "categories" c ON c."id" = l."category_id"
+  WHERE c."name" NOT IN ( SELECT "name" FROM "default_categories" )
+ ) AS l
+) AS u;
+
+UPDATE "categories" c SET "user_id" = cu."user_id"
+FROM "category_users" cu
+WHERE cu."category_id" = c."id" AND cu."copy_id" IS NULL;
+
+INSERT INTO "categories" ( "id", "name", "user_id" )
+SELECT cu."copy_id", c."name", cu."user_id"
+FROM "category_users" cu
+INNER JOIN "categories" c ON c."id" = cu."category_id"
+WHERE cu."copy_id" IS NOT NULL;
+
+UPDATE "locations" l SET "category_id" = cu."copy_id"
+FROM "category_users" cu
+WHERE cu."category_id" = l."category_id" AND cu."user_id" = l."user_id" AND cu."copy_id" IS NOT NULL;
+
+-- Other unused categories have no known owner, and must not become default categories visible to all users.
+-- They hold no locations, so they are dropped.
+DELETE FROM "categories"
+WHERE "user_id" IS NULL AND "name" NOT IN ( SELECT "name" FROM "default_categories" );
+
+CREATE INDEX "idx_categories_user" ON "categories"
+(
+    "user_id"
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX "fkIdx_categories_parent";
+
+ALTER TABLE "categories" DROP COLUMN "parent_id";
+
+COMMIT;
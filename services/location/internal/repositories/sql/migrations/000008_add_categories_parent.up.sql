@@ -0,0 +1,14 @@
+BEGIN;
+
+-- Categories may be nested, like "Sport > Tennis > Indoor courts". Children of a deleted category move to top level.
+ALTER TABLE "categories"
+ ADD COLUMN "parent_id" uuid,
+ ADD CONSTRAINT "FK_categories_parent" FOREIGN KEY ( "parent_id" ) REFERENCES "categories" ( "id" ) ON DELETE SET NULL,
+ ADD CONSTRAINT "CHK_categories_parent" CHECK ( "parent_id" <> "id" );
+
+CREATE INDEX "fkIdx_categories_parent" ON "categories"
+(
+    "parent_id"
+);
+
+COMMIT;
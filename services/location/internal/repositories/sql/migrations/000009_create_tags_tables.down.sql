@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE "location_tags";
+
+DROP TABLE "tags";
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE "tags"
+(
+ "id"      uuid NOT NULL,
+ "name"    varchar(50) NOT NULL,
+ "user_id" uuid NOT NULL,
+ CONSTRAINT "PK_tags" PRIMARY KEY ( "id" )
+);
+
+CREATE UNIQUE INDEX "idx_tags_name" ON "tags"
+(
+    "name", "user_id"
+);
+
+-- Tags are removed from locations when either of them is deleted
+CREATE TABLE "location_tags"
+(
+ "location_id" uuid NOT NULL,
+ "tag_id"      uuid NOT NULL,
+ CONSTRAINT "PK_location_tags" PRIMARY KEY ( "location_id", "tag_id" ),
+ CONSTRAINT "FK_location_tags_location" FOREIGN KEY ( "location_id" ) REFERENCES "locations" ( "id" ) ON DELETE CASCADE,
+ CONSTRAINT "FK_location_tags_tag" FOREIGN KEY ( "tag_id" ) REFERENCES "tags" ( "id" ) ON DELETE CASCADE
+);
+
+CREATE INDEX "fkIdx_location_tags_tag" ON "location_tags"
+(
+    "tag_id"
+);
+
+COMMIT;
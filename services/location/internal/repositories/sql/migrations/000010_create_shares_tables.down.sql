@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE "category_shares";
+
+DROP TABLE "location_shares";
+
+COMMIT;
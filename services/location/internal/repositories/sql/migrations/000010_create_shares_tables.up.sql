@@ -0,0 +1,35 @@
+BEGIN;
+
+-- Locations shared with other users. Grants are removed along with the location.
+CREATE TABLE "location_shares"
+(
+ "location_id" uuid NOT NULL,
+ "user_id"     uuid NOT NULL,
+ "role"        varchar(10) NOT NULL,
+ CONSTRAINT "PK_location_shares" PRIMARY KEY ( "location_id", "user_id" ),
+ CONSTRAINT "FK_location_shares_location" FOREIGN KEY ( "location_id" ) REFERENCES "locations" ( "id" ) ON DELETE CASCADE,
+ CONSTRAINT "CHK_location_shares_role" CHECK ( "role" IN ('owner', 'editor', 'viewer') )
+);
+
+CREATE INDEX "idx_location_shares_user" ON "location_shares"
+(
+    "user_id"
+);
+
+-- Categories shared with other users, along with all their locations
+CREATE TABLE "category_shares"
+(
+ "category_id" uuid NOT NULL,
+ "user_id"     uuid NOT NULL,
+ "role"        varchar(10) NOT NULL,
+ CONSTRAINT "PK_category_shares" PRIMARY KEY ( "category_id", "user_id" ),
+ CONSTRAINT "FK_category_shares_category" FOREIGN KEY ( "category_id" ) REFERENCES "categories" ( "id" ) ON DELETE CASCADE,
+ CONSTRAINT "CHK_category_shares_role" CHECK ( "role" IN ('owner', 'editor', 'viewer') )
+);
+
+CREATE INDEX "idx_category_shares_user" ON "category_shares"
+(
+    "user_id"
+);
+
+COMMIT;
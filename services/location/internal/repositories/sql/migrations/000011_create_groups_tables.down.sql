@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX "fkIdx_locations_group";
+
+ALTER TABLE "locations" DROP COLUMN "group_id";
+
+DROP TABLE "group_members";
+
+DROP TABLE "groups";
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+
+CREATE TABLE "groups"
+(
+ "id"   uuid NOT NULL,
+ "name" varchar(50) NOT NULL,
+ CONSTRAINT "PK_groups" PRIMARY KEY ( "id" )
+);
+
+-- Members of groups. Memberships are removed along with the group.
+CREATE TABLE "group_members"
+(
+ "group_id" uuid NOT NULL,
+ "user_id"  uuid NOT NULL,
+ "role"     varchar(10) NOT NULL,
+ CONSTRAINT "PK_group_members" PRIMARY KEY ( "group_id", "user_id" ),
+ CONSTRAINT "FK_group_members_group" FOREIGN KEY ( "group_id" ) REFERENCES "groups" ( "id" ) ON DELETE CASCADE,
+ CONSTRAINT "CHK_group_members_role" CHECK ( "role" IN ('owner', 'member') )
+);
+
+CREATE INDEX "idx_group_members_user" ON "group_members"
+(
+    "user_id"
+);
+
+-- Locations owned by a group rather than by the user who created them.
+-- Locations of deleted groups are given back to the users who created them.
+ALTER TABLE "locations" ADD COLUMN "group_id" uuid;
+ALTER TABLE "locations" ADD CONSTRAINT "FK_locations_group" FOREIGN KEY ( "group_id" ) REFERENCES "groups" ( "id" ) ON DELETE SET NULL;
+
+CREATE INDEX "fkIdx_locations_group" ON "locations"
+(
+    "group_id"
+);
+
+COMMIT;
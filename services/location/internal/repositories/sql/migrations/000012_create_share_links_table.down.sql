@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE "share_links";
+
+COMMIT;
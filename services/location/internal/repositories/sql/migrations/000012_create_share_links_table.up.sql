@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Public links to locations, given to guests who are not users.
+-- Links are revoked by deleting them, and are removed along with the location.
+CREATE TABLE "share_links"
+(
+ "id"          uuid NOT NULL,
+ "location_id" uuid NOT NULL,
+ "user_id"     uuid NOT NULL,
+ "expires_at"  timestamptz NOT NULL,
+ "accesses"    integer NOT NULL DEFAULT 0,
+ CONSTRAINT "PK_share_links" PRIMARY KEY ( "id" ),
+ CONSTRAINT "FK_share_links_location" FOREIGN KEY ( "location_id" ) REFERENCES "locations" ( "id" ) ON DELETE CASCADE
+);
+
+CREATE INDEX "fkIdx_share_links_location" ON "share_links"
+(
+    "location_id"
+);
+
+COMMIT;
@@ -0,0 +1,119 @@
+package sqlrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+)
+
+// CreateShareLink stores a new share link in repository, created by the user
+func (r *SQLRepository) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "INSERT INTO share_links (id, location_id, user_id, expires_at) VALUES ($1, $2, $3, $4)"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("CreateShareLink: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("CreateShareLink: Failed to get user from context")
+	}
+
+	_, err = stmt.ExecContext(ctx, link.ID, link.Location, user.ID, link.ExpiresAt)
+	switch {
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("CreateShareLink: location %s not found. %w", link.Location, usecases.ErrLocationNotFound)
+	case err != nil:
+		return fmt.Errorf("CreateShareLink: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// GetShareLinks returns the share links of a location, expired ones included, sorted by expiry date
+func (r *SQLRepository) GetShareLinks(ctx context.Context, id models.ID) (*models.ShareLinks, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT id, location_id, expires_at, accesses FROM share_links WHERE location_id = $1 ORDER BY expires_at"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("GetShareLinks: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetShareLinks: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	links := make(models.ShareLinks, 0)
+	for rows.Next() {
+		link := new(models.ShareLink)
+		if err := rows.Scan(&link.ID, &link.Location, &link.ExpiresAt, &link.Accesses); err != nil {
+			return nil, fmt.Errorf("GetShareLinks: failed to scan SQL row. %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetShareLinks: rows failed. %w", err)
+	}
+
+	return &links, nil
+}
+
+// DeleteShareLink deletes share link in repository, revoking it
+func (r *SQLRepository) DeleteShareLink(ctx context.Context, id models.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "DELETE FROM share_links WHERE id = $1"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("DeleteShareLink: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteShareLink: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// FindPublicLocationByShareLink returns the restricted view of the location shared by the link matching id,
+// counting the access, or nil when the link has expired or has been revoked. It does not need any user in context.
+func (r *SQLRepository) FindPublicLocationByShareLink(ctx context.Context, id models.ID) (*models.PublicLocation, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "WITH link AS (UPDATE share_links SET accesses = accesses + 1 WHERE id = $1 AND expires_at > now() RETURNING location_id) " +
+		"SELECT l.name, l.address, l.latitude, l.longitude FROM locations l INNER JOIN link ON link.location_id = l.id"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindPublicLocationByShareLink: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	var loc models.PublicLocation
+	err = stmt.QueryRowContext(ctx, id).Scan(&loc.Name, &loc.Address, &loc.Latitude, &loc.Longitude)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("FindPublicLocationByShareLink: failed to query row for query %s. %w", query, err)
+	default:
+		return &loc, nil
+	}
+}
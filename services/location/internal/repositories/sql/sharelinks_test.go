@@ -0,0 +1,149 @@
+package sqlrepository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+const findPublicLocationByShareLinkQuery = "WITH link AS (UPDATE share_links SET accesses = accesses + 1 WHERE id = $1 AND expires_at > now() RETURNING location_id) " +
+	"SELECT l.name, l.address, l.latitude, l.longitude FROM locations l INNER JOIN link ON link.location_id = l.id"
+
+func TestCreateShareLinkWithLocationNotFound(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	link := models.NewShareLink(models.NewID(), models.NewID(), time.Now().Add(time.Hour))
+
+	prep := mock.ExpectPrepare("INSERT INTO share_links (id, location_id, user_id, expires_at) VALUES ($1, $2, $3, $4)")
+	prep.ExpectExec().
+		WithArgs(link.ID, link.Location, user.ID, link.ExpiresAt).
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	err := repo.CreateShareLink(ctx, link)
+	assert.True(t, errors.Is(err, usecases.ErrLocationNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateShareLinkWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	link := models.NewShareLink(models.NewID(), models.NewID(), time.Now().Add(time.Hour))
+
+	prep := mock.ExpectPrepare("INSERT INTO share_links (id, location_id, user_id, expires_at) VALUES ($1, $2, $3, $4)")
+	prep.ExpectExec().
+		WithArgs(link.ID, link.Location, user.ID, link.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.CreateShareLink(ctx, link)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetShareLinksWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	location := models.NewID()
+	expiresAt := time.Date(2021, 3, 8, 12, 0, 0, 0, time.UTC)
+	link := models.NewShareLink(models.NewID(), location, expiresAt)
+	link.Accesses = 3
+
+	rows := sqlmock.NewRows([]string{"id", "location_id", "expires_at", "accesses"}).
+		AddRow(link.ID, location, expiresAt, 3)
+
+	prep := mock.ExpectPrepare("SELECT id, location_id, expires_at, accesses FROM share_links WHERE location_id = $1 ORDER BY expires_at")
+	prep.ExpectQuery().WithArgs(location).WillReturnRows(rows)
+
+	links, err := repo.GetShareLinks(newTestContext(), location)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ShareLinks{link}, *links)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteShareLinkWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+
+	prep := mock.ExpectPrepare("DELETE FROM share_links WHERE id = $1")
+	prep.ExpectExec().WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.DeleteShareLink(newTestContext(), id)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindPublicLocationByShareLinkWithoutUser(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	latitude, longitude := 48.8606111, 2.3376
+
+	rows := sqlmock.NewRows([]string{"name", "address", "latitude", "longitude"}).
+		AddRow("Home", "1 rue de la Poste, 75001 Paris", latitude, longitude)
+
+	prep := mock.ExpectPrepare(findPublicLocationByShareLinkQuery)
+	prep.ExpectQuery().WithArgs(id).WillReturnRows(rows)
+
+	loc, err := repo.FindPublicLocationByShareLink(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.PublicLocation{
+		Name:      "Home",
+		Address:   "1 rue de la Poste, 75001 Paris",
+		Latitude:  &latitude,
+		Longitude: &longitude,
+	}, loc)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindPublicLocationByShareLinkWithExpiredLink(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+
+	prep := mock.ExpectPrepare(findPublicLocationByShareLinkQuery)
+	prep.ExpectQuery().WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"name", "address", "latitude", "longitude"}))
+
+	loc, err := repo.FindPublicLocationByShareLink(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Nil(t, loc)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindPublicLocationByShareLinkWithoutCoordinates(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+
+	rows := sqlmock.NewRows([]string{"name", "address", "latitude", "longitude"}).
+		AddRow("Home", "1 rue de la Poste, 75001 Paris", driver.Value(nil), driver.Value(nil))
+
+	prep := mock.ExpectPrepare(findPublicLocationByShareLinkQuery)
+	prep.ExpectQuery().WithArgs(id).WillReturnRows(rows)
+
+	loc, err := repo.FindPublicLocationByShareLink(context.Background(), id)
+	assert.NoError(t, err)
+	if assert.NotNil(t, loc) {
+		assert.Nil(t, loc.Latitude)
+		assert.Nil(t, loc.Longitude)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,139 @@
+package sqlrepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+)
+
+// visibleLocations returns the condition matching locations of table owned by the user given as param,
+// owned by a group they are a member of, or shared with them, either directly or along with their category
+func visibleLocations(table, param string) string {
+	return "((" + table + ".group_id IS NULL AND " + table + ".user_id = " + param + ")" +
+		" OR " + table + ".group_id IN (SELECT group_id FROM group_members WHERE user_id = " + param + ")" +
+		" OR " + table + ".id IN (SELECT location_id FROM location_shares WHERE user_id = " + param + ")" +
+		" OR " + table + ".category_id IN (SELECT category_id FROM category_shares WHERE user_id = " + param + "))"
+}
+
+// locationRole returns the expression of the role of the user given as param on each location of table:
+// owner of their own locations, or the highest role granted on the location, on its category or by its group.
+// Group owners own the locations of the group, and other members edit them. Null for locations not visible to the user.
+func locationRole(table, param string) string {
+	return "CASE WHEN " + table + ".group_id IS NULL AND " + table + ".user_id = " + param + " THEN 'owner' ELSE (SELECT s.role FROM (" +
+		"SELECT role FROM location_shares WHERE location_id = " + table + ".id AND user_id = " + param +
+		" UNION ALL SELECT role FROM category_shares WHERE category_id = " + table + ".category_id AND user_id = " + param +
+		" UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = " + table + ".group_id AND user_id = " + param +
+		") s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END"
+}
+
+// GetLocationShares returns the shares of a location, sorted by user
+func (r *SQLRepository) GetLocationShares(ctx context.Context, id models.ID) (*models.Shares, error) {
+	return r.getShares(ctx, "GetLocationShares", "SELECT user_id, role FROM location_shares WHERE location_id = $1 ORDER BY user_id", id)
+}
+
+// SetLocationShare grants a role on a location to a user, replacing the role already granted to them if any
+func (r *SQLRepository) SetLocationShare(ctx context.Context, id models.ID, share *models.Share) error {
+	query := "INSERT INTO location_shares (location_id, user_id, role) VALUES ($1, $2, $3) " +
+		"ON CONFLICT (location_id, user_id) DO UPDATE SET role = EXCLUDED.role"
+	err := r.setShare(ctx, "SetLocationShare", query, id, share)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("SetLocationShare: location %s not found. %w", id, usecases.ErrLocationNotFound)
+	}
+	return err
+}
+
+// DeleteLocationShare revokes the role granted on a location to a user
+func (r *SQLRepository) DeleteLocationShare(ctx context.Context, id models.ID, user models.ID) error {
+	return r.deleteShare(ctx, "DeleteLocationShare", "DELETE FROM location_shares WHERE location_id = $1 AND user_id = $2", id, user)
+}
+
+// GetCategoryShares returns the shares of a category, sorted by user
+func (r *SQLRepository) GetCategoryShares(ctx context.Context, id models.ID) (*models.Shares, error) {
+	return r.getShares(ctx, "GetCategoryShares", "SELECT user_id, role FROM category_shares WHERE category_id = $1 ORDER BY user_id", id)
+}
+
+// SetCategoryShare grants a role on all locations of a category to a user, replacing the role already granted to them if any
+func (r *SQLRepository) SetCategoryShare(ctx context.Context, id models.ID, share *models.Share) error {
+	query := "INSERT INTO category_shares (category_id, user_id, role) VALUES ($1, $2, $3) " +
+		"ON CONFLICT (category_id, user_id) DO UPDATE SET role = EXCLUDED.role"
+	err := r.setShare(ctx, "SetCategoryShare", query, id, share)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("SetCategoryShare: category %s not found. %w", id, usecases.ErrCategoryNotFound)
+	}
+	return err
+}
+
+// DeleteCategoryShare revokes the role granted on a category to a user
+func (r *SQLRepository) DeleteCategoryShare(ctx context.Context, id models.ID, user models.ID) error {
+	return r.deleteShare(ctx, "DeleteCategoryShare", "DELETE FROM category_shares WHERE category_id = $1 AND user_id = $2", id, user)
+}
+
+func (r *SQLRepository) getShares(ctx context.Context, name, query string, id models.ID) (*models.Shares, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to prepare context for query %s. %w", name, query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query context for query %s. %w", name, query, err)
+	}
+	defer rows.Close()
+
+	shares := make(models.Shares, 0)
+	for rows.Next() {
+		share := new(models.Share)
+		if err := rows.Scan(&share.User, &share.Role); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan SQL row. %w", name, err)
+		}
+		shares = append(shares, share)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows failed. %w", name, err)
+	}
+
+	return &shares, nil
+}
+
+func (r *SQLRepository) setShare(ctx context.Context, name, query string, id models.ID, share *models.Share) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%s: failed to prepare context for query %s. %w", name, query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id, share.User, string(share.Role))
+	if err != nil {
+		return fmt.Errorf("%s: failed to exec context for query %s. %w", name, query, err)
+	}
+
+	return nil
+}
+
+func (r *SQLRepository) deleteShare(ctx context.Context, name, query string, id models.ID, user models.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%s: failed to prepare context for query %s. %w", name, query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id, user)
+	if err != nil {
+		return fmt.Errorf("%s: failed to exec context for query %s. %w", name, query, err)
+	}
+
+	return nil
+}
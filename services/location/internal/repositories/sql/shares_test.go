@@ -0,0 +1,161 @@
+package sqlrepository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLocationSharesWithQueryError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+
+	query := "SELECT user_id, role FROM location_shares WHERE location_id = $1 ORDER BY user_id"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(id).WillReturnError(errors.New("failed"))
+
+	_, err := repo.GetLocationShares(newTestContext(), id)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLocationSharesWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	share1 := models.NewShare(models.NewID(), models.ShareRoleEditor)
+	share2 := models.NewShare(models.NewID(), models.ShareRoleViewer)
+
+	rows := sqlmock.NewRows([]string{"user_id", "role"}).
+		AddRow(share1.User, "editor").
+		AddRow(share2.User, "viewer")
+
+	query := "SELECT user_id, role FROM location_shares WHERE location_id = $1 ORDER BY user_id"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(id).WillReturnRows(rows)
+
+	shares, err := repo.GetLocationShares(newTestContext(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Shares{share1, share2}, *shares)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetLocationShareWithLocationNotFound(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	share := models.NewShare(models.NewID(), models.ShareRoleEditor)
+
+	query := "INSERT INTO location_shares (location_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT (location_id, user_id) DO UPDATE SET role = EXCLUDED.role"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, share.User, "editor").WillReturnError(&pq.Error{Code: "23503"})
+
+	err := repo.SetLocationShare(newTestContext(), id, share)
+	assert.True(t, errors.Is(err, usecases.ErrLocationNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetLocationShareWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	share := models.NewShare(models.NewID(), models.ShareRoleEditor)
+
+	query := "INSERT INTO location_shares (location_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT (location_id, user_id) DO UPDATE SET role = EXCLUDED.role"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, share.User, "editor").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetLocationShare(newTestContext(), id, share)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteLocationShareWithExecError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id, user := models.NewID(), models.NewID()
+
+	query := "DELETE FROM location_shares WHERE location_id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, user).WillReturnError(errors.New("failed"))
+
+	err := repo.DeleteLocationShare(newTestContext(), id, user)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteLocationShareWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id, user := models.NewID(), models.NewID()
+
+	query := "DELETE FROM location_shares WHERE location_id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, user).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.DeleteLocationShare(newTestContext(), id, user)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCategorySharesWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	share := models.NewShare(models.NewID(), models.ShareRoleOwner)
+
+	rows := sqlmock.NewRows([]string{"user_id", "role"}).AddRow(share.User, "owner")
+
+	query := "SELECT user_id, role FROM category_shares WHERE category_id = $1 ORDER BY user_id"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(id).WillReturnRows(rows)
+
+	shares, err := repo.GetCategoryShares(newTestContext(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Shares{share}, *shares)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetCategoryShareWithCategoryNotFound(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id := models.NewID()
+	share := models.NewShare(models.NewID(), models.ShareRoleViewer)
+
+	query := "INSERT INTO category_shares (category_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT (category_id, user_id) DO UPDATE SET role = EXCLUDED.role"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, share.User, "viewer").WillReturnError(&pq.Error{Code: "23503"})
+
+	err := repo.SetCategoryShare(newTestContext(), id, share)
+	assert.True(t, errors.Is(err, usecases.ErrCategoryNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategoryShareWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	id, user := models.NewID(), models.NewID()
+
+	query := "DELETE FROM category_shares WHERE category_id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, user).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.DeleteCategoryShare(newTestContext(), id, user)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,188 @@
+package sqlrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/geohash"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/lib/pq"
+)
+
+// geography expression of location coordinates, matching the index created when PostGIS is installed
+const locationGeography = "geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326))"
+
+// FindLocationsNear returns locations visible to the user within radius meters of center, filtered by category unless
+// cat is nil. Locations are sorted by distance, which is set on each of them, and annotated with the role of the user.
+// PostGIS is used when installed. Otherwise, candidates are selected using geohash prefixes
+// and their distance is computed with the haversine formula.
+func (r *SQLRepository) FindLocationsNear(ctx context.Context, cat *models.Category, center models.Point, radius float64) (*models.Locations, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindLocationsNear: Failed to get user from context")
+	}
+
+	postgis, err := r.hasPostGIS(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsNear: %w", err)
+	}
+
+	if postgis {
+		return r.findLocationsNearWithPostGIS(ctx, user, cat, center, radius)
+	}
+	return r.findLocationsNearWithGeohash(ctx, user, cat, center, radius)
+}
+
+func (r *SQLRepository) findLocationsNearWithPostGIS(ctx context.Context, user *models.User, cat *models.Category, center models.Point, radius float64) (*models.Locations, error) {
+	centerGeography := "geography(ST_SetSRID(ST_MakePoint($2::double precision, $3::double precision), 4326))"
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$1") + ", " +
+		"ST_Distance(" + locationGeography + ", " + centerGeography + ") AS distance FROM locations " +
+		"WHERE " + visibleLocations("locations", "$1") + " AND ST_DWithin(" + locationGeography + ", " + centerGeography + ", $4)"
+	args := []interface{}{user.ID, center.Longitude, center.Latitude, radius}
+	if cat != nil {
+		query += " AND category_id = $5"
+		args = append(args, cat.ID)
+	}
+	query += " ORDER BY distance"
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsNear: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsNear: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	locs := make(models.Locations, 0)
+	for rows.Next() {
+		loc := &models.Location{Distance: new(float64)}
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role, loc.Distance); err != nil {
+			return nil, fmt.Errorf("FindLocationsNear: failed to scan SQL row. %w", err)
+		}
+		locs = append(locs, loc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindLocationsNear: rows failed. %w", err)
+	}
+
+	return &locs, nil
+}
+
+func (r *SQLRepository) findLocationsNearWithGeohash(ctx context.Context, user *models.User, cat *models.Category, center models.Point, radius float64) (*models.Locations, error) {
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$1") +
+		" FROM locations WHERE " + visibleLocations("locations", "$1") + " AND geohash IS NOT NULL"
+	args := []interface{}{user.ID}
+
+	// Without prefixes, the radius is so large that every location is a candidate
+	if prefixes := geohash.CoveringPrefixes(center.Latitude, center.Longitude, radius); prefixes != nil {
+		conditions := make([]string, len(prefixes))
+		for i, prefix := range prefixes {
+			args = append(args, prefix+"%")
+			conditions[i] = fmt.Sprintf("geohash LIKE $%d", len(args))
+		}
+		query += " AND (" + strings.Join(conditions, " OR ") + ")"
+	}
+	if cat != nil {
+		args = append(args, cat.ID)
+		query += fmt.Sprintf(" AND category_id = $%d", len(args))
+	}
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsNear: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsNear: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	locs := make(models.Locations, 0)
+	for rows.Next() {
+		loc := new(models.Location)
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role); err != nil {
+			return nil, fmt.Errorf("FindLocationsNear: failed to scan SQL row. %w", err)
+		}
+
+		distance := center.DistanceTo(models.NewPoint(*loc.Latitude, *loc.Longitude))
+		if distance <= radius {
+			loc.Distance = &distance
+			locs = append(locs, loc)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindLocationsNear: rows failed. %w", err)
+	}
+
+	sort.SliceStable(locs, func(i, j int) bool {
+		return *locs[i].Distance < *locs[j].Distance
+	})
+
+	return &locs, nil
+}
+
+// FindLocationsInBoundingBox returns locations visible to the user inside bbox, filtered by category unless cat is nil.
+// Locations are annotated with the role of the user.
+func (r *SQLRepository) FindLocationsInBoundingBox(ctx context.Context, cat *models.Category, bbox models.BoundingBox) (*models.Locations, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindLocationsInBoundingBox: Failed to get user from context")
+	}
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$1") +
+		" FROM locations WHERE " + visibleLocations("locations", "$1") + " AND latitude BETWEEN $2 AND $3"
+	if bbox.CrossesAntimeridian() {
+		query += " AND (longitude >= $4 OR longitude <= $5)"
+	} else {
+		query += " AND longitude BETWEEN $4 AND $5"
+	}
+	args := []interface{}{user.ID, bbox.South, bbox.North, bbox.West, bbox.East}
+	if cat != nil {
+		query += " AND category_id = $6"
+		args = append(args, cat.ID)
+	}
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsInBoundingBox: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsInBoundingBox: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	locs := make(models.Locations, 0)
+	for rows.Next() {
+		loc := new(models.Location)
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role); err != nil {
+			return nil, fmt.Errorf("FindLocationsInBoundingBox: failed to scan SQL row. %w", err)
+		}
+		locs = append(locs, loc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindLocationsInBoundingBox: rows failed. %w", err)
+	}
+
+	return &locs, nil
+}
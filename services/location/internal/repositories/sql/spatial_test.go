@@ -0,0 +1,303 @@
+package sqlrepository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const postGISQuery = "SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'postgis')"
+
+func expectPostGIS(mock sqlmock.Sqlmock, installed bool) {
+	mock.ExpectQuery(postGISQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(installed))
+}
+
+func TestFindLocationsNearWithPostGISDetectionError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	mock.ExpectQuery(postGISQuery).WillReturnError(errors.New("failed"))
+
+	locs, err := repo.FindLocationsNear(newTestContext(), nil, models.NewPoint(48.8606111, 2.3376), 1000)
+	assert.Error(t, err)
+	assert.Nil(t, locs)
+	assert.Nil(t, repo.extensions.postgis)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsNearWithPostGIS(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc.SetCoordinates(48.8606111, 2.3376)
+	loc.Role = models.ShareRoleOwner
+	distance := 125.5
+	loc.Distance = &distance
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "distance"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, *loc.Latitude, *loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner", distance)
+
+	expectPostGIS(mock, true)
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", ST_Distance(geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)), geography(ST_SetSRID(ST_MakePoint($2::double precision, $3::double precision), 4326))) AS distance FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND ST_DWithin(geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)), geography(ST_SetSRID(ST_MakePoint($2::double precision, $3::double precision), 4326)), $4) AND category_id = $5 ORDER BY distance"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, 2.3376, 48.8606, 1000.0, cat.ID).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsNear(ctx, cat, models.NewPoint(48.8606, 2.3376), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsNearWithPostGISOfGroup(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	// Created by another member of a group of the user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+	loc.SetGroup(models.NewID())
+	loc.Role = models.ShareRoleEditor
+	distance := 125.5
+	loc.Distance = &distance
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "distance"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, *loc.Latitude, *loc.Longitude, loc.Category, loc.User, *loc.Group, nil, "editor", distance)
+
+	expectPostGIS(mock, true)
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", ST_Distance(geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)), geography(ST_SetSRID(ST_MakePoint($2::double precision, $3::double precision), 4326))) AS distance FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND ST_DWithin(geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)), geography(ST_SetSRID(ST_MakePoint($2::double precision, $3::double precision), 4326)), $4) ORDER BY distance"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, 2.3376, 48.8606, 1000.0).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsNear(ctx, nil, models.NewPoint(48.8606, 2.3376), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsNearWithPostGISShared(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	// Shared with the user by another user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+	loc.Role = models.ShareRoleViewer
+	distance := 125.5
+	loc.Distance = &distance
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role", "distance"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, *loc.Latitude, *loc.Longitude, loc.Category, loc.User, loc.Group, nil, "viewer", distance)
+
+	expectPostGIS(mock, true)
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		", ST_Distance(geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)), geography(ST_SetSRID(ST_MakePoint($2::double precision, $3::double precision), 4326))) AS distance FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND ST_DWithin(geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)), geography(ST_SetSRID(ST_MakePoint($2::double precision, $3::double precision), 4326)), $4) ORDER BY distance"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, 2.3376, 48.8606, 1000.0).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsNear(ctx, nil, models.NewPoint(48.8606, 2.3376), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsNearWithGeohash(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	catID := models.NewID()
+	near := models.NewLocation(models.NewID(), "Near", "1 rue de la Poste, 75001 Paris", catID, user.ID)
+	near.SetCoordinates(48.8606111, 2.3376)
+	nearest := models.NewLocation(models.NewID(), "Nearest", "2 rue de la Poste, 75001 Paris", catID, user.ID)
+	nearest.SetCoordinates(48.8606, 2.3377)
+	// Shares a covering prefix but lies outside radius
+	far := models.NewLocation(models.NewID(), "Far", "3 rue de la Poste, 75001 Paris", catID, user.ID)
+	far.SetCoordinates(48.87, 2.35)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"})
+	for _, loc := range []*models.Location{near, nearest, far} {
+		rows.AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, *loc.Latitude, *loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner")
+	}
+
+	expectPostGIS(mock, false)
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND geohash IS NOT NULL AND (geohash LIKE $2 OR geohash LIKE $3 OR geohash LIKE $4 OR geohash LIKE $5 OR geohash LIKE $6 OR geohash LIKE $7 OR geohash LIKE $8 OR geohash LIKE $9 OR geohash LIKE $10)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().
+		WithArgs(user.ID, "u09tvn%", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsNear(ctx, nil, models.NewPoint(48.8606, 2.3377), 500)
+	if assert.NoError(t, err) && assert.Len(t, *locs, 2) {
+		assert.Equal(t, nearest.ID, (*locs)[0].ID)
+		assert.Equal(t, 0.0, *(*locs)[0].Distance)
+		assert.Equal(t, near.ID, (*locs)[1].ID)
+		assert.InDelta(t, 7.5, *(*locs)[1].Distance, 0.5)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsNearWithGeohashAndLargeRadius(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+
+	// Detection result is remembered
+	installed := false
+	repo.extensions.postgis = &installed
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND geohash IS NOT NULL AND category_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, cat.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}))
+
+	locs, err := repo.FindLocationsNear(ctx, cat, models.NewPoint(48.8606, 2.3377), 6000000)
+	assert.NoError(t, err)
+	assert.Empty(t, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsInBoundingBoxWithPrepareError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND latitude BETWEEN $2 AND $3 AND longitude BETWEEN $4 AND $5"
+	mock.ExpectPrepare(query).WillReturnError(errors.New("failed"))
+
+	locs, err := repo.FindLocationsInBoundingBox(newTestContext(), nil, models.BoundingBox{West: 2.25, South: 48.81, East: 2.42, North: 48.9})
+	assert.Error(t, err)
+	assert.Nil(t, locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsInBoundingBoxWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, user.ID)
+	loc.SetCoordinates(48.8606111, 2.3376)
+	loc.Role = models.ShareRoleOwner
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, *loc.Latitude, *loc.Longitude, loc.Category, loc.User, loc.Group, nil, "owner")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND latitude BETWEEN $2 AND $3 AND longitude BETWEEN $4 AND $5 AND category_id = $6"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, 48.81, 48.9, 2.25, 2.42, cat.ID).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsInBoundingBox(ctx, cat, models.BoundingBox{West: 2.25, South: 48.81, East: 2.42, North: 48.9})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsInBoundingBoxOfGroup(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	// Created by another member of a group of the user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+	loc.SetGroup(models.NewID())
+	loc.Role = models.ShareRoleEditor
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, *loc.Latitude, *loc.Longitude, loc.Category, loc.User, *loc.Group, nil, "editor")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND latitude BETWEEN $2 AND $3 AND longitude BETWEEN $4 AND $5"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, 48.81, 48.9, 2.25, 2.42).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsInBoundingBox(ctx, nil, models.BoundingBox{West: 2.25, South: 48.81, East: 2.42, North: 48.9})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsInBoundingBoxShared(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	// Shared with the user by another user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+	loc.Role = models.ShareRoleViewer
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, *loc.Latitude, *loc.Longitude, loc.Category, loc.User, loc.Group, nil, "viewer")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND latitude BETWEEN $2 AND $3 AND longitude BETWEEN $4 AND $5"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, 48.81, 48.9, 2.25, 2.42).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsInBoundingBox(ctx, nil, models.BoundingBox{West: 2.25, South: 48.81, East: 2.42, North: 48.9})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Locations{loc}, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsInBoundingBoxAcrossAntimeridian(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name), " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND latitude BETWEEN $2 AND $3 AND (longitude >= $4 OR longitude <= $5)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, -20.0, 20.0, 170.0, -170.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}))
+
+	locs, err := repo.FindLocationsInBoundingBox(ctx, nil, models.BoundingBox{West: 170, South: -20, East: -170, North: 20})
+	assert.NoError(t, err)
+	assert.Empty(t, *locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,7 +3,9 @@ package sqlrepository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/dlmiddlecote/sqlstats"
@@ -20,6 +22,11 @@ var (
 const (
 	// PostgreSQLDriver describes SQL driver for Postgres databases
 	PostgreSQLDriver = "postgres"
+
+	// pgForeignKeyViolation is the Postgres error code raised when a foreign key constraint is violated
+	pgForeignKeyViolation = "23503"
+	// pgUniqueViolation is the Postgres error code raised when a unique constraint is violated
+	pgUniqueViolation = "23505"
 )
 
 // SQLRepository represents a repository using SQL to query
@@ -27,6 +34,7 @@ type SQLRepository struct {
 	Config             *Config
 	db                 *sql.DB
 	prometheusRegistry prometheus.Registerer
+	extensions         *extensions
 }
 
 // NewSQLRepository creates a new SQLRepository
@@ -35,7 +43,62 @@ func NewSQLRepository(config *Config, registry prometheus.Registerer) *SQLReposi
 		config,
 		nil,
 		registry,
+		newExtensions(),
+	}
+}
+
+type txContextKey struct{}
+
+// dbtx is implemented by both *sql.DB and *sql.Tx, so that queries run the same way inside and outside transactions
+type dbtx interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn returns the transaction carried by ctx, or DB handler outside transactions
+func (r *SQLRepository) conn(ctx context.Context) dbtx {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return r.db
+}
+
+// WithinTx runs fn in a single transaction. Repository operations called with the context given to fn
+// run in the transaction, which is committed when fn succeeds and rolled back otherwise.
+// Nested calls join the ongoing transaction.
+func (r *SQLRepository) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
 	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("WithinTx: failed to begin transaction. %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("WithinTx: failed to commit transaction. %w", err)
+	}
+
+	return nil
+}
+
+// extensions remembers which optional database extensions are installed.
+// Detection is done on first use, and retried until it succeeds.
+type extensions struct {
+	mu      sync.Mutex
+	postgis *bool
+}
+
+func newExtensions() *extensions {
+	return &extensions{}
 }
 
 // Config describes configs and options of SQL repository
@@ -90,6 +153,26 @@ func (r *SQLRepository) Ping(ctx context.Context) error {
 	return nil
 }
 
+// hasPostGIS tells whether PostGIS extension is installed in database
+func (r *SQLRepository) hasPostGIS(ctx context.Context) (bool, error) {
+	r.extensions.mu.Lock()
+	defer r.extensions.mu.Unlock()
+
+	if r.extensions.postgis != nil {
+		return *r.extensions.postgis, nil
+	}
+
+	query := "SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'postgis')"
+	var installed bool
+	if err := r.db.QueryRowContext(ctx, query).Scan(&installed); err != nil {
+		return false, fmt.Errorf("failed to detect PostGIS extension. %w", err)
+	}
+
+	logger.Infof("PostGIS extension installed : %t", installed)
+	r.extensions.postgis = &installed
+	return installed, nil
+}
+
 func (r *SQLRepository) dsn() string {
 	var sslmode string
 	if r.Config.SSL {
@@ -117,3 +200,21 @@ func (r *SQLRepository) Close() error {
 
 	return nil
 }
+
+// isForeignKeyViolation tells whether err was raised by Postgres because of a foreign key constraint
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation
+}
+
+// isUniqueViolation tells whether err was raised by Postgres because of a unique constraint
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation
+}
+
+// isConstraintViolation tells whether err was raised by Postgres because of the constraint named name
+func isConstraintViolation(err error, name string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Constraint == name
+}
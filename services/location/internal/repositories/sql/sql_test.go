@@ -24,6 +24,7 @@ func newSQLMock(t *testing.T) (*SQLRepository, sqlmock.Sqlmock) {
 		&Config{QueryTimeout: 5 * time.Second},
 		db,
 		prometheus.NewRegistry(),
+		newExtensions(),
 	}
 
 	return repo, mock
@@ -106,3 +107,62 @@ func TestCloseRepositoryWithError(t *testing.T) {
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestWithinTxWithBeginError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	mock.ExpectBegin().WillReturnError(errors.New("failed"))
+
+	err := repo.WithinTx(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithinTxWithRollback(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM tags").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	failure := errors.New("failed")
+	err := repo.WithinTx(context.Background(), func(ctx context.Context) error {
+		if _, err := repo.conn(ctx).ExecContext(ctx, "DELETE FROM tags"); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	assert.Equal(t, failure, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithinTxWithCommit(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM tags").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM categories").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.WithinTx(context.Background(), func(ctx context.Context) error {
+		if _, err := repo.conn(ctx).ExecContext(ctx, "DELETE FROM tags"); err != nil {
+			return err
+		}
+
+		// Nested transactions join the ongoing one
+		return repo.WithinTx(ctx, func(ctx context.Context) error {
+			_, err := repo.conn(ctx).ExecContext(ctx, "DELETE FROM categories")
+			return err
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
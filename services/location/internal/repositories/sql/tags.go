@@ -0,0 +1,273 @@
+package sqlrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases"
+	"github.com/lib/pq"
+)
+
+// locationTags returns the expression selecting the sorted tag names of each location of table, as a text array
+func locationTags(table string) string {
+	return "ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = " + table + ".id ORDER BY t.name)"
+}
+
+// CreateTag creates a new user tag in repository
+func (r *SQLRepository) CreateTag(ctx context.Context, tag *models.Tag) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "INSERT INTO tags (id, name, user_id) VALUES ($1, $2, $3)"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("CreateTag: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, tag.ID, tag.Name, tag.User)
+	switch {
+	case isUniqueViolation(err):
+		return fmt.Errorf("CreateTag: tag %s already exists. %w", tag.Name, usecases.ErrTagAlreadyExists)
+	case err != nil:
+		return fmt.Errorf("CreateTag: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// GetTags returns all user tags stored in the repository, sorted by name
+func (r *SQLRepository) GetTags(ctx context.Context) (*models.Tags, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT id, name, user_id FROM tags WHERE user_id = $1 ORDER BY name"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("GetTags: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("GetTags: Failed to get user from context")
+	}
+
+	rows, err := stmt.QueryContext(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("GetTags: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	tags := make(models.Tags, 0)
+	for rows.Next() {
+		tag := new(models.Tag)
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.User); err != nil {
+			return nil, fmt.Errorf("GetTags: failed to scan SQL row. %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetTags: rows failed. %w", err)
+	}
+
+	return &tags, nil
+}
+
+// FindTagByID returns the user tag that matches the requested ID or nil
+func (r *SQLRepository) FindTagByID(ctx context.Context, id models.ID) (*models.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT id, name, user_id FROM tags WHERE id = $1 AND user_id = $2"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindTagByID: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindTagByID: Failed to get user from context")
+	}
+
+	var tag models.Tag
+	err = stmt.QueryRowContext(ctx, id, user.ID).Scan(&tag.ID, &tag.Name, &tag.User)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("FindTagByID: failed to query row for query %s. %w", query, err)
+	default:
+		return &tag, nil
+	}
+}
+
+// FindTagByName returns the user tag that matches the requested name or nil
+func (r *SQLRepository) FindTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "SELECT id, name, user_id FROM tags WHERE name = $1 AND user_id = $2"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindTagByName: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindTagByName: Failed to get user from context")
+	}
+
+	var tag models.Tag
+	err = stmt.QueryRowContext(ctx, name, user.ID).Scan(&tag.ID, &tag.Name, &tag.User)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("FindTagByName: failed to query row for query %s. %w", query, err)
+	default:
+		return &tag, nil
+	}
+}
+
+// UpdateTag renames user tag in repository
+func (r *SQLRepository) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "UPDATE tags SET name = $1 WHERE id = $2 AND user_id = $3"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("UpdateTag: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("UpdateTag: Failed to get user from context")
+	}
+
+	_, err = stmt.ExecContext(ctx, tag.Name, tag.ID, user.ID)
+	switch {
+	case isUniqueViolation(err):
+		return fmt.Errorf("UpdateTag: tag %s already exists. %w", tag.Name, usecases.ErrTagAlreadyExists)
+	case err != nil:
+		return fmt.Errorf("UpdateTag: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// DeleteTag deletes user tag in repository. Tag is removed from locations by the database.
+func (r *SQLRepository) DeleteTag(ctx context.Context, id models.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "DELETE FROM tags WHERE id = $1 AND user_id = $2"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("DeleteTag: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("DeleteTag: Failed to get user from context")
+	}
+
+	_, err = stmt.ExecContext(ctx, id, user.ID)
+	if err != nil {
+		return fmt.Errorf("DeleteTag: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// SetLocationTags replaces the tags of a user location by the tags named in names.
+// Missing tags are created. A single statement is used, so that tags are replaced atomically.
+func (r *SQLRepository) SetLocationTags(ctx context.Context, id models.ID, names []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	query := "WITH input (name) AS (SELECT DISTINCT unnest($3::text[])), " +
+		"created AS (INSERT INTO tags (id, name, user_id) SELECT gen_random_uuid(), name, $2::uuid FROM input ON CONFLICT (name, user_id) DO NOTHING RETURNING id), " +
+		"assigned (id) AS (SELECT id FROM tags WHERE user_id = $2::uuid AND name IN (SELECT name FROM input) UNION ALL SELECT id FROM created), " +
+		"removed AS (DELETE FROM location_tags WHERE location_id = $1::uuid AND tag_id NOT IN (SELECT id FROM assigned)) " +
+		"INSERT INTO location_tags (location_id, tag_id) SELECT $1::uuid, id FROM assigned ON CONFLICT DO NOTHING"
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("SetLocationTags: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("SetLocationTags: Failed to get user from context")
+	}
+
+	_, err = stmt.ExecContext(ctx, id, user.ID, pq.Array(names))
+	if err != nil {
+		return fmt.Errorf("SetLocationTags: failed to exec context for query %s. %w", query, err)
+	}
+
+	return nil
+}
+
+// FindLocationsByTags returns locations visible to the user having all tags named in names when matchAll is true,
+// or any of them otherwise, filtered by category unless cat is nil. Names must not contain duplicates.
+// Tags of shared locations are the tags of their owner. Locations are annotated with the role of the user.
+func (r *SQLRepository) FindLocationsByTags(ctx context.Context, cat *models.Category, names []string, matchAll bool) (*models.Locations, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Config.QueryTimeout)
+	defer cancel()
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("FindLocationsByTags: Failed to get user from context")
+	}
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + locationTags("locations") + ", " + locationRole("locations", "$1") +
+		" FROM locations WHERE " + visibleLocations("locations", "$1") + " AND id IN (SELECT lt.location_id FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE t.name = ANY($2)"
+	args := []interface{}{user.ID, pq.Array(names)}
+	if matchAll {
+		args = append(args, len(names))
+		query += " GROUP BY lt.location_id HAVING count(*) = $3"
+	}
+	query += ")"
+	if cat != nil {
+		args = append(args, cat.ID)
+		query += fmt.Sprintf(" AND category_id = $%d", len(args))
+	}
+
+	stmt, err := r.conn(ctx).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsByTags: failed to prepare context for query %s. %w", query, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsByTags: failed to query context for query %s. %w", query, err)
+	}
+	defer rows.Close()
+
+	locs := make(models.Locations, 0)
+	for rows.Next() {
+		loc := new(models.Location)
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.Address, &loc.Components.Street, &loc.Components.HouseNumber, &loc.Components.PostalCode, &loc.Components.Locality, &loc.Components.Region, &loc.Components.CountryCode, &loc.Latitude, &loc.Longitude, &loc.Category, &loc.User, &loc.Group, pq.Array(&loc.Tags), &loc.Role); err != nil {
+			return nil, fmt.Errorf("FindLocationsByTags: failed to scan SQL row. %w", err)
+		}
+		locs = append(locs, loc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindLocationsByTags: rows failed. %w", err)
+	}
+
+	return &locs, nil
+}
@@ -0,0 +1,306 @@
+package sqlrepository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tagsQuery            = "ARRAY(SELECT t.name FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE lt.location_id = locations.id ORDER BY t.name)"
+	setLocationTagsQuery = "WITH input (name) AS (SELECT DISTINCT unnest($3::text[])), " +
+		"created AS (INSERT INTO tags (id, name, user_id) SELECT gen_random_uuid(), name, $2::uuid FROM input ON CONFLICT (name, user_id) DO NOTHING RETURNING id), " +
+		"assigned (id) AS (SELECT id FROM tags WHERE user_id = $2::uuid AND name IN (SELECT name FROM input) UNION ALL SELECT id FROM created), " +
+		"removed AS (DELETE FROM location_tags WHERE location_id = $1::uuid AND tag_id NOT IN (SELECT id FROM assigned)) " +
+		"INSERT INTO location_tags (location_id, tag_id) SELECT $1::uuid, id FROM assigned ON CONFLICT DO NOTHING"
+)
+
+func TestCreateTagWithExecError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	tag := models.NewTag(models.NewID(), "Favorite", models.NewID())
+
+	query := "INSERT INTO tags (id, name, user_id) VALUES ($1, $2, $3)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(tag.ID, tag.Name, tag.User).WillReturnError(errors.New("failed"))
+
+	err := repo.CreateTag(newTestContext(), tag)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTagWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	tag := models.NewTag(models.NewID(), "Favorite", models.NewID())
+
+	query := "INSERT INTO tags (id, name, user_id) VALUES ($1, $2, $3)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(tag.ID, tag.Name, tag.User).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.CreateTag(newTestContext(), tag)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTagsWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	tag1 := models.NewTag(models.NewID(), "Favorite", user.ID)
+	tag2 := models.NewTag(models.NewID(), "Vegan", user.ID)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "user_id"}).
+		AddRow(tag1.ID, tag1.Name, tag1.User).
+		AddRow(tag2.ID, tag2.Name, tag2.User)
+
+	query := "SELECT id, name, user_id FROM tags WHERE user_id = $1 ORDER BY name"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID).WillReturnRows(rows)
+
+	tags, err := repo.GetTags(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Tags{tag1, tag2}, tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindTagByIDWithNoResult(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	id := models.NewID()
+
+	query := "SELECT id, name, user_id FROM tags WHERE id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(id, user.ID).WillReturnRows(sqlmock.NewRows(nil))
+
+	tag, err := repo.FindTagByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Nil(t, tag)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindTagByIDWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	tag := models.NewTag(models.NewID(), "Favorite", user.ID)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "user_id"}).AddRow(tag.ID, tag.Name, tag.User)
+
+	query := "SELECT id, name, user_id FROM tags WHERE id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(tag.ID, user.ID).WillReturnRows(rows)
+
+	returnedTag, err := repo.FindTagByID(ctx, tag.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, tag, returnedTag)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindTagByNameWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	tag := models.NewTag(models.NewID(), "Favorite", user.ID)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "user_id"}).AddRow(tag.ID, tag.Name, tag.User)
+
+	query := "SELECT id, name, user_id FROM tags WHERE name = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(tag.Name, user.ID).WillReturnRows(rows)
+
+	returnedTag, err := repo.FindTagByName(ctx, tag.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, tag, returnedTag)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateTagWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	tag := models.NewTag(models.NewID(), "Favorite", user.ID)
+
+	query := "UPDATE tags SET name = $1 WHERE id = $2 AND user_id = $3"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(tag.Name, tag.ID, user.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.UpdateTag(ctx, tag)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTagWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	id := models.NewID()
+
+	query := "DELETE FROM tags WHERE id = $1 AND user_id = $2"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(id, user.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.DeleteTag(ctx, id)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetLocationTagsWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	id := models.NewID()
+	names := []string{"Favorite", "Vegan"}
+
+	prep := mock.ExpectPrepare(setLocationTagsQuery)
+	prep.ExpectExec().WithArgs(id, user.ID, pq.Array(names)).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := repo.SetLocationTags(ctx, id, names)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetLocationTagsWithExecError(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	id := models.NewID()
+
+	prep := mock.ExpectPrepare(setLocationTagsQuery)
+	prep.ExpectExec().WithArgs(id, user.ID, pq.Array([]string{})).WillReturnError(errors.New("failed"))
+
+	err := repo.SetLocationTags(ctx, id, []string{})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsByTagsMatchingAllWithSuccess(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	names := []string{"Favorite", "Vegan"}
+
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), user.ID)
+	loc.SetTags([]string{"Favorite", "Outdoor", "Vegan"})
+	loc.Role = models.ShareRoleOwner
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, "{Favorite,Outdoor,Vegan}", "owner")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + tagsQuery + ", " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND id IN (SELECT lt.location_id FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE t.name = ANY($2) GROUP BY lt.location_id HAVING count(*) = $3)"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, pq.Array(names), 2).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsByTags(ctx, nil, names, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Locations{loc}, locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsByTagsMatchingAnyWithCategory(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	names := []string{"Favorite", "Vegan"}
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + tagsQuery + ", " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND id IN (SELECT lt.location_id FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE t.name = ANY($2)) AND category_id = $3"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, pq.Array(names), cat.ID).WillReturnRows(sqlmock.NewRows(nil))
+
+	locs, err := repo.FindLocationsByTags(ctx, cat, names, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Locations{}, locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsByTagsOfGroup(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	names := []string{"Favorite"}
+
+	// Created by another member of a group of the user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetGroup(models.NewID())
+	loc.Role = models.ShareRoleEditor
+	loc.SetTags([]string{"Favorite"})
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, *loc.Group, "{Favorite}", "editor")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + tagsQuery + ", " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND id IN (SELECT lt.location_id FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE t.name = ANY($2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, pq.Array(names)).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsByTags(ctx, nil, names, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Locations{loc}, locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLocationsByTagsShared(t *testing.T) {
+	repo, mock := newSQLMock(t)
+	defer repo.Close()
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+
+	names := []string{"Favorite"}
+
+	// Shared with the user by another user
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Role = models.ShareRoleViewer
+	loc.SetTags([]string{"Favorite"})
+
+	rows := sqlmock.NewRows([]string{"id", "name", "address", "street", "house_number", "postal_code", "locality", "region", "country_code", "latitude", "longitude", "category_id", "user_id", "group_id", "tags", "role"}).
+		AddRow(loc.ID, loc.Name, loc.Address, loc.Components.Street, loc.Components.HouseNumber, loc.Components.PostalCode, loc.Components.Locality, loc.Components.Region, loc.Components.CountryCode, loc.Latitude, loc.Longitude, loc.Category, loc.User, loc.Group, "{Favorite}", "viewer")
+
+	query := "SELECT id, name, address, street, house_number, postal_code, locality, region, country_code, latitude, longitude, category_id, user_id, group_id, " + tagsQuery + ", " +
+		"CASE WHEN locations.group_id IS NULL AND locations.user_id = $1 THEN 'owner' ELSE (SELECT s.role FROM (SELECT role FROM location_shares WHERE location_id = locations.id AND user_id = $1 UNION ALL SELECT role FROM category_shares WHERE category_id = locations.category_id AND user_id = $1 UNION ALL SELECT CASE WHEN role = 'owner' THEN 'owner' ELSE 'editor' END FROM group_members WHERE group_id = locations.group_id AND user_id = $1) s ORDER BY array_position(ARRAY['owner', 'editor', 'viewer'], s.role::text) LIMIT 1) END" +
+		" FROM locations WHERE ((locations.group_id IS NULL AND locations.user_id = $1) OR locations.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1) OR locations.id IN (SELECT location_id FROM location_shares WHERE user_id = $1) OR locations.category_id IN (SELECT category_id FROM category_shares WHERE user_id = $1)) AND id IN (SELECT lt.location_id FROM location_tags lt INNER JOIN tags t ON t.id = lt.tag_id WHERE t.name = ANY($2))"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(user.ID, pq.Array(names)).WillReturnRows(rows)
+
+	locs, err := repo.FindLocationsByTags(ctx, nil, names, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Locations{loc}, locs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,81 @@
+// Package sharelinks signs share links into tokens given to guests, and verifies them.
+// Tokens carry the ID of the link along with its expiry date, so that forged and expired
+// tokens are rejected without reaching the repository.
+package sharelinks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+var (
+	// ErrInvalidToken is raised when a token is malformed or its signature does not match
+	ErrInvalidToken = errors.New("invalid share link token")
+	// ErrExpiredToken is raised when a token is past its expiry date
+	ErrExpiredToken = errors.New("expired share link token")
+
+	encoding = base64.RawURLEncoding
+)
+
+// Length of token payloads: 16 bytes of link ID followed by 8 bytes of expiry unix time
+const payloadSize = 16 + 8
+
+// Signer signs share links with HMAC-SHA256
+type Signer struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewSigner creates a new Signer using secret as key
+func NewSigner(secret string) *Signer {
+	return &Signer{[]byte(secret), time.Now}
+}
+
+// Sign returns the token of the share link matching id, valid until expiresAt
+func (s *Signer) Sign(id models.ID, expiresAt time.Time) string {
+	payload := make([]byte, payloadSize)
+	copy(payload, id[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(expiresAt.Unix()))
+
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(s.signature(payload))
+}
+
+// Verify returns the ID of the share link token was signed for,
+// or an error when token is invalid or expired
+func (s *Signer) Verify(token string) (models.ID, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return models.NilID, ErrInvalidToken
+	}
+
+	payload, err := encoding.DecodeString(parts[0])
+	if err != nil || len(payload) != payloadSize {
+		return models.NilID, ErrInvalidToken
+	}
+	signature, err := encoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, s.signature(payload)) {
+		return models.NilID, ErrInvalidToken
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+	if !s.now().Before(expiresAt) {
+		return models.NilID, ErrExpiredToken
+	}
+
+	var id models.ID
+	copy(id[:], payload[:16])
+	return id, nil
+}
+
+func (s *Signer) signature(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,75 @@
+package sharelinks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSigner(secret string, now time.Time) *Signer {
+	signer := NewSigner(secret)
+	signer.now = func() time.Time { return now }
+	return signer
+}
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	signer := newTestSigner("secret", now)
+	id := models.NewID()
+
+	token := signer.Sign(id, now.Add(time.Hour))
+
+	verified, err := signer.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, id, verified)
+}
+
+func TestSignIsDeterministic(t *testing.T) {
+	signer := NewSigner("secret")
+	id := models.NewID()
+	expiresAt := time.Now().Add(time.Hour)
+
+	assert.Equal(t, signer.Sign(id, expiresAt), signer.Sign(id, expiresAt))
+	assert.NotEqual(t, signer.Sign(id, expiresAt), signer.Sign(id, expiresAt.Add(time.Second)))
+}
+
+func TestVerifyExpiredToken(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	token := newTestSigner("secret", now).Sign(models.NewID(), now.Add(time.Hour))
+
+	_, err := newTestSigner("secret", now.Add(time.Hour)).Verify(token)
+	assert.Equal(t, ErrExpiredToken, err)
+}
+
+func TestVerifyTokenSignedWithAnotherSecret(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	token := newTestSigner("other", now).Sign(models.NewID(), now.Add(time.Hour))
+
+	_, err := newTestSigner("secret", now).Verify(token)
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestVerifyTamperedToken(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	signer := newTestSigner("secret", now)
+	token := signer.Sign(models.NewID(), now.Add(time.Hour))
+	other := signer.Sign(models.NewID(), now.Add(24*time.Hour))
+
+	// Payload of another link with the signature of the first one
+	tampered := strings.Split(other, ".")[0] + "." + strings.Split(token, ".")[1]
+
+	_, err := signer.Verify(tampered)
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestVerifyMalformedToken(t *testing.T) {
+	signer := NewSigner("secret")
+
+	for _, token := range []string{"", "abc", "a.b.c", "!!!.abc", "YWJj.abc"} {
+		_, err := signer.Verify(token)
+		assert.Equal(t, ErrInvalidToken, err, token)
+	}
+}
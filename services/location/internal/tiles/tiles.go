@@ -0,0 +1,184 @@
+// Package tiles renders locations as Mapbox Vector Tiles (MVT), addressed with the
+// z/x/y scheme of web maps using the Web Mercator projection.
+package tiles
+
+import (
+	"errors"
+	"math"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// MaxZoom is the highest supported zoom level
+	MaxZoom = 22
+	// Extent is the number of units of a tile side
+	Extent = 4096
+	// Buffer is the number of units around a tile whose points are encoded too, so that
+	// markers overlapping two tiles are not cut
+	Buffer = 64
+	// LayerName is the name of the layer holding locations
+	LayerName = "locations"
+	// MaxLatitude is the latitude at which the Web Mercator projection is cut
+	MaxLatitude = 85.0511287798066
+
+	// MVT specification version
+	version = 2
+)
+
+// ErrInvalidTile is raised when tile coordinates are out of range
+var ErrInvalidTile = errors.New("invalid tile")
+
+// Tile identifies a map tile
+type Tile struct {
+	Z uint32
+	X uint32
+	Y uint32
+}
+
+// NewTile creates a new tile, checking that x and y are valid at zoom level z
+func NewTile(z, x, y uint32) (Tile, error) {
+	if z > MaxZoom || x >= 1<<z || y >= 1<<z {
+		return Tile{}, ErrInvalidTile
+	}
+
+	return Tile{z, x, y}, nil
+}
+
+// BoundingBox returns the area covered by the tile, including its buffer
+func (t Tile) BoundingBox() models.BoundingBox {
+	n := float64(uint32(1) << t.Z)
+	margin := float64(Buffer) / Extent
+
+	return models.BoundingBox{
+		West:  math.Max(-180, tileToLongitude(float64(t.X)-margin, n)),
+		South: math.Max(-MaxLatitude, tileToLatitude(float64(t.Y+1)+margin, n)),
+		East:  math.Min(180, tileToLongitude(float64(t.X+1)+margin, n)),
+		North: math.Min(MaxLatitude, tileToLatitude(float64(t.Y)-margin, n)),
+	}
+}
+
+// project returns the position of point in tile units, and whether it lies within tile buffer
+func (t Tile) project(p models.Point) (int64, int64, bool) {
+	n := float64(uint32(1) << t.Z)
+	latitude := math.Max(-MaxLatitude, math.Min(MaxLatitude, p.Latitude)) * math.Pi / 180
+
+	x := ((p.Longitude+180)/360*n - float64(t.X)) * Extent
+	y := ((1-math.Log(math.Tan(latitude)+1/math.Cos(latitude))/math.Pi)/2*n - float64(t.Y)) * Extent
+
+	px, py := int64(math.Round(x)), int64(math.Round(y))
+	inside := px >= -Buffer && px <= Extent+Buffer && py >= -Buffer && py <= Extent+Buffer
+	return px, py, inside
+}
+
+func tileToLongitude(x, n float64) float64 {
+	return x/n*360 - 180
+}
+
+func tileToLatitude(y, n float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+}
+
+// Field numbers and values of the vector tile protobuf schema
+// https://github.com/mapbox/vector-tile-spec/blob/master/2.1/vector_tile.proto
+const (
+	tileLayers = 3
+
+	layerName     = 1
+	layerFeatures = 2
+	layerKeys     = 3
+	layerValues   = 4
+	layerExtent   = 5
+	layerVersion  = 15
+
+	featureTags     = 2
+	featureType     = 3
+	featureGeometry = 4
+
+	valueString = 1
+
+	geomTypePoint = 1
+	commandMoveTo = 1
+)
+
+// Feature properties, by key index
+var propertyKeys = []string{"id", "name", "category_id", "category_name"}
+
+// Encode renders locations, with their category embedded, into one MVT layer.
+// Locations without coordinates or outside tile buffer are skipped.
+func Encode(t Tile, locs models.Locations) []byte {
+	// Property values are shared by features, and referenced by index
+	var values []string
+	valueIndexes := make(map[string]uint64)
+	valueIndex := func(value string) uint64 {
+		index, ok := valueIndexes[value]
+		if !ok {
+			index = uint64(len(values))
+			values = append(values, value)
+			valueIndexes[value] = index
+		}
+		return index
+	}
+
+	var layer []byte
+	layer = protowire.AppendTag(layer, layerVersion, protowire.VarintType)
+	layer = protowire.AppendVarint(layer, version)
+	layer = protowire.AppendTag(layer, layerName, protowire.BytesType)
+	layer = protowire.AppendString(layer, LayerName)
+
+	for _, loc := range locs {
+		if loc.Latitude == nil || loc.Longitude == nil {
+			continue
+		}
+		x, y, inside := t.project(models.NewPoint(*loc.Latitude, *loc.Longitude))
+		if !inside {
+			continue
+		}
+
+		properties := []string{loc.ID.String(), loc.Name, loc.Category.String(), ""}
+		if loc.ExpandedCategory != nil {
+			properties[3] = loc.ExpandedCategory.Name
+		}
+		var tags []byte
+		for key, value := range properties {
+			tags = protowire.AppendVarint(tags, uint64(key))
+			tags = protowire.AppendVarint(tags, valueIndex(value))
+		}
+
+		var geometry []byte
+		geometry = protowire.AppendVarint(geometry, commandMoveTo|1<<3)
+		geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(x))
+		geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(y))
+
+		var feature []byte
+		feature = protowire.AppendTag(feature, featureTags, protowire.BytesType)
+		feature = protowire.AppendBytes(feature, tags)
+		feature = protowire.AppendTag(feature, featureType, protowire.VarintType)
+		feature = protowire.AppendVarint(feature, geomTypePoint)
+		feature = protowire.AppendTag(feature, featureGeometry, protowire.BytesType)
+		feature = protowire.AppendBytes(feature, geometry)
+
+		layer = protowire.AppendTag(layer, layerFeatures, protowire.BytesType)
+		layer = protowire.AppendBytes(layer, feature)
+	}
+
+	for _, key := range propertyKeys {
+		layer = protowire.AppendTag(layer, layerKeys, protowire.BytesType)
+		layer = protowire.AppendString(layer, key)
+	}
+	for _, value := range values {
+		var v []byte
+		v = protowire.AppendTag(v, valueString, protowire.BytesType)
+		v = protowire.AppendString(v, value)
+
+		layer = protowire.AppendTag(layer, layerValues, protowire.BytesType)
+		layer = protowire.AppendBytes(layer, v)
+	}
+	layer = protowire.AppendTag(layer, layerExtent, protowire.VarintType)
+	layer = protowire.AppendVarint(layer, Extent)
+
+	var tile []byte
+	tile = protowire.AppendTag(tile, tileLayers, protowire.BytesType)
+	return protowire.AppendBytes(tile, layer)
+}
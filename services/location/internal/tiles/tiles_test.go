@@ -0,0 +1,157 @@
+package tiles
+
+import (
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type decodedFeature struct {
+	geomType   uint64
+	geometry   []uint64
+	properties map[string]string
+}
+
+type decodedLayer struct {
+	version  uint64
+	name     string
+	extent   uint64
+	features []decodedFeature
+}
+
+// decode parses the single layer of an encoded tile
+func decode(t *testing.T, data []byte) decodedLayer {
+	num, typ, n := protowire.ConsumeTag(data)
+	require.Equal(t, protowire.Number(tileLayers), num)
+	require.Equal(t, protowire.BytesType, typ)
+	layerData, m := protowire.ConsumeBytes(data[n:])
+	require.Equal(t, len(data), n+m)
+
+	var layer decodedLayer
+	var keys, values []string
+	var rawFeatures [][]byte
+	for len(layerData) > 0 {
+		num, typ, n := protowire.ConsumeTag(layerData)
+		layerData = layerData[n:]
+		switch num {
+		case layerVersion:
+			layer.version, n = protowire.ConsumeVarint(layerData)
+		case layerName:
+			layer.name, n = protowire.ConsumeString(layerData)
+		case layerExtent:
+			layer.extent, n = protowire.ConsumeVarint(layerData)
+		case layerKeys:
+			var key string
+			key, n = protowire.ConsumeString(layerData)
+			keys = append(keys, key)
+		case layerValues:
+			var value []byte
+			value, n = protowire.ConsumeBytes(layerData)
+			_, _, m := protowire.ConsumeTag(value)
+			s, _ := protowire.ConsumeString(value[m:])
+			values = append(values, s)
+		case layerFeatures:
+			var feature []byte
+			feature, n = protowire.ConsumeBytes(layerData)
+			rawFeatures = append(rawFeatures, feature)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, layerData)
+		}
+		require.True(t, n >= 0)
+		layerData = layerData[n:]
+	}
+
+	for _, data := range rawFeatures {
+		feature := decodedFeature{properties: make(map[string]string)}
+		for len(data) > 0 {
+			num, _, n := protowire.ConsumeTag(data)
+			data = data[n:]
+			switch num {
+			case featureType:
+				feature.geomType, n = protowire.ConsumeVarint(data)
+			case featureTags, featureGeometry:
+				var packed []byte
+				packed, n = protowire.ConsumeBytes(data)
+				var ints []uint64
+				for len(packed) > 0 {
+					v, m := protowire.ConsumeVarint(packed)
+					ints = append(ints, v)
+					packed = packed[m:]
+				}
+				if num == featureGeometry {
+					feature.geometry = ints
+				} else {
+					for i := 0; i < len(ints); i += 2 {
+						feature.properties[keys[ints[i]]] = values[ints[i+1]]
+					}
+				}
+			}
+			data = data[n:]
+		}
+		layer.features = append(layer.features, feature)
+	}
+
+	return layer
+}
+
+func TestNewTile(t *testing.T) {
+	tile, err := NewTile(10, 518, 352)
+	assert.NoError(t, err)
+	assert.Equal(t, Tile{10, 518, 352}, tile)
+
+	for _, coordinates := range [][3]uint32{{23, 0, 0}, {0, 1, 0}, {2, 0, 4}} {
+		_, err := NewTile(coordinates[0], coordinates[1], coordinates[2])
+		assert.Equal(t, ErrInvalidTile, err, coordinates)
+	}
+}
+
+func TestTileBoundingBox(t *testing.T) {
+	world := Tile{0, 0, 0}.BoundingBox()
+	assert.Equal(t, models.BoundingBox{West: -180, South: -MaxLatitude, East: 180, North: MaxLatitude}, world)
+
+	paris := Tile{10, 518, 352}.BoundingBox()
+	assert.True(t, paris.Contains(models.NewPoint(48.8606111, 2.3376)))
+	assert.InDelta(t, 2.109375, paris.West, 0.01)
+	assert.InDelta(t, 2.4609375, paris.East, 0.01)
+}
+
+func TestEncode(t *testing.T) {
+	cat := models.NewCategory(models.NewID(), "Museums")
+	louvre := models.NewLocation(models.NewID(), "Louvre", "Rue de Rivoli, 75001 Paris", cat.ID, models.NewID())
+	louvre.SetCoordinates(0, 0)
+	louvre.ExpandedCategory = cat
+	orsay := models.NewLocation(models.NewID(), "Orsay", "1 Rue de la Légion d'Honneur, 75007 Paris", cat.ID, models.NewID())
+	orsay.SetCoordinates(0, 90)
+	orsay.ExpandedCategory = cat
+	unresolved := models.NewLocation(models.NewID(), "Unresolved", "Somewhere", cat.ID, models.NewID())
+
+	layer := decode(t, Encode(Tile{0, 0, 0}, models.Locations{louvre, orsay, unresolved}))
+
+	assert.Equal(t, uint64(2), layer.version)
+	assert.Equal(t, LayerName, layer.name)
+	assert.Equal(t, uint64(Extent), layer.extent)
+	if assert.Len(t, layer.features, 2) {
+		assert.Equal(t, uint64(geomTypePoint), layer.features[0].geomType)
+		assert.Equal(t, []uint64{9, protowire.EncodeZigZag(2048), protowire.EncodeZigZag(2048)}, layer.features[0].geometry)
+		assert.Equal(t, map[string]string{
+			"id":            louvre.ID.String(),
+			"name":          "Louvre",
+			"category_id":   cat.ID.String(),
+			"category_name": "Museums",
+		}, layer.features[0].properties)
+
+		assert.Equal(t, []uint64{9, protowire.EncodeZigZag(3072), protowire.EncodeZigZag(2048)}, layer.features[1].geometry)
+		assert.Equal(t, "Orsay", layer.features[1].properties["name"])
+	}
+}
+
+func TestEncodeSkipsLocationsOutsideBuffer(t *testing.T) {
+	loc := models.NewLocation(models.NewID(), "Louvre", "Rue de Rivoli, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+
+	assert.Len(t, decode(t, Encode(Tile{10, 518, 352}, models.Locations{loc})).features, 1)
+	assert.Empty(t, decode(t, Encode(Tile{10, 520, 352}, models.Locations{loc})).features)
+}
@@ -0,0 +1,172 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/apperrors"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// Roles of users, read from their token
+const (
+	// RoleAdmin is granted every scope
+	RoleAdmin = "admin"
+	// RoleUser manages its own locations, categories, tags and groups. Users without any role have this role.
+	RoleUser = "user"
+	// RoleViewer can only read its own locations, categories and tags
+	RoleViewer = "viewer"
+)
+
+// Scopes granted to users by their roles. Tokens may be restricted to some of them.
+const (
+	ScopeLocationsRead   = "locations:read"
+	ScopeLocationsWrite  = "locations:write"
+	ScopeCategoriesWrite = "categories:write"
+	ScopeTagsWrite       = "tags:write"
+	ScopeGroupsWrite     = "groups:write"
+	// ScopeDefaultsWrite is granted to no role but RoleAdmin, to manage default categories shared by all users
+	ScopeDefaultsWrite = "defaults:write"
+)
+
+var (
+	// ErrForbidden is raised when the user is not allowed to perform an operation
+	ErrForbidden = apperrors.New(apperrors.KindForbidden, "", "operation not allowed")
+)
+
+// Policy tells what users need to perform an operation
+type Policy struct {
+	// Scopes the user must be granted, all of them.
+	Scopes []string
+	// Whether the operation is allowed without any user, like guests holding a share link.
+	// Public operations check by themselves what they give access to.
+	Public bool
+}
+
+// RoleScopes tells which scopes each role grants, except RoleAdmin which is granted every scope
+var RoleScopes = map[string][]string{
+	RoleUser:   {ScopeLocationsRead, ScopeLocationsWrite, ScopeCategoriesWrite, ScopeTagsWrite, ScopeGroupsWrite},
+	RoleViewer: {ScopeLocationsRead},
+}
+
+// Policies of LocationUsecase operations, by operation name. Operations without policy are denied.
+var Policies = map[string]Policy{
+	"CreateCategory":   {Scopes: []string{ScopeCategoriesWrite}},
+	"GetCategories":    {Scopes: []string{ScopeLocationsRead}},
+	"FindCategoryByID": {Scopes: []string{ScopeLocationsRead}},
+	"UpdateCategory":   {Scopes: []string{ScopeCategoriesWrite}},
+	// Locations of deleted categories may be reassigned or deleted along with them
+	"DeleteCategory":  {Scopes: []string{ScopeCategoriesWrite, ScopeLocationsWrite}},
+	"MergeCategories": {Scopes: []string{ScopeCategoriesWrite, ScopeLocationsWrite}},
+	// Categories are shared along with all their locations
+	"GetCategoryShares": {Scopes: []string{ScopeLocationsRead}},
+	"ShareCategory":     {Scopes: []string{ScopeCategoriesWrite, ScopeLocationsWrite}},
+	"UnshareCategory":   {Scopes: []string{ScopeCategoriesWrite, ScopeLocationsWrite}},
+	// Checked by category operations on top of their own policy, when they involve default categories
+	"ManageDefaultCategories": {Scopes: []string{ScopeDefaultsWrite}},
+
+	"CreateLocation":             {Scopes: []string{ScopeLocationsWrite}},
+	"GetLocations":               {Scopes: []string{ScopeLocationsRead}},
+	"FindLocationByID":           {Scopes: []string{ScopeLocationsRead}},
+	"FindLocationsByCategory":    {Scopes: []string{ScopeLocationsRead}},
+	"GetExpandedLocations":       {Scopes: []string{ScopeLocationsRead}},
+	"FindExpandedLocationByID":   {Scopes: []string{ScopeLocationsRead}},
+	"StreamLocations":            {Scopes: []string{ScopeLocationsRead}},
+	"FindLocationsNear":          {Scopes: []string{ScopeLocationsRead}},
+	"FindLocationsInBoundingBox": {Scopes: []string{ScopeLocationsRead}},
+	"GetLocationClusters":        {Scopes: []string{ScopeLocationsRead}},
+	"GetLocationTile":            {Scopes: []string{ScopeLocationsRead}},
+	"ResolvePlusCode":            {Scopes: []string{ScopeLocationsRead}},
+	"ReverseGeocode":             {Scopes: []string{ScopeLocationsRead}},
+	"UpdateLocation":             {Scopes: []string{ScopeLocationsWrite}},
+	"DeleteLocation":             {Scopes: []string{ScopeLocationsWrite}},
+	"MoveLocations":              {Scopes: []string{ScopeLocationsWrite}},
+	"GetLocationShares":          {Scopes: []string{ScopeLocationsRead}},
+	"ShareLocation":              {Scopes: []string{ScopeLocationsWrite}},
+	"UnshareLocation":            {Scopes: []string{ScopeLocationsWrite}},
+
+	"CreateTag":           {Scopes: []string{ScopeTagsWrite}},
+	"GetTags":             {Scopes: []string{ScopeLocationsRead}},
+	"FindTagByID":         {Scopes: []string{ScopeLocationsRead}},
+	"UpdateTag":           {Scopes: []string{ScopeTagsWrite}},
+	"DeleteTag":           {Scopes: []string{ScopeTagsWrite}},
+	"FindLocationsByTags": {Scopes: []string{ScopeLocationsRead}},
+
+	"CreateGroup":     {Scopes: []string{ScopeGroupsWrite}},
+	"GetGroups":       {Scopes: []string{ScopeLocationsRead}},
+	"FindGroupByID":   {Scopes: []string{ScopeLocationsRead}},
+	"UpdateGroup":     {Scopes: []string{ScopeGroupsWrite}},
+	"GetGroupMembers": {Scopes: []string{ScopeLocationsRead}},
+	// Locations of deleted groups are given back to the users who created them
+	"DeleteGroup":       {Scopes: []string{ScopeGroupsWrite, ScopeLocationsWrite}},
+	"SetGroupMember":    {Scopes: []string{ScopeGroupsWrite}},
+	"RemoveGroupMember": {Scopes: []string{ScopeGroupsWrite}},
+
+	"CreateShareLink": {Scopes: []string{ScopeLocationsWrite}},
+	"GetShareLinks":   {Scopes: []string{ScopeLocationsRead}},
+	"RevokeShareLink": {Scopes: []string{ScopeLocationsWrite}},
+	// Guests are given access to one location by the token of its share link
+	"GetPublicLocation": {Public: true},
+}
+
+// Authorizer decides whether the user of a request may perform an operation, according to policies
+type Authorizer struct {
+	policies map[string]Policy
+}
+
+// NewAuthorizer creates a new Authorizer enforcing policies
+func NewAuthorizer(policies map[string]Policy) *Authorizer {
+	return &Authorizer{policies}
+}
+
+// Authorize returns ErrForbidden unless the user found in context may perform operation.
+// Public operations are allowed to anyone.
+func (a *Authorizer) Authorize(ctx context.Context, operation string) error {
+	policy, ok := a.policies[operation]
+	if !ok {
+		return ErrForbidden
+	}
+	if policy.Public {
+		return nil
+	}
+
+	user, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+
+	for _, scope := range policy.Scopes {
+		if !HasScope(user, scope) {
+			return ErrForbidden
+		}
+	}
+
+	return nil
+}
+
+// HasScope tells whether user is granted scope by its roles, and by its token when restricted
+func HasScope(user *models.User, scope string) bool {
+	if user.Scopes != nil && !contains(user.Scopes, scope) {
+		return false
+	}
+
+	roles := user.Roles
+	if len(roles) == 0 {
+		roles = []string{RoleUser}
+	}
+	for _, role := range roles {
+		if role == RoleAdmin || contains(RoleScopes[role], scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestContext returns a context holding a user without any role, which is given RoleUser
+func newTestContext() context.Context {
+	return newTestContextWithUser(nil, nil)
+}
+
+func newTestContextWithUser(roles []string, scopes []string) context.Context {
+	user := models.NewUser(models.NewID(), "user@test.fr")
+	user.Roles = roles
+	user.Scopes = scopes
+	return models.NewContextWithUser(context.Background(), user)
+}
+
+func TestPoliciesCoverAllOperations(t *testing.T) {
+	// Policies checked within operations, rather than for a whole operation
+	nested := []string{"ManageDefaultCategories"}
+	for _, name := range nested {
+		_, ok := Policies[name]
+		assert.True(t, ok, "missing policy for %s", name)
+	}
+
+	usecase := reflect.TypeOf(&LocationUsecase{})
+	for i := 0; i < usecase.NumMethod(); i++ {
+		name := usecase.Method(i).Name
+		_, ok := Policies[name]
+		assert.True(t, ok, "missing policy for %s", name)
+	}
+	assert.Equal(t, usecase.NumMethod()+len(nested), len(Policies))
+}
+
+func TestAuthorizeManageDefaultCategories(t *testing.T) {
+	authorizer := NewAuthorizer(Policies)
+
+	assert.NoError(t, authorizer.Authorize(newTestContextWithUser([]string{RoleAdmin}, nil), "ManageDefaultCategories"))
+	assert.Equal(t, ErrForbidden, authorizer.Authorize(newTestContextWithUser([]string{RoleUser}, nil), "ManageDefaultCategories"))
+	assert.Equal(t, ErrForbidden, authorizer.Authorize(newTestContextWithUser([]string{RoleUser}, []string{ScopeDefaultsWrite}), "ManageDefaultCategories"))
+	assert.Equal(t, ErrForbidden, authorizer.Authorize(newTestContextWithUser([]string{RoleAdmin}, []string{ScopeCategoriesWrite}), "ManageDefaultCategories"))
+}
+
+func TestAuthorizeWithoutUser(t *testing.T) {
+	authorizer := NewAuthorizer(Policies)
+
+	err := authorizer.Authorize(context.Background(), "GetLocations")
+	assert.Equal(t, ErrForbidden, err)
+}
+
+func TestAuthorizePublicOperationWithoutUser(t *testing.T) {
+	authorizer := NewAuthorizer(Policies)
+
+	err := authorizer.Authorize(context.Background(), "GetPublicLocation")
+	assert.NoError(t, err)
+}
+
+func TestAuthorizeUnknownOperation(t *testing.T) {
+	authorizer := NewAuthorizer(Policies)
+
+	err := authorizer.Authorize(newTestContext(), "DropDatabase")
+	assert.Equal(t, ErrForbidden, err)
+}
+
+func TestAuthorizePolicies(t *testing.T) {
+	authorizer := NewAuthorizer(Policies)
+
+	tests := []struct {
+		name      string
+		roles     []string
+		scopes    []string
+		operation string
+		allowed   bool
+	}{
+		{"default role reads", nil, nil, "GetLocations", true},
+		{"default role writes", nil, nil, "CreateCategory", true},
+		{"user writes", []string{RoleUser}, nil, "DeleteTag", true},
+		{"viewer reads", []string{RoleViewer}, nil, "FindLocationsNear", true},
+		{"viewer cannot write", []string{RoleViewer}, nil, "CreateLocation", false},
+		{"viewer cannot rename categories", []string{RoleViewer}, nil, "UpdateCategory", false},
+		{"admin writes", []string{RoleAdmin}, nil, "MergeCategories", true},
+		{"unknown role", []string{"guest"}, nil, "GetLocations", false},
+		{"viewer and user roles", []string{RoleViewer, RoleUser}, nil, "UpdateLocation", true},
+		{"restricted token reads", nil, []string{ScopeLocationsRead}, "GetTags", true},
+		{"restricted token cannot write", nil, []string{ScopeLocationsRead}, "CreateTag", false},
+		{"restricted admin token", []string{RoleAdmin}, []string{ScopeLocationsRead}, "DeleteLocation", false},
+		{"scope beyond role", []string{RoleViewer}, []string{ScopeLocationsWrite}, "UpdateLocation", false},
+		{"all scopes required", nil, []string{ScopeCategoriesWrite}, "DeleteCategory", false},
+		{"all scopes granted", nil, []string{ScopeCategoriesWrite, ScopeLocationsWrite}, "DeleteCategory", true},
+	}
+
+	for _, test := range tests {
+		err := authorizer.Authorize(newTestContextWithUser(test.roles, test.scopes), test.operation)
+		if test.allowed {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Equal(t, ErrForbidden, err, test.name)
+		}
+	}
+}
+
+func TestCreateCategoryWithViewerRole(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContextWithUser([]string{RoleViewer}, nil)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+
+	err := usecase.CreateCategory(ctx, cat)
+	assert.Equal(t, ErrForbidden, err)
+	repo.AssertNotCalled(t, "CreateCategory", ctx, cat)
+}
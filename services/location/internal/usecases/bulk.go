@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// errBulkInternal is reported for items that failed because of an unexpected error, which is logged instead
+var errBulkInternal = errors.New("internal error")
+
+// MergeCategories merges source categories into target category. Locations and child categories
+// of each source category move to the target category, then the source category is deleted.
+// Each source category is merged on its own, and the outcome of each merge is reported.
+func (u *LocationUsecase) MergeCategories(ctx context.Context, targetID models.ID, sourceIDs []models.ID) (*models.BulkReport, error) {
+	if err := u.authorizer.Authorize(ctx, "MergeCategories"); err != nil {
+		return nil, err
+	}
+
+	target, err := u.repo.FindCategoryByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("MergeCategories: failed to find category by id, %s. %w", targetID, err)
+	}
+	if target == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	ancestors, err := u.categoryAncestors(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("MergeCategories: failed to find ancestors of category, %s. %w", targetID, err)
+	}
+
+	report := models.NewBulkReport()
+	for _, id := range sourceIDs {
+		if report.Has(id) {
+			continue
+		}
+
+		if err := u.mergeCategory(ctx, id, target, ancestors); err != nil {
+			report.Fail(id, err)
+			continue
+		}
+		report.Succeed(id)
+	}
+
+	return report, nil
+}
+
+func (u *LocationUsecase) mergeCategory(ctx context.Context, id models.ID, target *models.Category, ancestors map[models.ID]bool) error {
+	if id == target.ID {
+		return ErrCategoryMergedIntoItself
+	}
+	if ancestors[id] {
+		return ErrCategoryCycle
+	}
+
+	cat, err := u.repo.FindCategoryByID(ctx, id)
+	if err != nil {
+		logger.Errorf("MergeCategories: failed to find category by id, %s. %v", id, err)
+		return errBulkInternal
+	}
+	if cat == nil {
+		return ErrCategoryNotFound
+	}
+	if cat.IsDefault() {
+		return ErrCategoryReadOnly
+	}
+
+	if err := u.repo.MergeCategory(ctx, id, target.ID); err != nil {
+		logger.Errorf("MergeCategories: failed to merge category %s into %s. %v", id, target.ID, err)
+		return errBulkInternal
+	}
+
+	return nil
+}
+
+// categoryAncestors returns IDs of all the ancestors of cat
+func (u *LocationUsecase) categoryAncestors(ctx context.Context, cat *models.Category) (map[models.ID]bool, error) {
+	ancestors := make(map[models.ID]bool)
+	for id := cat.Parent; id != nil && !ancestors[*id]; {
+		ancestors[*id] = true
+
+		ancestor, err := u.repo.FindCategoryByID(ctx, *id)
+		if err != nil {
+			return nil, fmt.Errorf("categoryAncestors: failed to find category by id, %s. %w", *id, err)
+		}
+		if ancestor == nil {
+			break
+		}
+		id = ancestor.Parent
+	}
+
+	return ancestors, nil
+}
+
+// MoveLocations moves user locations to specified category at once.
+// Locations that cannot be found are reported as failed, while others are moved.
+func (u *LocationUsecase) MoveLocations(ctx context.Context, ids []models.ID, catID models.ID) (*models.BulkReport, error) {
+	if err := u.authorizer.Authorize(ctx, "MoveLocations"); err != nil {
+		return nil, err
+	}
+
+	cat, err := u.repo.FindCategoryByID(ctx, catID)
+	if err != nil {
+		return nil, fmt.Errorf("MoveLocations: failed to find category by id, %s. %w", catID, err)
+	}
+	if cat == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	moved, err := u.repo.MoveLocations(ctx, ids, cat)
+	if err != nil {
+		return nil, fmt.Errorf("MoveLocations: failed to move locations to category, %s. %w", catID, err)
+	}
+
+	isMoved := make(map[models.ID]bool, len(moved))
+	for _, id := range moved {
+		isMoved[id] = true
+	}
+
+	report := models.NewBulkReport()
+	for _, id := range ids {
+		switch {
+		case report.Has(id):
+			continue
+		case isMoved[id]:
+			report.Succeed(id)
+		default:
+			report.Fail(id, ErrLocationNotFound)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeCategoriesWithTargetNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	targetID := models.NewID()
+	repo.On("FindCategoryByID", ctx, targetID).Return(nil, nil)
+
+	_, err := usecase.MergeCategories(ctx, targetID, []models.ID{models.NewID()})
+	assert.Equal(t, ErrCategoryNotFound, err)
+}
+
+func TestMergeCategoriesWithPartialFailure(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user := models.NewID()
+
+	parent := models.NewCategory(models.NewID(), "Food")
+	parent.SetUser(user)
+	target := models.NewCategory(models.NewID(), "Restaurants")
+	target.SetUser(user)
+	target.SetParent(parent.ID)
+	merged := models.NewCategory(models.NewID(), "restaurant")
+	merged.SetUser(user)
+	failing := models.NewCategory(models.NewID(), "Bistros")
+	failing.SetUser(user)
+	defaultCat := models.NewCategory(models.NewID(), "Default")
+	missing := models.NewID()
+
+	repo.On("FindCategoryByID", ctx, target.ID).Return(target, nil)
+	repo.On("FindCategoryByID", ctx, parent.ID).Return(parent, nil)
+	repo.On("FindCategoryByID", ctx, merged.ID).Return(merged, nil)
+	repo.On("FindCategoryByID", ctx, failing.ID).Return(failing, nil)
+	repo.On("FindCategoryByID", ctx, defaultCat.ID).Return(defaultCat, nil)
+	repo.On("FindCategoryByID", ctx, missing).Return(nil, nil)
+	repo.On("MergeCategory", ctx, merged.ID, target.ID).Return(nil)
+	repo.On("MergeCategory", ctx, failing.ID, target.ID).Return(errors.New("failed"))
+
+	report, err := usecase.MergeCategories(ctx, target.ID, []models.ID{merged.ID, target.ID, parent.ID, defaultCat.ID, missing, failing.ID, merged.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ID{merged.ID}, report.Succeeded)
+	assert.Equal(t, []*models.BulkFailure{
+		{ID: target.ID, Error: ErrCategoryMergedIntoItself.Error()},
+		{ID: parent.ID, Error: ErrCategoryCycle.Error()},
+		{ID: defaultCat.ID, Error: ErrCategoryReadOnly.Error()},
+		{ID: missing, Error: ErrCategoryNotFound.Error()},
+		{ID: failing.ID, Error: "internal error"},
+	}, report.Failed)
+	repo.AssertNotCalled(t, "MergeCategory", ctx, parent.ID, target.ID)
+}
+
+func TestMoveLocationsWithCategoryNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	catID := models.NewID()
+	repo.On("FindCategoryByID", ctx, catID).Return(nil, nil)
+
+	_, err := usecase.MoveLocations(ctx, []models.ID{models.NewID()}, catID)
+	assert.Equal(t, ErrCategoryNotFound, err)
+}
+
+func TestMoveLocationsWithRepositoryMoveLocationsError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	ids := []models.ID{models.NewID()}
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("MoveLocations", ctx, ids, cat).Return(nil, errors.New("failed"))
+
+	_, err := usecase.MoveLocations(ctx, ids, cat.ID)
+	assert.Error(t, err)
+}
+
+func TestMoveLocationsWithPartialFailure(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	ids := []models.ID{models.NewID(), models.NewID(), models.NewID()}
+	ids = append(ids, ids[0])
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("MoveLocations", ctx, ids, cat).Return([]models.ID{ids[0], ids[2]}, nil)
+
+	report, err := usecase.MoveLocations(ctx, ids, cat.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ID{ids[0], ids[2]}, report.Succeeded)
+	assert.Equal(t, []*models.BulkFailure{{ID: ids[1], Error: ErrLocationNotFound.Error()}}, report.Failed)
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/geohash"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// FindLocationsInBoundingBox returns user locations inside bbox, filtered by category unless catID is NilID
+func (u *LocationUsecase) FindLocationsInBoundingBox(ctx context.Context, catID models.ID, bbox models.BoundingBox) (*models.Locations, error) {
+	if err := u.authorizer.Authorize(ctx, "FindLocationsInBoundingBox"); err != nil {
+		return nil, err
+	}
+
+	cat, err := u.findOptionalCategory(ctx, catID)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := u.repo.FindLocationsInBoundingBox(ctx, cat, bbox)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsInBoundingBox: failed to find locations in %v. %w", bbox, err)
+	}
+
+	return locations, nil
+}
+
+// GetLocationClusters groups user locations inside bbox by geohash cells sized for the zoom level of a map.
+// Clusters are filtered by category unless catID is NilID, and sorted by geohash.
+func (u *LocationUsecase) GetLocationClusters(ctx context.Context, catID models.ID, bbox models.BoundingBox, zoom int) (*models.Clusters, error) {
+	if err := u.authorizer.Authorize(ctx, "GetLocationClusters"); err != nil {
+		return nil, err
+	}
+
+	cat, err := u.findOptionalCategory(ctx, catID)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := u.repo.FindLocationsInBoundingBox(ctx, cat, bbox)
+	if err != nil {
+		return nil, fmt.Errorf("GetLocationClusters: failed to find locations in %v. %w", bbox, err)
+	}
+
+	precision := geohash.PrecisionForZoom(zoom)
+	clustersByHash := make(map[string]*models.Cluster)
+	for _, loc := range *locations {
+		hash := geohash.Encode(*loc.Latitude, *loc.Longitude, precision)
+
+		cluster, ok := clustersByHash[hash]
+		if !ok {
+			cluster = models.NewCluster(hash, 0, 0, 0)
+			cluster.Location = loc
+			clustersByHash[hash] = cluster
+		}
+
+		// Running average keeps the centroid of the cluster up to date
+		cluster.Count++
+		cluster.Latitude += (*loc.Latitude - cluster.Latitude) / float64(cluster.Count)
+		cluster.Longitude += (*loc.Longitude - cluster.Longitude) / float64(cluster.Count)
+	}
+
+	clusters := make(models.Clusters, 0, len(clustersByHash))
+	for _, cluster := range clustersByHash {
+		if cluster.Count > 1 {
+			cluster.Location = nil
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Geohash < clusters[j].Geohash
+	})
+
+	return &clusters, nil
+}
+
+// findOptionalCategory returns category matching catID, or nil when catID is NilID
+func (u *LocationUsecase) findOptionalCategory(ctx context.Context, catID models.ID) (*models.Category, error) {
+	if catID == models.NilID {
+		return nil, nil
+	}
+
+	cat, err := u.repo.FindVisibleCategoryByID(ctx, catID)
+	if err != nil {
+		return nil, fmt.Errorf("findOptionalCategory: failed to find category by ID, %s. %w", catID, err)
+	}
+	if cat == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	return cat, nil
+}
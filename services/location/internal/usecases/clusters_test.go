@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var parisBoundingBox = models.BoundingBox{West: 2.25, South: 48.81, East: 2.42, North: 48.9}
+
+func newTestLocationAt(latitude, longitude float64) *models.Location {
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.SetCoordinates(latitude, longitude)
+	return loc
+}
+
+func TestFindLocationsInBoundingBoxWithCategoryNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	catID := models.NewID()
+	repo.On("FindVisibleCategoryByID", ctx, catID).Return(nil, nil)
+
+	locations, err := usecase.FindLocationsInBoundingBox(ctx, catID, parisBoundingBox)
+	assert.Equal(t, ErrCategoryNotFound, err)
+	assert.Nil(t, locations)
+}
+
+func TestFindLocationsInBoundingBoxWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	locs := models.Locations{newTestLocationAt(48.8606111, 2.3376)}
+	repo.On("FindLocationsInBoundingBox", ctx, (*models.Category)(nil), parisBoundingBox).Return(&locs, nil)
+
+	returnedLocs, err := usecase.FindLocationsInBoundingBox(ctx, models.NilID, parisBoundingBox)
+	assert.NoError(t, err)
+	assert.Equal(t, locs, *returnedLocs)
+}
+
+func TestGetLocationClustersWithRepositoryError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	repo.On("FindLocationsInBoundingBox", ctx, (*models.Category)(nil), parisBoundingBox).Return(nil, errors.New("failed"))
+
+	clusters, err := usecase.GetLocationClusters(ctx, models.NilID, parisBoundingBox, 12)
+	assert.Error(t, err)
+	assert.Nil(t, clusters)
+}
+
+func TestGetLocationClustersWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	louvre := newTestLocationAt(48.8606111, 2.3376)
+	palaisRoyal := newTestLocationAt(48.865, 2.342)
+	eiffel := newTestLocationAt(48.8584, 2.2945)
+	locs := models.Locations{louvre, eiffel, palaisRoyal}
+	repo.On("FindLocationsInBoundingBox", ctx, (*models.Category)(nil), parisBoundingBox).Return(&locs, nil)
+
+	// Precision 5 cells are about 5 kilometers wide
+	clusters, err := usecase.GetLocationClusters(ctx, models.NilID, parisBoundingBox, 10)
+	if assert.NoError(t, err) && assert.Len(t, *clusters, 2) {
+		assert.Equal(t, "u09tu", (*clusters)[0].Geohash)
+		assert.Equal(t, 1, (*clusters)[0].Count)
+		assert.Equal(t, eiffel, (*clusters)[0].Location)
+
+		assert.Equal(t, "u09tv", (*clusters)[1].Geohash)
+		assert.Equal(t, 2, (*clusters)[1].Count)
+		assert.InDelta(t, 48.8628056, (*clusters)[1].Latitude, 1e-7)
+		assert.InDelta(t, 2.3398, (*clusters)[1].Longitude, 1e-7)
+		assert.Nil(t, (*clusters)[1].Location)
+	}
+}
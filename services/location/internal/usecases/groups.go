@@ -0,0 +1,245 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// CreateGroup stores a new group in repository. The user becomes its owner.
+func (u *LocationUsecase) CreateGroup(ctx context.Context, group *models.Group) error {
+	if err := u.authorizer.Authorize(ctx, "CreateGroup"); err != nil {
+		return err
+	}
+
+	if err := validateGroup(group); err != nil {
+		return err
+	}
+
+	if err := u.repo.CreateGroup(ctx, group); err != nil {
+		return fmt.Errorf("CreateGroup: failed to create group in repository : %v. %w", group, err)
+	}
+	group.Role = models.GroupRoleOwner
+
+	return nil
+}
+
+// GetGroups returns all groups the user is a member of
+func (u *LocationUsecase) GetGroups(ctx context.Context) (*models.Groups, error) {
+	if err := u.authorizer.Authorize(ctx, "GetGroups"); err != nil {
+		return nil, err
+	}
+
+	groups, err := u.repo.GetGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetGroups: failed to get groups from repository. %w", err)
+	}
+
+	return groups, nil
+}
+
+// FindGroupByID returns group of the user matching specified ID
+func (u *LocationUsecase) FindGroupByID(ctx context.Context, id models.ID) (*models.Group, error) {
+	if err := u.authorizer.Authorize(ctx, "FindGroupByID"); err != nil {
+		return nil, err
+	}
+
+	return u.findGroup(ctx, id)
+}
+
+// UpdateGroup renames specified group. Only its owners may rename it.
+func (u *LocationUsecase) UpdateGroup(ctx context.Context, group *models.Group) error {
+	if err := u.authorizer.Authorize(ctx, "UpdateGroup"); err != nil {
+		return err
+	}
+
+	if err := validateGroup(group); err != nil {
+		return err
+	}
+
+	groupByID, err := u.findOwnedGroup(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repo.UpdateGroup(ctx, group); err != nil {
+		return fmt.Errorf("UpdateGroup: failed to update group, %s. %w", group.ID, err)
+	}
+	group.Role = groupByID.Role
+
+	return nil
+}
+
+// DeleteGroup deletes specified group. Only its owners may delete it.
+// Locations of the group are given back to the users who created them.
+func (u *LocationUsecase) DeleteGroup(ctx context.Context, id models.ID) error {
+	if err := u.authorizer.Authorize(ctx, "DeleteGroup"); err != nil {
+		return err
+	}
+
+	if _, err := u.findOwnedGroup(ctx, id); err != nil {
+		return err
+	}
+
+	if err := u.repo.DeleteGroup(ctx, id); err != nil {
+		return fmt.Errorf("DeleteGroup: failed to delete group, %s. %w", id, err)
+	}
+
+	return nil
+}
+
+// GetGroupMembers returns the members of a group of the user
+func (u *LocationUsecase) GetGroupMembers(ctx context.Context, id models.ID) (*models.GroupMembers, error) {
+	if err := u.authorizer.Authorize(ctx, "GetGroupMembers"); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findGroup(ctx, id); err != nil {
+		return nil, err
+	}
+
+	members, err := u.repo.GetGroupMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetGroupMembers: failed to get members of group %s. %w", id, err)
+	}
+
+	return members, nil
+}
+
+// SetGroupMember adds a member to a group, or changes the role of a member. Only owners of the group
+// may manage its members, and the group must keep at least one owner.
+func (u *LocationUsecase) SetGroupMember(ctx context.Context, id models.ID, member *models.GroupMember) error {
+	if err := u.authorizer.Authorize(ctx, "SetGroupMember"); err != nil {
+		return err
+	}
+
+	if err := validateGroupMember(member); err != nil {
+		return err
+	}
+
+	if _, err := u.findOwnedGroup(ctx, id); err != nil {
+		return err
+	}
+
+	// Members are locked while checking owners, so that concurrent changes cannot remove the last one
+	err := u.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if member.Role != models.GroupRoleOwner {
+			if err := u.checkOtherGroupOwner(ctx, id, member.User); err != nil {
+				return err
+			}
+		}
+
+		return u.repo.SetGroupMember(ctx, id, member)
+	})
+	switch {
+	case errors.Is(err, ErrLastGroupOwner):
+		return ErrLastGroupOwner
+	case errors.Is(err, ErrGroupNotFound):
+		return ErrGroupNotFound
+	case err != nil:
+		return fmt.Errorf("SetGroupMember: failed to set member %s of group %s. %w", member.User, id, err)
+	}
+
+	return nil
+}
+
+// RemoveGroupMember removes a member from a group. Owners of the group may remove any member,
+// and members may leave the group, as long as it keeps at least one owner.
+func (u *LocationUsecase) RemoveGroupMember(ctx context.Context, id models.ID, user models.ID) error {
+	if err := u.authorizer.Authorize(ctx, "RemoveGroupMember"); err != nil {
+		return err
+	}
+
+	group, err := u.findGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	current, ok := models.NewUserFromContext(ctx)
+	if !ok {
+		return errors.New("RemoveGroupMember: Failed to get user from context")
+	}
+	if group.Role != models.GroupRoleOwner && user != current.ID {
+		return ErrForbidden
+	}
+
+	// Members are locked while checking owners, so that concurrent changes cannot remove the last one
+	return u.repo.WithinTx(ctx, func(ctx context.Context) error {
+		members, err := u.repo.LockGroupMembers(ctx, id)
+		if err != nil {
+			return fmt.Errorf("RemoveGroupMember: failed to lock members of group %s. %w", id, err)
+		}
+		if findGroupMember(*members, user) == nil {
+			return ErrGroupMemberNotFound
+		}
+		if !hasOtherGroupOwner(*members, user) {
+			return ErrLastGroupOwner
+		}
+
+		if err := u.repo.DeleteGroupMember(ctx, id, user); err != nil {
+			return fmt.Errorf("RemoveGroupMember: failed to remove member %s of group %s. %w", user, id, err)
+		}
+
+		return nil
+	})
+}
+
+// findGroup returns the group of the user matching id
+func (u *LocationUsecase) findGroup(ctx context.Context, id models.ID) (*models.Group, error) {
+	group, err := u.repo.FindGroupByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("findGroup: failed to find group by id, %s. %w", id, err)
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	return group, nil
+}
+
+// findOwnedGroup returns the group of the user matching id, provided the user owns it
+func (u *LocationUsecase) findOwnedGroup(ctx context.Context, id models.ID) (*models.Group, error) {
+	group, err := u.findGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if group.Role != models.GroupRoleOwner {
+		return nil, ErrForbidden
+	}
+
+	return group, nil
+}
+
+// checkOtherGroupOwner makes sure that the group keeps an owner other than user.
+// Members are locked until the end of the transaction of ctx.
+func (u *LocationUsecase) checkOtherGroupOwner(ctx context.Context, id models.ID, user models.ID) error {
+	members, err := u.repo.LockGroupMembers(ctx, id)
+	if err != nil {
+		return fmt.Errorf("checkOtherGroupOwner: failed to lock members of group %s. %w", id, err)
+	}
+	if !hasOtherGroupOwner(*members, user) {
+		return ErrLastGroupOwner
+	}
+
+	return nil
+}
+
+func findGroupMember(members models.GroupMembers, user models.ID) *models.GroupMember {
+	for _, member := range members {
+		if member.User == user {
+			return member
+		}
+	}
+	return nil
+}
+
+func hasOtherGroupOwner(members models.GroupMembers, user models.ID) bool {
+	for _, member := range members {
+		if member.User != user && member.Role == models.GroupRoleOwner {
+			return true
+		}
+	}
+	return false
+}
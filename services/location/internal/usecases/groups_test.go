@@ -0,0 +1,287 @@
+package usecases
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGroup(role models.GroupRole) *models.Group {
+	group := models.NewGroup(models.NewID(), "Family")
+	group.Role = role
+	return group
+}
+
+func TestCreateGroupWithInvalidName(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	group := models.NewGroup(models.NewID(), "  ")
+
+	err := usecase.CreateGroup(ctx, group)
+	assertInvalidField(t, err, ErrInvalidGroup, "name")
+	repo.AssertNotCalled(t, "CreateGroup", ctx, group)
+}
+
+func TestCreateGroupWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	group := models.NewGroup(models.NewID(), " Family ")
+	repo.On("CreateGroup", ctx, group).Return(nil)
+
+	err := usecase.CreateGroup(ctx, group)
+	assert.NoError(t, err)
+	assert.Equal(t, "Family", group.Name)
+	assert.Equal(t, models.GroupRoleOwner, group.Role)
+}
+
+func TestFindGroupByIDWithNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	id := models.NewID()
+	repo.On("FindGroupByID", ctx, id).Return(nil, nil)
+
+	_, err := usecase.FindGroupByID(ctx, id)
+	assert.Equal(t, ErrGroupNotFound, err)
+}
+
+func TestUpdateGroupAsMember(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	existing := newTestGroup(models.GroupRoleMember)
+	group := models.NewGroup(existing.ID, "Friends")
+	repo.On("FindGroupByID", ctx, group.ID).Return(existing, nil)
+
+	err := usecase.UpdateGroup(ctx, group)
+	assert.Equal(t, ErrForbidden, err)
+	repo.AssertNotCalled(t, "UpdateGroup", ctx, group)
+}
+
+func TestUpdateGroupWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	existing := newTestGroup(models.GroupRoleOwner)
+	group := models.NewGroup(existing.ID, "Friends")
+	repo.On("FindGroupByID", ctx, group.ID).Return(existing, nil)
+	repo.On("UpdateGroup", ctx, group).Return(nil)
+
+	err := usecase.UpdateGroup(ctx, group)
+	assert.NoError(t, err)
+	assert.Equal(t, models.GroupRoleOwner, group.Role)
+}
+
+func TestDeleteGroupWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	group := newTestGroup(models.GroupRoleOwner)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("DeleteGroup", ctx, group.ID).Return(nil)
+
+	err := usecase.DeleteGroup(ctx, group.ID)
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "DeleteGroup", ctx, group.ID)
+}
+
+func TestGetGroupMembersWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	group := newTestGroup(models.GroupRoleMember)
+	members := &models.GroupMembers{models.NewGroupMember(models.NewID(), models.GroupRoleOwner)}
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("GetGroupMembers", ctx, group.ID).Return(members, nil)
+
+	returned, err := usecase.GetGroupMembers(ctx, group.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, members, returned)
+}
+
+func TestSetGroupMemberWithUnknownRole(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	member := models.NewGroupMember(models.NewID(), models.GroupRole("admin"))
+
+	err := usecase.SetGroupMember(ctx, models.NewID(), member)
+	assertInvalidField(t, err, ErrInvalidGroupMember, "role")
+}
+
+func TestSetGroupMemberAsMember(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	group := newTestGroup(models.GroupRoleMember)
+	member := models.NewGroupMember(models.NewID(), models.GroupRoleMember)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+
+	err := usecase.SetGroupMember(ctx, group.ID, member)
+	assert.Equal(t, ErrForbidden, err)
+}
+
+func TestSetGroupMemberDemotingLastOwner(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	group := newTestGroup(models.GroupRoleOwner)
+	member := models.NewGroupMember(user.ID, models.GroupRoleMember)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("LockGroupMembers", ctx, group.ID).Return(&models.GroupMembers{
+		models.NewGroupMember(user.ID, models.GroupRoleOwner),
+		models.NewGroupMember(models.NewID(), models.GroupRoleMember),
+	}, nil)
+
+	err := usecase.SetGroupMember(ctx, group.ID, member)
+	assert.Equal(t, ErrLastGroupOwner, err)
+	repo.AssertNotCalled(t, "SetGroupMember", ctx, group.ID, member)
+}
+
+func TestSetGroupMemberWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	group := newTestGroup(models.GroupRoleOwner)
+	member := models.NewGroupMember(models.NewID(), models.GroupRoleOwner)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("SetGroupMember", ctx, group.ID, member).Return(nil)
+
+	err := usecase.SetGroupMember(ctx, group.ID, member)
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "SetGroupMember", ctx, group.ID, member)
+}
+
+func TestRemoveGroupMemberAsMember(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	group := newTestGroup(models.GroupRoleMember)
+	other := models.NewID()
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+
+	err := usecase.RemoveGroupMember(ctx, group.ID, other)
+	assert.Equal(t, ErrForbidden, err)
+}
+
+func TestRemoveGroupMemberLeavingGroup(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	group := newTestGroup(models.GroupRoleMember)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("LockGroupMembers", ctx, group.ID).Return(&models.GroupMembers{
+		models.NewGroupMember(models.NewID(), models.GroupRoleOwner),
+		models.NewGroupMember(user.ID, models.GroupRoleMember),
+	}, nil)
+	repo.On("DeleteGroupMember", ctx, group.ID, user.ID).Return(nil)
+
+	err := usecase.RemoveGroupMember(ctx, group.ID, user.ID)
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "DeleteGroupMember", ctx, group.ID, user.ID)
+}
+
+func TestRemoveGroupMemberWithMemberNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	group := newTestGroup(models.GroupRoleOwner)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("LockGroupMembers", ctx, group.ID).Return(&models.GroupMembers{}, nil)
+
+	err := usecase.RemoveGroupMember(ctx, group.ID, models.NewID())
+	assert.Equal(t, ErrGroupMemberNotFound, err)
+}
+
+func TestRemoveGroupMemberWithLastOwner(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	group := newTestGroup(models.GroupRoleOwner)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("LockGroupMembers", ctx, group.ID).Return(&models.GroupMembers{models.NewGroupMember(user.ID, models.GroupRoleOwner)}, nil)
+
+	err := usecase.RemoveGroupMember(ctx, group.ID, user.ID)
+	assert.Equal(t, ErrLastGroupOwner, err)
+}
+
+func TestCreateLocationWithGroupNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.SetGroup(models.NewID())
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindGroupByID", ctx, *loc.Group).Return(nil, nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.Equal(t, ErrGroupNotFound, err)
+	repo.AssertNotCalled(t, "CreateLocation", ctx, loc)
+}
+
+func TestCreateLocationInGroupWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	group := newTestGroup(models.GroupRoleMember)
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.SetGroup(group.ID)
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "CreateLocation", ctx, loc)
+}
+
+func TestCreateLocationInGroupWithConcurrentGroupDeletion(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	group := newTestGroup(models.GroupRoleOwner)
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.SetGroup(group.ID)
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindGroupByID", ctx, group.ID).Return(group, nil)
+	repo.On("CreateLocation", ctx, loc).Return(fmt.Errorf("CreateLocation: group not found. %w", ErrGroupNotFound))
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.Equal(t, ErrGroupNotFound, err)
+}
@@ -4,56 +4,208 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/edebernis/social-life-manager/services/location/internal/addresses"
+	"github.com/edebernis/social-life-manager/services/location/internal/apperrors"
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/sirupsen/logrus"
 )
 
 var (
+	logger = logrus.WithField("package", "usecases")
+
 	// ErrLocationAlreadyExists is raised when a matching location
 	// is already stored in repository
-	ErrLocationAlreadyExists = errors.New("location already exists")
+	ErrLocationAlreadyExists = apperrors.New(apperrors.KindAlreadyExists, "location", "location already exists")
 	// ErrLocationNotFound is raised when specified location has not been found
 	// in repository
-	ErrLocationNotFound = errors.New("location not found")
+	ErrLocationNotFound = apperrors.New(apperrors.KindNotFound, "location", "location not found")
 	// ErrCategoryAlreadyExists is raised when a matching category
 	// is already stored in repository
-	ErrCategoryAlreadyExists = errors.New("category already exists")
+	ErrCategoryAlreadyExists = apperrors.New(apperrors.KindAlreadyExists, "category", "category already exists")
 	// ErrCategoryNotFound is raised when specified category has not been found
 	// in repository
-	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryNotFound = apperrors.New(apperrors.KindNotFound, "category", "category not found")
+	// ErrCategoryReadOnly is raised when trying to modify a default category
+	// without being allowed to manage default categories
+	ErrCategoryReadOnly = apperrors.New(apperrors.KindForbidden, "category", "category is read-only")
+	// ErrDefaultCategoryCascade is raised when deleting a default category along with its locations,
+	// which belong to many users
+	ErrDefaultCategoryCascade = apperrors.New(apperrors.KindInvalid, "category", "locations of default categories cannot be deleted along with them").WithField("cascade")
+	// ErrParentCategoryNotFound is raised when the parent of a category has not been found
+	ErrParentCategoryNotFound = apperrors.New(apperrors.KindNotFound, "category", "parent category not found").WithField("parent_id")
+	// ErrCategoryCycle is raised when a category would become its own ancestor
+	ErrCategoryCycle = apperrors.New(apperrors.KindInvalid, "category", "category cannot be moved under itself or its descendants")
+	// ErrCategoryInUse is raised when deleting a category that still has locations.
+	// It is wrapped by CategoryInUseError, which tells how many locations are left.
+	ErrCategoryInUse = apperrors.New(apperrors.KindConflict, "category", "category still has locations")
+	// ErrTargetCategoryNotFound is raised when the category that locations are moved to
+	// has not been found in repository
+	ErrTargetCategoryNotFound = apperrors.New(apperrors.KindNotFound, "category", "target category not found").WithField("reassign_to")
+	// ErrCategoryReassignedToItself is raised when locations of a deleted category
+	// would be moved to the category itself
+	ErrCategoryReassignedToItself = apperrors.New(apperrors.KindInvalid, "category", "category locations cannot be reassigned to the category itself")
+	// ErrCategoryMergedIntoItself is raised when a category would be merged into itself
+	ErrCategoryMergedIntoItself = apperrors.New(apperrors.KindInvalid, "category", "category cannot be merged into itself")
+	// ErrInvalidPlusCode is raised when a plus code cannot be decoded
+	ErrInvalidPlusCode = apperrors.New(apperrors.KindInvalid, "location", "invalid plus code").WithField("plus_code")
+	// ErrPlusCodeReferenceRequired is raised when a short plus code comes
+	// without any usable reference to recover it
+	ErrPlusCodeReferenceRequired = apperrors.New(apperrors.KindInvalid, "location", "short plus code requires a reference").WithField("plus_code")
+	// ErrTagAlreadyExists is raised when a tag with the same name
+	// is already stored in repository
+	ErrTagAlreadyExists = apperrors.New(apperrors.KindAlreadyExists, "tag", "tag already exists")
+	// ErrTagNotFound is raised when specified tag has not been found
+	// in repository
+	ErrTagNotFound = apperrors.New(apperrors.KindNotFound, "tag", "tag not found")
+	// ErrInvalidLocation is raised when a location fails validation.
+	// Errors derived from it tell which field is invalid and why.
+	ErrInvalidLocation = apperrors.New(apperrors.KindInvalid, "location", "invalid location")
+	// ErrInvalidCategory is raised when a category fails validation
+	ErrInvalidCategory = apperrors.New(apperrors.KindInvalid, "category", "invalid category")
+	// ErrInvalidTag is raised when a tag fails validation
+	ErrInvalidTag = apperrors.New(apperrors.KindInvalid, "tag", "invalid tag")
+	// ErrShareNotFound is raised when no role has been granted to specified user
+	ErrShareNotFound = apperrors.New(apperrors.KindNotFound, "share", "share not found")
+	// ErrInvalidShare is raised when a share fails validation
+	ErrInvalidShare = apperrors.New(apperrors.KindInvalid, "share", "invalid share")
+	// ErrSharedWithOwner is raised when sharing a location or a category with its owner
+	ErrSharedWithOwner = apperrors.New(apperrors.KindInvalid, "share", "cannot share with the owner").WithField("user_id")
+	// ErrGroupNotFound is raised when specified group has not been found
+	// among the groups of the user
+	ErrGroupNotFound = apperrors.New(apperrors.KindNotFound, "group", "group not found")
+	// ErrGroupMemberNotFound is raised when specified user is not a member of the group
+	ErrGroupMemberNotFound = apperrors.New(apperrors.KindNotFound, "group", "group member not found")
+	// ErrInvalidGroup is raised when a group fails validation
+	ErrInvalidGroup = apperrors.New(apperrors.KindInvalid, "group", "invalid group")
+	// ErrInvalidGroupMember is raised when a group member fails validation
+	ErrInvalidGroupMember = apperrors.New(apperrors.KindInvalid, "group", "invalid group member")
+	// ErrLastGroupOwner is raised when the last owner of a group would be removed or demoted
+	ErrLastGroupOwner = apperrors.New(apperrors.KindConflict, "group", "group must keep at least one owner")
+	// ErrShareLinkNotFound is raised when a share link does not exist, has expired or has been revoked
+	ErrShareLinkNotFound = apperrors.New(apperrors.KindNotFound, "share_link", "share link not found")
+	// ErrInvalidShareLink is raised when a share link fails validation
+	ErrInvalidShareLink = apperrors.New(apperrors.KindInvalid, "share_link", "invalid share link")
 )
 
+// CategoryInUseError is raised when deleting a category that still has locations,
+// without reassigning them or deleting them along with the category
+type CategoryInUseError struct {
+	Locations int
+}
+
+func (e *CategoryInUseError) Error() string {
+	return fmt.Sprintf("category still has %d locations", e.Locations)
+}
+
+// Unwrap makes CategoryInUseError match ErrCategoryInUse, with a message telling the count of locations
+func (e *CategoryInUseError) Unwrap() error {
+	return ErrCategoryInUse.WithMessage(e.Error())
+}
+
 // LocationRepository describes how to create, get, find, update and delete
-// locations and categories in a repository
+// locations, categories, tags, groups and share links in a repository.
+// Violations of repository constraints are reported by wrapping the matching usecase error.
 type LocationRepository interface {
+	// WithinTx runs fn in a single transaction, which operations called with the context given to fn are part of
+	WithinTx(ctx context.Context, fn func(context.Context) error) error
+
 	CreateCategory(context.Context, *models.Category) error
 	GetCategories(context.Context) (*models.Categories, error)
 	FindCategoryByID(context.Context, models.ID) (*models.Category, error)
+	FindVisibleCategoryByID(context.Context, models.ID) (*models.Category, error)
 	FindCategoryByName(context.Context, string) (*models.Category, error)
 	UpdateCategory(context.Context, *models.Category) error
-	DeleteCategory(context.Context, models.ID) error
+	DeleteCategory(ctx context.Context, id models.ID, deletion models.CategoryDeletion) error
+	UpdateDefaultCategory(context.Context, *models.Category) error
+	DeleteDefaultCategory(ctx context.Context, id models.ID, reassignTo *models.ID) error
+	CountLocationsByCategory(context.Context, models.ID) (int, error)
+	MergeCategory(ctx context.Context, id models.ID, target models.ID) error
 
 	CreateLocation(context.Context, *models.Location) error
 	GetLocations(context.Context) (*models.Locations, error)
 	FindLocationByID(context.Context, models.ID) (*models.Location, error)
 	FindLocationByName(context.Context, string) (*models.Location, error)
-	FindLocationsByCategory(context.Context, *models.Category) (*models.Locations, error)
+	FindLocationsByCategory(ctx context.Context, cat *models.Category, descendants bool) (*models.Locations, error)
+	FindExpandedLocations(context.Context, *models.Category) (*models.Locations, error)
+	FindExpandedLocationByID(context.Context, models.ID) (*models.Location, error)
+	StreamLocations(context.Context, *models.Category, func(*models.Location) error) error
+	FindLocationsNear(ctx context.Context, cat *models.Category, center models.Point, radius float64) (*models.Locations, error)
+	FindLocationsInBoundingBox(context.Context, *models.Category, models.BoundingBox) (*models.Locations, error)
 	UpdateLocation(context.Context, *models.Location) error
 	DeleteLocation(context.Context, models.ID) error
+	MoveLocations(context.Context, []models.ID, *models.Category) ([]models.ID, error)
+
+	CreateTag(context.Context, *models.Tag) error
+	GetTags(context.Context) (*models.Tags, error)
+	FindTagByID(context.Context, models.ID) (*models.Tag, error)
+	FindTagByName(context.Context, string) (*models.Tag, error)
+	UpdateTag(context.Context, *models.Tag) error
+	DeleteTag(context.Context, models.ID) error
+	SetLocationTags(ctx context.Context, id models.ID, names []string) error
+	FindLocationsByTags(ctx context.Context, cat *models.Category, names []string, matchAll bool) (*models.Locations, error)
+
+	GetLocationShares(context.Context, models.ID) (*models.Shares, error)
+	SetLocationShare(ctx context.Context, id models.ID, share *models.Share) error
+	DeleteLocationShare(ctx context.Context, id models.ID, user models.ID) error
+	GetCategoryShares(context.Context, models.ID) (*models.Shares, error)
+	SetCategoryShare(ctx context.Context, id models.ID, share *models.Share) error
+	DeleteCategoryShare(ctx context.Context, id models.ID, user models.ID) error
+
+	CreateGroup(context.Context, *models.Group) error
+	GetGroups(context.Context) (*models.Groups, error)
+	FindGroupByID(context.Context, models.ID) (*models.Group, error)
+	UpdateGroup(context.Context, *models.Group) error
+	DeleteGroup(context.Context, models.ID) error
+	GetGroupMembers(context.Context, models.ID) (*models.GroupMembers, error)
+	LockGroupMembers(context.Context, models.ID) (*models.GroupMembers, error)
+	SetGroupMember(ctx context.Context, id models.ID, member *models.GroupMember) error
+	DeleteGroupMember(ctx context.Context, id models.ID, user models.ID) error
+
+	CreateShareLink(context.Context, *models.ShareLink) error
+	GetShareLinks(context.Context, models.ID) (*models.ShareLinks, error)
+	DeleteShareLink(context.Context, models.ID) error
+	FindPublicLocationByShareLink(context.Context, models.ID) (*models.PublicLocation, error)
+}
+
+// Geocoder describes how to resolve addresses into places, and coordinates into addresses.
+// A nil place is returned when the address or the coordinates cannot be found.
+type Geocoder interface {
+	Geocode(context.Context, string) (*models.Place, error)
+	Reverse(ctx context.Context, latitude, longitude float64) (*models.Place, error)
+}
+
+// ShareLinkSigner describes how to sign share links into tokens given to guests, and to verify tokens.
+// Verify returns the ID of the share link a token was signed for, or an error when it is invalid or expired.
+type ShareLinkSigner interface {
+	Sign(id models.ID, expiresAt time.Time) string
+	Verify(token string) (models.ID, error)
 }
 
 // LocationUsecase represents a usecase around location handling
 type LocationUsecase struct {
-	repo LocationRepository
+	repo       LocationRepository
+	geocoder   Geocoder
+	signer     ShareLinkSigner
+	authorizer *Authorizer
 }
 
-// NewLocationUsecase creates a new LocationUsecase object
-func NewLocationUsecase(repo LocationRepository) *LocationUsecase {
-	return &LocationUsecase{repo}
+// NewLocationUsecase creates a new LocationUsecase object, whose operations are authorized according to Policies
+func NewLocationUsecase(repo LocationRepository, geocoder Geocoder, signer ShareLinkSigner) *LocationUsecase {
+	return &LocationUsecase{repo, geocoder, signer, NewAuthorizer(Policies)}
 }
 
 // CreateCategory stores a new location category in repository
 func (u *LocationUsecase) CreateCategory(ctx context.Context, cat *models.Category) error {
+	if err := u.authorizer.Authorize(ctx, "CreateCategory"); err != nil {
+		return err
+	}
+
+	if err := validateCategory(cat); err != nil {
+		return err
+	}
+
 	catWithSameName, err := u.repo.FindCategoryByName(ctx, cat.Name)
 	if err != nil {
 		return fmt.Errorf("CreateCategory: failed to find category by name in repository : %s. %w", cat.Name, err)
@@ -62,15 +214,27 @@ func (u *LocationUsecase) CreateCategory(ctx context.Context, cat *models.Catego
 		return ErrCategoryAlreadyExists
 	}
 
-	if err := u.repo.CreateCategory(ctx, cat); err != nil {
+	if err := u.checkCategoryParent(ctx, cat); err != nil {
+		return err
+	}
+
+	err = u.repo.CreateCategory(ctx, cat)
+	switch {
+	case errors.Is(err, ErrParentCategoryNotFound):
+		return ErrParentCategoryNotFound
+	case err != nil:
 		return fmt.Errorf("CreateCategory: failed to create category in repository : %v. %w", cat, err)
 	}
 
 	return nil
 }
 
-// GetCategories returns all categories of a specific user
+// GetCategories returns all categories of a specific user, along with default categories
 func (u *LocationUsecase) GetCategories(ctx context.Context) (*models.Categories, error) {
+	if err := u.authorizer.Authorize(ctx, "GetCategories"); err != nil {
+		return nil, err
+	}
+
 	cats, err := u.repo.GetCategories(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("GetCategories: failed to get categories from repository. %w", err)
@@ -79,9 +243,14 @@ func (u *LocationUsecase) GetCategories(ctx context.Context) (*models.Categories
 	return cats, nil
 }
 
-// FindCategoryByID returns category matching specified ID or nil
+// FindCategoryByID returns category matching specified ID or nil. Categories shared with the user,
+// or holding locations visible to them, are found too.
 func (u *LocationUsecase) FindCategoryByID(ctx context.Context, id models.ID) (*models.Category, error) {
-	cat, err := u.repo.FindCategoryByID(ctx, id)
+	if err := u.authorizer.Authorize(ctx, "FindCategoryByID"); err != nil {
+		return nil, err
+	}
+
+	cat, err := u.repo.FindVisibleCategoryByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("FindCategoryByID: failed to get category by id, %s. %w", id, err)
 	}
@@ -92,8 +261,28 @@ func (u *LocationUsecase) FindCategoryByID(ctx context.Context, id models.ID) (*
 	return cat, nil
 }
 
-// UpdateCategory updates specified location
+// authorizeCategoryWrite returns ErrCategoryReadOnly when cat is a default category,
+// unless the user is allowed to manage default categories
+func (u *LocationUsecase) authorizeCategoryWrite(ctx context.Context, cat *models.Category) error {
+	if !cat.IsDefault() {
+		return nil
+	}
+	if err := u.authorizer.Authorize(ctx, "ManageDefaultCategories"); err != nil {
+		return ErrCategoryReadOnly
+	}
+	return nil
+}
+
+// UpdateCategory updates specified category. Default categories are updated by users allowed to manage them.
 func (u *LocationUsecase) UpdateCategory(ctx context.Context, cat *models.Category) error {
+	if err := u.authorizer.Authorize(ctx, "UpdateCategory"); err != nil {
+		return err
+	}
+
+	if err := validateCategory(cat); err != nil {
+		return err
+	}
+
 	catByID, err := u.repo.FindCategoryByID(ctx, cat.ID)
 	if err != nil {
 		return fmt.Errorf("UpdateCategory: failed to find category by id, %s. %w", cat.ID, err)
@@ -101,16 +290,90 @@ func (u *LocationUsecase) UpdateCategory(ctx context.Context, cat *models.Catego
 	if catByID == nil {
 		return ErrCategoryNotFound
 	}
+	if err := u.authorizeCategoryWrite(ctx, catByID); err != nil {
+		return err
+	}
+
+	if err := u.checkCategoryParent(ctx, cat); err != nil {
+		return err
+	}
 
-	if err := u.repo.UpdateCategory(ctx, cat); err != nil {
+	if catByID.IsDefault() {
+		if err := u.checkDefaultCategoryParent(ctx, cat); err != nil {
+			return err
+		}
+		// Default categories keep having no owner
+		cat.User = nil
+		err = u.repo.UpdateDefaultCategory(ctx, cat)
+	} else {
+		err = u.repo.UpdateCategory(ctx, cat)
+	}
+	switch {
+	case errors.Is(err, ErrParentCategoryNotFound):
+		return ErrParentCategoryNotFound
+	case err != nil:
 		return fmt.Errorf("UpdateCategory: failed to update category, %s. %w", cat.ID, err)
 	}
 
 	return nil
 }
 
-// DeleteCategory deletes specified category
-func (u *LocationUsecase) DeleteCategory(ctx context.Context, id models.ID) error {
+// checkCategoryParent makes sure that the parent of cat exists, and that cat is none of its ancestors
+func (u *LocationUsecase) checkCategoryParent(ctx context.Context, cat *models.Category) error {
+	if cat.Parent == nil {
+		return nil
+	}
+
+	visited := map[models.ID]bool{cat.ID: true}
+	for id := cat.Parent; id != nil; {
+		if visited[*id] {
+			return ErrCategoryCycle
+		}
+		visited[*id] = true
+
+		ancestor, err := u.repo.FindCategoryByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("checkCategoryParent: failed to find category by id, %s. %w", *id, err)
+		}
+		if ancestor == nil {
+			if *id == *cat.Parent {
+				return ErrParentCategoryNotFound
+			}
+			break
+		}
+		id = ancestor.Parent
+	}
+
+	return nil
+}
+
+// checkDefaultCategoryParent makes sure that the parent of default category cat is a default category too,
+// since user categories are not visible to other users
+func (u *LocationUsecase) checkDefaultCategoryParent(ctx context.Context, cat *models.Category) error {
+	if cat.Parent == nil {
+		return nil
+	}
+
+	parent, err := u.repo.FindCategoryByID(ctx, *cat.Parent)
+	if err != nil {
+		return fmt.Errorf("checkDefaultCategoryParent: failed to find category by id, %s. %w", *cat.Parent, err)
+	}
+	if parent == nil || !parent.IsDefault() {
+		return ErrParentCategoryNotFound
+	}
+
+	return nil
+}
+
+// DeleteCategory deletes specified category. Categories that still have locations are not deleted,
+// unless their locations are reassigned to another category or deleted along with them.
+// Default categories are deleted by users allowed to manage them. Their locations belong to many users,
+// so they may only be reassigned to another default category.
+func (u *LocationUsecase) DeleteCategory(ctx context.Context, id models.ID, deletion models.CategoryDeletion) error {
+	if err := u.authorizer.Authorize(ctx, "DeleteCategory"); err != nil {
+		return err
+	}
+
 	cat, err := u.repo.FindCategoryByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("DeleteCategory: failed to find category by id, %s. %w", id, err)
@@ -118,16 +381,64 @@ func (u *LocationUsecase) DeleteCategory(ctx context.Context, id models.ID) erro
 	if cat == nil {
 		return ErrCategoryNotFound
 	}
-
-	if err := u.repo.DeleteCategory(ctx, id); err != nil {
+	if err := u.authorizeCategoryWrite(ctx, cat); err != nil {
+		return err
+	}
+	if cat.IsDefault() && deletion.Cascade {
+		return ErrDefaultCategoryCascade
+	}
+
+	if deletion.ReassignTo != nil {
+		if *deletion.ReassignTo == id {
+			return ErrCategoryReassignedToItself
+		}
+
+		target, err := u.repo.FindCategoryByID(ctx, *deletion.ReassignTo)
+		if err != nil {
+			return fmt.Errorf("DeleteCategory: failed to find category by id, %s. %w", *deletion.ReassignTo, err)
+		}
+		if target == nil || (cat.IsDefault() && !target.IsDefault()) {
+			return ErrTargetCategoryNotFound
+		}
+	}
+
+	if cat.IsDefault() {
+		err = u.repo.DeleteDefaultCategory(ctx, id, deletion.ReassignTo)
+		switch {
+		case errors.Is(err, ErrCategoryInUse):
+			// Locations left belong to many users, so they are not counted
+			return ErrCategoryInUse
+		case err != nil:
+			return fmt.Errorf("DeleteCategory: failed to delete default category, %s. %w", id, err)
+		}
+		return nil
+	}
+
+	err = u.repo.DeleteCategory(ctx, id, deletion)
+	switch {
+	case errors.Is(err, ErrCategoryInUse):
+		count, err := u.repo.CountLocationsByCategory(ctx, id)
+		if err != nil {
+			return fmt.Errorf("DeleteCategory: failed to count locations of category, %s. %w", id, err)
+		}
+		return &CategoryInUseError{count}
+	case err != nil:
 		return fmt.Errorf("DeleteCategory: failed to delete category, %s. %w", id, err)
 	}
 
 	return nil
 }
 
-// CreateLocation stores a new user location in repository
+// CreateLocation stores a new user location in repository, or a location of a group the user is a member of
 func (u *LocationUsecase) CreateLocation(ctx context.Context, loc *models.Location) error {
+	if err := u.authorizer.Authorize(ctx, "CreateLocation"); err != nil {
+		return err
+	}
+
+	if err := validateLocation(loc, true); err != nil {
+		return err
+	}
+
 	locWithSameName, err := u.repo.FindLocationByName(ctx, loc.Name)
 	if err != nil {
 		return fmt.Errorf("CreateLocation: failed to find location by name in repository : %s. %w", loc.Name, err)
@@ -144,15 +455,64 @@ func (u *LocationUsecase) CreateLocation(ctx context.Context, loc *models.Locati
 		return ErrCategoryNotFound
 	}
 
-	if err := u.repo.CreateLocation(ctx, loc); err != nil {
-		return fmt.Errorf("CreateLocation: failed to create location in repository : %v. %w", loc, err)
+	if loc.Group != nil {
+		group, err := u.repo.FindGroupByID(ctx, *loc.Group)
+		if err != nil {
+			return fmt.Errorf("CreateLocation: failed to find group by ID, %s. %w", *loc.Group, err)
+		}
+		if group == nil {
+			return ErrGroupNotFound
+		}
+	}
+
+	if loc.Address == "" && !loc.Components.IsZero() {
+		loc.Address = addresses.Format(loc.Components)
+	}
+	// Components are parsed from the address as entered, before the geocoder normalizes it
+	parseAddress(loc)
+
+	switch {
+	case loc.Latitude == nil || loc.Longitude == nil:
+		u.resolveAddress(ctx, loc)
+	case loc.Address == "":
+		u.resolveCoordinates(ctx, loc)
+		parseAddress(loc)
+	}
+
+	err = u.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.CreateLocation(ctx, loc); err != nil {
+			return fmt.Errorf("failed to create location in repository : %v. %w", loc, err)
+		}
+
+		if len(loc.Tags) > 0 {
+			if err := u.repo.SetLocationTags(ctx, loc.ID, loc.Tags); err != nil {
+				return fmt.Errorf("failed to set tags of location %s. %w", loc.ID, err)
+			}
+		}
+
+		return nil
+	})
+	// Checks above may pass for concurrent requests, which are then caught by repository constraints
+	switch {
+	case errors.Is(err, ErrLocationAlreadyExists):
+		return ErrLocationAlreadyExists
+	case errors.Is(err, ErrCategoryNotFound):
+		return ErrCategoryNotFound
+	case errors.Is(err, ErrGroupNotFound):
+		return ErrGroupNotFound
+	case err != nil:
+		return fmt.Errorf("CreateLocation: %w", err)
 	}
 
 	return nil
 }
 
-// GetLocations returns all locations of a specific user
+// GetLocations returns all locations of a specific user, along with locations of their groups and locations shared with them
 func (u *LocationUsecase) GetLocations(ctx context.Context) (*models.Locations, error) {
+	if err := u.authorizer.Authorize(ctx, "GetLocations"); err != nil {
+		return nil, err
+	}
+
 	locations, err := u.repo.GetLocations(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("GetLocations: failed to get locations from repository. %w", err)
@@ -161,8 +521,12 @@ func (u *LocationUsecase) GetLocations(ctx context.Context) (*models.Locations,
 	return locations, nil
 }
 
-// FindLocationByID returns location matching specified ID or nil
+// FindLocationByID returns user location, or location of a group of the user or shared with them, matching specified ID or nil
 func (u *LocationUsecase) FindLocationByID(ctx context.Context, id models.ID) (*models.Location, error) {
+	if err := u.authorizer.Authorize(ctx, "FindLocationByID"); err != nil {
+		return nil, err
+	}
+
 	location, err := u.repo.FindLocationByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("FindLocationByID: failed to get location by id, %s. %w", id, err)
@@ -174,9 +538,14 @@ func (u *LocationUsecase) FindLocationByID(ctx context.Context, id models.ID) (*
 	return location, nil
 }
 
-// FindLocationsByCategory returns locations matching specified category or nil
-func (u *LocationUsecase) FindLocationsByCategory(ctx context.Context, catID models.ID) (*models.Locations, error) {
-	cat, err := u.repo.FindCategoryByID(ctx, catID)
+// FindLocationsByCategory returns locations visible to the user matching specified category or nil.
+// Locations of all descendant categories are included when descendants is true.
+func (u *LocationUsecase) FindLocationsByCategory(ctx context.Context, catID models.ID, descendants bool) (*models.Locations, error) {
+	if err := u.authorizer.Authorize(ctx, "FindLocationsByCategory"); err != nil {
+		return nil, err
+	}
+
+	cat, err := u.repo.FindVisibleCategoryByID(ctx, catID)
 	if err != nil {
 		return nil, fmt.Errorf("FindLocationByID: failed to find category by ID, %s. %w", catID, err)
 	}
@@ -184,16 +553,122 @@ func (u *LocationUsecase) FindLocationsByCategory(ctx context.Context, catID mod
 		return nil, ErrCategoryNotFound
 	}
 
-	locations, err := u.repo.FindLocationsByCategory(ctx, cat)
+	locations, err := u.repo.FindLocationsByCategory(ctx, cat, descendants)
 	if err != nil {
-		return nil, fmt.Errorf("FindLocationsByCategory: failed to find locations by category, %s. %w", cat, err)
+		return nil, fmt.Errorf("FindLocationsByCategory: failed to find locations by category, %s. %w", catID, err)
 	}
 
 	return locations, nil
 }
 
-// UpdateLocation updates specified location
+// GetExpandedLocations returns locations visible to the user with their category embedded,
+// filtered by category unless catID is NilID
+func (u *LocationUsecase) GetExpandedLocations(ctx context.Context, catID models.ID) (*models.Locations, error) {
+	if err := u.authorizer.Authorize(ctx, "GetExpandedLocations"); err != nil {
+		return nil, err
+	}
+
+	var cat *models.Category
+	if catID != models.NilID {
+		var err error
+		cat, err = u.repo.FindVisibleCategoryByID(ctx, catID)
+		if err != nil {
+			return nil, fmt.Errorf("GetExpandedLocations: failed to find category by ID, %s. %w", catID, err)
+		}
+		if cat == nil {
+			return nil, ErrCategoryNotFound
+		}
+	}
+
+	locations, err := u.repo.FindExpandedLocations(ctx, cat)
+	if err != nil {
+		return nil, fmt.Errorf("GetExpandedLocations: failed to find expanded locations. %w", err)
+	}
+
+	return locations, nil
+}
+
+// FindLocationsNear returns locations visible to the user within radius meters of center, nearest first,
+// filtered by category unless catID is NilID
+func (u *LocationUsecase) FindLocationsNear(ctx context.Context, catID models.ID, center models.Point, radius float64) (*models.Locations, error) {
+	if err := u.authorizer.Authorize(ctx, "FindLocationsNear"); err != nil {
+		return nil, err
+	}
+
+	var cat *models.Category
+	if catID != models.NilID {
+		var err error
+		cat, err = u.repo.FindVisibleCategoryByID(ctx, catID)
+		if err != nil {
+			return nil, fmt.Errorf("FindLocationsNear: failed to find category by ID, %s. %w", catID, err)
+		}
+		if cat == nil {
+			return nil, ErrCategoryNotFound
+		}
+	}
+
+	locations, err := u.repo.FindLocationsNear(ctx, cat, center, radius)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsNear: failed to find locations near %v. %w", center, err)
+	}
+
+	return locations, nil
+}
+
+// FindExpandedLocationByID returns location matching specified ID, with its category embedded
+func (u *LocationUsecase) FindExpandedLocationByID(ctx context.Context, id models.ID) (*models.Location, error) {
+	if err := u.authorizer.Authorize(ctx, "FindExpandedLocationByID"); err != nil {
+		return nil, err
+	}
+
+	location, err := u.repo.FindExpandedLocationByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("FindExpandedLocationByID: failed to get expanded location by id, %s. %w", id, err)
+	}
+	if location == nil {
+		return nil, ErrLocationNotFound
+	}
+
+	return location, nil
+}
+
+// StreamLocations calls fn for each location visible to the user, filtered by category unless catID is NilID,
+// without loading all of them in memory.
+func (u *LocationUsecase) StreamLocations(ctx context.Context, catID models.ID, fn func(*models.Location) error) error {
+	if err := u.authorizer.Authorize(ctx, "StreamLocations"); err != nil {
+		return err
+	}
+
+	var cat *models.Category
+	if catID != models.NilID {
+		var err error
+		cat, err = u.repo.FindVisibleCategoryByID(ctx, catID)
+		if err != nil {
+			return fmt.Errorf("StreamLocations: failed to find category by ID, %s. %w", catID, err)
+		}
+		if cat == nil {
+			return ErrCategoryNotFound
+		}
+	}
+
+	if err := u.repo.StreamLocations(ctx, cat, fn); err != nil {
+		return fmt.Errorf("StreamLocations: failed to stream locations. %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLocation updates specified location. Members of its group, and users it is shared with
+// as owner or editor, may update it too, except for its category and tags.
 func (u *LocationUsecase) UpdateLocation(ctx context.Context, loc *models.Location) error {
+	if err := u.authorizer.Authorize(ctx, "UpdateLocation"); err != nil {
+		return err
+	}
+
+	if err := validateLocation(loc, false); err != nil {
+		return err
+	}
+
 	locByID, err := u.repo.FindLocationByID(ctx, loc.ID)
 	if err != nil {
 		return fmt.Errorf("UpdateLocation: failed to find location by id, %s. %w", loc.ID, err)
@@ -201,14 +676,31 @@ func (u *LocationUsecase) UpdateLocation(ctx context.Context, loc *models.Locati
 	if locByID == nil {
 		return ErrLocationNotFound
 	}
+	if !locByID.Role.CanEdit() {
+		return ErrForbidden
+	}
 
-	cat, err := u.repo.FindCategoryByID(ctx, loc.Category)
-	if err != nil {
-		return fmt.Errorf("UpdateLocation: failed to find category by ID, %s. %w", loc.Category, err)
+	if locByID.User != loc.User {
+		// Categories and tags belong to the owner of the location, so users it is shared with cannot change them
+		if (loc.Category != models.NilID && loc.Category != locByID.Category) || loc.Tags != nil {
+			return ErrForbidden.WithMessage("only the owner of the location may change its category and tags")
+		}
+		loc.User = locByID.User
+	} else {
+		cat, err := u.repo.FindCategoryByID(ctx, loc.Category)
+		if err != nil {
+			return fmt.Errorf("UpdateLocation: failed to find category by ID, %s. %w", loc.Category, err)
+		}
+		if cat == nil {
+			return ErrCategoryNotFound
+		}
 	}
-	if cat == nil {
-		return ErrCategoryNotFound
+	loc.Role = locByID.Role
+
+	if loc.Address == "" && !loc.Components.IsZero() {
+		loc.Address = addresses.Format(loc.Components)
 	}
+	addressChanged := loc.Address != "" && loc.Address != locByID.Address
 
 	// Merge existing location with new fields to update
 	if loc.Name == "" {
@@ -217,19 +709,123 @@ func (u *LocationUsecase) UpdateLocation(ctx context.Context, loc *models.Locati
 	if loc.Address == "" {
 		loc.Address = locByID.Address
 	}
+	if loc.Components.IsZero() && !addressChanged {
+		loc.Components = locByID.Components
+	}
+	parseAddress(loc)
 	if loc.Category == models.NilID {
 		loc.Category = locByID.Category
 	}
-
-	if err := u.repo.UpdateLocation(ctx, loc); err != nil {
-		return fmt.Errorf("UpdateLocation: failed to update location, %s. %w", loc.ID, err)
+	if loc.Latitude == nil || loc.Longitude == nil {
+		if addressChanged || locByID.Latitude == nil || locByID.Longitude == nil {
+			u.resolveAddress(ctx, loc)
+		} else {
+			loc.Latitude, loc.Longitude = locByID.Latitude, locByID.Longitude
+		}
+	}
+
+	err = u.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateLocation(ctx, loc); err != nil {
+			return fmt.Errorf("failed to update location, %s. %w", loc.ID, err)
+		}
+
+		// Tags are only replaced when set, even to an empty list
+		if loc.Tags == nil {
+			loc.Tags = locByID.Tags
+		} else if err := u.repo.SetLocationTags(ctx, loc.ID, loc.Tags); err != nil {
+			return fmt.Errorf("failed to set tags of location %s. %w", loc.ID, err)
+		}
+
+		return nil
+	})
+	switch {
+	case errors.Is(err, ErrLocationAlreadyExists):
+		return ErrLocationAlreadyExists
+	case errors.Is(err, ErrCategoryNotFound):
+		return ErrCategoryNotFound
+	case err != nil:
+		return fmt.Errorf("UpdateLocation: %w", err)
 	}
 
 	return nil
 }
 
-// DeleteLocation deletes specified location
+// parseAddress sets location address components from its address, unless they are already set
+func parseAddress(loc *models.Location) {
+	if loc.Components.IsZero() {
+		loc.Components = ParseAddressComponents(loc.Address)
+	}
+}
+
+// ParseAddressComponents parses components of a free text address. They are cut to the limits
+// of user input, as parts of a valid address may exceed them.
+func ParseAddressComponents(address string) models.AddressComponents {
+	c := addresses.Parse(address)
+	c.Street = truncate(c.Street, maxStreetLength)
+	c.HouseNumber = truncate(c.HouseNumber, maxHouseNumberLength)
+	c.PostalCode = truncate(c.PostalCode, maxPostalCodeLength)
+	c.Locality = truncate(c.Locality, maxLocalityLength)
+	c.Region = truncate(c.Region, maxRegionLength)
+
+	return c
+}
+
+// resolveAddress sets location coordinates and normalized address using the geocoder.
+// Geocoding failures are not fatal: coordinates are left empty to be resolved later.
+func (u *LocationUsecase) resolveAddress(ctx context.Context, loc *models.Location) {
+	place, err := u.geocoder.Geocode(ctx, loc.Address)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("resolveAddress: failed to geocode address of location %s. %v", loc.ID, err)
+		return
+	}
+	if place == nil {
+		return
+	}
+
+	loc.Address = place.Address
+	loc.SetCoordinates(place.Latitude, place.Longitude)
+}
+
+// resolveCoordinates sets location address using the geocoder reverse lookup.
+// Coordinates are used as address when none can be found.
+func (u *LocationUsecase) resolveCoordinates(ctx context.Context, loc *models.Location) {
+	place, err := u.reverseGeocode(ctx, *loc.Latitude, *loc.Longitude)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("resolveCoordinates: failed to reverse geocode coordinates of location %s. %v", loc.ID, err)
+		place = models.NewPlaceFromCoordinates(*loc.Latitude, *loc.Longitude)
+	}
+
+	loc.Address = place.Address
+}
+
+// ReverseGeocode returns the place found at specified coordinates. Coordinates
+// are used as address when none can be found or when geocoding is disabled.
+func (u *LocationUsecase) ReverseGeocode(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	if err := u.authorizer.Authorize(ctx, "ReverseGeocode"); err != nil {
+		return nil, err
+	}
+
+	return u.reverseGeocode(ctx, latitude, longitude)
+}
+
+func (u *LocationUsecase) reverseGeocode(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	place, err := u.geocoder.Reverse(ctx, latitude, longitude)
+	if err != nil {
+		return nil, fmt.Errorf("ReverseGeocode: failed to reverse geocode coordinates %f,%f. %w", latitude, longitude, err)
+	}
+	if place == nil {
+		return models.NewPlaceFromCoordinates(latitude, longitude), nil
+	}
+
+	return place, nil
+}
+
+// DeleteLocation deletes specified location, unless only shared with the user as editor or viewer
 func (u *LocationUsecase) DeleteLocation(ctx context.Context, id models.ID) error {
+	if err := u.authorizer.Authorize(ctx, "DeleteLocation"); err != nil {
+		return err
+	}
+
 	loc, err := u.repo.FindLocationByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("DeleteLocation: failed to find location by id, %s. %w", id, err)
@@ -237,6 +833,9 @@ func (u *LocationUsecase) DeleteLocation(ctx context.Context, id models.ID) erro
 	if loc == nil {
 		return ErrLocationNotFound
 	}
+	if !loc.Role.CanManage() {
+		return ErrForbidden
+	}
 
 	if err := u.repo.DeleteLocation(ctx, id); err != nil {
 		return fmt.Errorf("DeleteLocation: failed to delete location, %s. %w", id, err)
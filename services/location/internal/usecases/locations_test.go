@@ -1,20 +1,23 @@
 package usecases
 
 import (
-	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
 	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCreateCategoryWithRepositoryFindCategoryByNameError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("CreateCategory", ctx, cat).Return(nil)
 	repo.On("FindCategoryByName", ctx, "Test Category").Return(nil, errors.New("failed"))
@@ -25,9 +28,9 @@ func TestCreateCategoryWithRepositoryFindCategoryByNameError(t *testing.T) {
 
 func TestCreateCategoryWithRepositoryCreateCategoryError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("CreateCategory", ctx, cat).Return(errors.New("failed"))
 	repo.On("FindCategoryByName", ctx, "Test Category").Return(nil, nil)
@@ -38,9 +41,9 @@ func TestCreateCategoryWithRepositoryCreateCategoryError(t *testing.T) {
 
 func TestCreateCategoryWithNameAlreadyExisting(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("CreateCategory", ctx, cat).Return(nil)
 	repo.On("FindCategoryByName", ctx, "Test Category").Return(cat, nil)
@@ -49,11 +52,42 @@ func TestCreateCategoryWithNameAlreadyExisting(t *testing.T) {
 	assert.Equal(t, err, ErrCategoryAlreadyExists)
 }
 
+func TestCreateCategoryWithParentNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Tennis")
+	cat.SetParent(models.NewID())
+	repo.On("FindCategoryByName", ctx, "Tennis").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, *cat.Parent).Return(nil, nil)
+
+	err := usecase.CreateCategory(ctx, cat)
+	assert.Equal(t, ErrParentCategoryNotFound, err)
+	repo.AssertNotCalled(t, "CreateCategory", ctx, cat)
+}
+
+func TestCreateCategoryWithParent(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	sport := models.NewCategory(models.NewID(), "Sport")
+	cat := models.NewCategory(models.NewID(), "Tennis")
+	cat.SetParent(sport.ID)
+	repo.On("FindCategoryByName", ctx, "Tennis").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, sport.ID).Return(sport, nil)
+	repo.On("CreateCategory", ctx, cat).Return(nil)
+
+	err := usecase.CreateCategory(ctx, cat)
+	assert.NoError(t, err)
+}
+
 func TestCreateCategoryWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("CreateCategory", ctx, cat).Return(nil)
 	repo.On("FindCategoryByName", ctx, "Test Category").Return(nil, nil)
@@ -64,9 +98,9 @@ func TestCreateCategoryWithSuccess(t *testing.T) {
 
 func TestGetCategoriesWithRepositoryError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	repo.On("GetCategories", ctx).Return(nil, errors.New("failed"))
 
 	cats, err := usecase.GetCategories(ctx)
@@ -76,9 +110,9 @@ func TestGetCategoriesWithRepositoryError(t *testing.T) {
 
 func TestGetCategoriesWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cats := models.Categories{
 		models.NewCategory(models.NewID(), "Test Category"),
 	}
@@ -91,11 +125,11 @@ func TestGetCategoriesWithSuccess(t *testing.T) {
 
 func TestFindCategoryByIDWithRepositoryError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	id := models.NewID()
-	repo.On("FindCategoryByID", ctx, id).Return(nil, errors.New("failed"))
+	repo.On("FindVisibleCategoryByID", ctx, id).Return(nil, errors.New("failed"))
 
 	cat, err := usecase.FindCategoryByID(ctx, id)
 	assert.Error(t, err)
@@ -104,11 +138,11 @@ func TestFindCategoryByIDWithRepositoryError(t *testing.T) {
 
 func TestFindCategoryByIDWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
-	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindVisibleCategoryByID", ctx, cat.ID).Return(cat, nil)
 
 	returnedCat, err := usecase.FindCategoryByID(ctx, cat.ID)
 	assert.NoError(t, err)
@@ -117,9 +151,9 @@ func TestFindCategoryByIDWithSuccess(t *testing.T) {
 
 func TestUpdateCategoryWithCategoryNotFoundError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, nil)
 	repo.On("UpdateCategory", ctx, cat).Return(nil)
@@ -130,9 +164,9 @@ func TestUpdateCategoryWithCategoryNotFoundError(t *testing.T) {
 
 func TestUpdateCategoryWithRepositoryUpdateCategoryError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
 	repo.On("UpdateCategory", ctx, cat).Return(errors.New("failed"))
@@ -143,9 +177,9 @@ func TestUpdateCategoryWithRepositoryUpdateCategoryError(t *testing.T) {
 
 func TestUpdateCategoryWithRepositoryFindCategoryByIDError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, errors.New("failed"))
 	repo.On("UpdateCategory", ctx, cat).Return(nil)
@@ -156,10 +190,11 @@ func TestUpdateCategoryWithRepositoryFindCategoryByIDError(t *testing.T) {
 
 func TestUpdateCategoryWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
 	repo.On("UpdateCategory", ctx, cat).Return(nil)
 
@@ -167,68 +202,297 @@ func TestUpdateCategoryWithSuccess(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUpdateCategoryWithCycle(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user := models.NewID()
+	sport := models.NewCategory(models.NewID(), "Sport")
+	sport.SetUser(user)
+	tennis := models.NewCategory(models.NewID(), "Tennis")
+	tennis.SetParent(sport.ID)
+	tennis.SetUser(user)
+	indoor := models.NewCategory(models.NewID(), "Indoor courts")
+	indoor.SetParent(tennis.ID)
+	indoor.SetUser(user)
+	repo.On("FindCategoryByID", ctx, sport.ID).Return(sport, nil)
+	repo.On("FindCategoryByID", ctx, tennis.ID).Return(tennis, nil)
+	repo.On("FindCategoryByID", ctx, indoor.ID).Return(indoor, nil)
+
+	moved := models.NewCategory(sport.ID, "Sport")
+	moved.SetParent(indoor.ID)
+	moved.SetUser(user)
+
+	err := usecase.UpdateCategory(ctx, moved)
+	assert.Equal(t, ErrCategoryCycle, err)
+	repo.AssertNotCalled(t, "UpdateCategory", ctx, moved)
+}
+
+func TestUpdateCategoryWithDefaultCategory(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+
+	err := usecase.UpdateCategory(ctx, cat)
+	assert.Equal(t, ErrCategoryReadOnly, err)
+	repo.AssertNotCalled(t, "UpdateCategory", ctx, cat)
+	repo.AssertNotCalled(t, "UpdateDefaultCategory", ctx, cat)
+}
+
+func TestUpdateCategoryWithDefaultCategoryAsAdmin(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContextWithUser([]string{RoleAdmin}, nil)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("UpdateDefaultCategory", ctx, cat).Return(nil)
+
+	err := usecase.UpdateCategory(ctx, cat)
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "UpdateDefaultCategory", ctx, cat)
+	repo.AssertNotCalled(t, "UpdateCategory", ctx, cat)
+}
+
+func TestUpdateCategoryWithDefaultCategoryUnderUserCategory(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContextWithUser([]string{RoleAdmin}, nil)
+	parent := models.NewCategory(models.NewID(), "Admin Category")
+	parent.SetUser(models.NewID())
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetParent(parent.ID)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindCategoryByID", ctx, parent.ID).Return(parent, nil)
+
+	err := usecase.UpdateCategory(ctx, cat)
+	assert.Equal(t, ErrParentCategoryNotFound, err)
+	repo.AssertNotCalled(t, "UpdateDefaultCategory", ctx, cat)
+}
+
 func TestDeleteCategoryWithRepositoryFindCategoryByIDError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, errors.New("failed"))
-	repo.On("DeleteCategory", ctx, cat.ID).Return(nil)
+	repo.On("DeleteCategory", ctx, cat.ID, models.CategoryDeletion{}).Return(nil)
 
-	err := usecase.DeleteCategory(ctx, cat.ID)
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{})
 	assert.Error(t, err)
 }
 
 func TestDeleteCategoryWithCategoryNotFoundError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, nil)
-	repo.On("DeleteCategory", ctx, cat.ID).Return(nil)
+	repo.On("DeleteCategory", ctx, cat.ID, models.CategoryDeletion{}).Return(nil)
 
-	err := usecase.DeleteCategory(ctx, cat.ID)
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{})
 	assert.Equal(t, err, ErrCategoryNotFound)
 }
 
+func TestDeleteCategoryWithDefaultCategory(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{})
+	assert.Equal(t, ErrCategoryReadOnly, err)
+	repo.AssertNotCalled(t, "DeleteCategory", ctx, cat.ID, models.CategoryDeletion{})
+}
+
+func TestDeleteCategoryWithDefaultCategoryAsAdmin(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContextWithUser([]string{RoleAdmin}, nil)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	target := models.NewCategory(models.NewID(), "Target Category")
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindCategoryByID", ctx, target.ID).Return(target, nil)
+	repo.On("DeleteDefaultCategory", ctx, cat.ID, &target.ID).Return(nil)
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{ReassignTo: &target.ID})
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "DeleteDefaultCategory", ctx, cat.ID, &target.ID)
+}
+
+func TestDeleteCategoryWithDefaultCategoryReassignedToUserCategory(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContextWithUser([]string{RoleAdmin}, nil)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	target := models.NewCategory(models.NewID(), "Target Category")
+	target.SetUser(models.NewID())
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindCategoryByID", ctx, target.ID).Return(target, nil)
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{ReassignTo: &target.ID})
+	assert.Equal(t, ErrTargetCategoryNotFound, err)
+	repo.AssertNotCalled(t, "DeleteDefaultCategory", ctx, cat.ID, &target.ID)
+}
+
+func TestDeleteCategoryWithDefaultCategoryCascade(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContextWithUser([]string{RoleAdmin}, nil)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{Cascade: true})
+	assert.Equal(t, ErrDefaultCategoryCascade, err)
+}
+
+func TestDeleteCategoryWithDefaultCategoryInUse(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContextWithUser([]string{RoleAdmin}, nil)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("DeleteDefaultCategory", ctx, cat.ID, (*models.ID)(nil)).Return(fmt.Errorf("failed. %w", ErrCategoryInUse))
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{})
+	assert.Equal(t, ErrCategoryInUse, err)
+	repo.AssertNotCalled(t, "CountLocationsByCategory", ctx, cat.ID)
+}
+
 func TestDeleteCategoryWithRepositoryDeleteCategoryError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
-	repo.On("DeleteCategory", ctx, cat.ID).Return(errors.New(("failed")))
+	repo.On("DeleteCategory", ctx, cat.ID, models.CategoryDeletion{}).Return(errors.New(("failed")))
 
-	err := usecase.DeleteCategory(ctx, cat.ID)
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{})
 	assert.Error(t, err)
 }
 
+func TestDeleteCategoryWithLocationsLeft(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("DeleteCategory", ctx, cat.ID, models.CategoryDeletion{}).Return(fmt.Errorf("still referenced. %w", ErrCategoryInUse))
+	repo.On("CountLocationsByCategory", ctx, cat.ID).Return(3, nil)
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{})
+	var inUse *CategoryInUseError
+	if assert.True(t, errors.As(err, &inUse)) {
+		assert.Equal(t, 3, inUse.Locations)
+	}
+	assert.True(t, errors.Is(err, ErrCategoryInUse))
+}
+
+func TestDeleteCategoryWithRepositoryCountLocationsByCategoryError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("DeleteCategory", ctx, cat.ID, models.CategoryDeletion{}).Return(ErrCategoryInUse)
+	repo.On("CountLocationsByCategory", ctx, cat.ID).Return(0, errors.New("failed"))
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{})
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCategoryInUse))
+}
+
+func TestDeleteCategoryWithReassignToItself(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{ReassignTo: &cat.ID})
+	assert.Equal(t, ErrCategoryReassignedToItself, err)
+}
+
+func TestDeleteCategoryWithTargetCategoryNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
+	target := models.NewID()
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindCategoryByID", ctx, target).Return(nil, nil)
+
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{ReassignTo: &target})
+	assert.Equal(t, ErrTargetCategoryNotFound, err)
+	repo.AssertNotCalled(t, "DeleteCategory", ctx, cat.ID, models.CategoryDeletion{ReassignTo: &target})
+}
+
+func TestDeleteCategoryWithReassignSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
+	target := models.NewCategory(models.NewID(), "Default Category")
+	deletion := models.CategoryDeletion{ReassignTo: &target.ID}
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindCategoryByID", ctx, target.ID).Return(target, nil)
+	repo.On("DeleteCategory", ctx, cat.ID, deletion).Return(nil)
+
+	err := usecase.DeleteCategory(ctx, cat.ID, deletion)
+	assert.NoError(t, err)
+}
+
 func TestDeleteCategoryWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(models.NewID())
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
-	repo.On("DeleteCategory", ctx, cat.ID).Return(nil)
+	repo.On("DeleteCategory", ctx, cat.ID, models.CategoryDeletion{Cascade: true}).Return(nil)
 
-	err := usecase.DeleteCategory(ctx, cat.ID)
+	err := usecase.DeleteCategory(ctx, cat.ID, models.CategoryDeletion{Cascade: true})
 	assert.NoError(t, err)
 }
 
 func TestCreateLocationWithRepositoryCreateLocationError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
 	repo.On("CreateLocation", ctx, loc).Return(errors.New("failed"))
 	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.CreateLocation(ctx, loc)
 	assert.Error(t, err)
@@ -236,14 +500,16 @@ func TestCreateLocationWithRepositoryCreateLocationError(t *testing.T) {
 
 func TestCreateLocationWithRepositoryFindLocationByNameError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
 	repo.On("CreateLocation", ctx, loc).Return(nil)
 	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, errors.New("failed"))
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.CreateLocation(ctx, loc)
 	assert.Error(t, err)
@@ -251,14 +517,16 @@ func TestCreateLocationWithRepositoryFindLocationByNameError(t *testing.T) {
 
 func TestCreateLocationWithRepositoryFindLocationByIDError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
 	repo.On("CreateLocation", ctx, loc).Return(nil)
 	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, errors.New("failed"))
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.CreateLocation(ctx, loc)
 	assert.Error(t, err)
@@ -266,14 +534,16 @@ func TestCreateLocationWithRepositoryFindLocationByIDError(t *testing.T) {
 
 func TestCreateLocationWithNameAlreadyExisting(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
 	repo.On("CreateLocation", ctx, loc).Return(nil)
 	repo.On("FindLocationByName", ctx, "Test Location").Return(loc, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.CreateLocation(ctx, loc)
 	assert.Equal(t, err, ErrLocationAlreadyExists)
@@ -281,14 +551,16 @@ func TestCreateLocationWithNameAlreadyExisting(t *testing.T) {
 
 func TestCreateLocationWithCategoryNotFound(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
 	repo.On("CreateLocation", ctx, loc).Return(nil)
 	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.CreateLocation(ctx, loc)
 	assert.Equal(t, err, ErrCategoryNotFound)
@@ -296,24 +568,271 @@ func TestCreateLocationWithCategoryNotFound(t *testing.T) {
 
 func TestCreateLocationWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+}
+
+func TestCreateLocationWithConcurrentLocationAlreadyExists(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	repo.On("CreateLocation", ctx, loc).Return(fmt.Errorf("CreateLocation: location already exists. %w", ErrLocationAlreadyExists))
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.Equal(t, ErrLocationAlreadyExists, err)
+}
+
+func TestUpdateLocationWithConcurrentCategoryDeletion(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+	loc.Role = models.ShareRoleOwner
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("UpdateLocation", ctx, loc).Return(fmt.Errorf("UpdateLocation: category not found. %w", ErrCategoryNotFound))
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.Equal(t, ErrCategoryNotFound, err)
+}
+
+func TestCreateLocationWithGeocodedAddress(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la poste paris", cat.ID, models.NewID())
+	place := models.NewPlace("1 Rue de la Poste, 75001 Paris, France", 48.8606111, 2.3376)
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, "1 rue de la poste paris").Return(place, nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, place.Address, loc.Address)
+	if assert.NotNil(t, loc.Latitude) && assert.NotNil(t, loc.Longitude) {
+		assert.Equal(t, place.Latitude, *loc.Latitude)
+		assert.Equal(t, place.Longitude, *loc.Longitude)
+	}
+}
+
+func TestCreateLocationWithGeocoderError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, errors.New("failed"))
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, "1 rue de la Poste, 75001 Paris", loc.Address)
+	assert.Nil(t, loc.Latitude)
+}
+
+func TestCreateLocationWithCoordinates(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	geocoder.AssertNotCalled(t, "Geocode", ctx, loc.Address)
+}
+
+func TestCreateLocationWithCoordinatesOnly(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "", cat.ID, models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Reverse", ctx, 48.8606111, 2.3376).Return(models.NewPlace("1, Rue de la Poste, Paris, France", 48.8606, 2.3375), nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, "1, Rue de la Poste, Paris, France", loc.Address)
+	assert.Equal(t, 48.8606111, *loc.Latitude)
+	assert.Equal(t, 2.3376, *loc.Longitude)
+}
+
+func TestCreateLocationWithCoordinatesOnlyAndGeocoderError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "", cat.ID, models.NewID())
+	loc.SetCoordinates(48.8606111, 2.3376)
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Reverse", ctx, 48.8606111, 2.3376).Return(nil, errors.New("failed"))
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, "48.8606111, 2.3376", loc.Address)
+}
+
+func TestCreateLocationParsesAddressBeforeGeocoding(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	place := models.NewPlace("1, Rue de la Poste, Quartier des Halles, Paris, Île-de-France, France métropolitaine, 75001, France", 48.8606, 2.3375)
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Geocode", ctx, "1 rue de la Poste, 75001 Paris").Return(place, nil)
 	repo.On("CreateLocation", ctx, loc).Return(nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, place.Address, loc.Address)
+	assert.Equal(t, models.AddressComponents{
+		Street:      "rue de la Poste",
+		HouseNumber: "1",
+		PostalCode:  "75001",
+		Locality:    "Paris",
+	}, loc.Components)
+}
+
+func TestCreateLocationWithOverlongAddressComponents(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	address := "1 rue " + strings.Repeat("é", 300) + ", 75001 " + strings.Repeat("Paris", 30)
+	loc := models.NewLocation(models.NewID(), "Test Location", address, cat.ID, models.NewID())
 	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Geocode", ctx, address).Return(nil, nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, "rue "+strings.Repeat("é", maxStreetLength-4), loc.Components.Street)
+	assert.Equal(t, maxStreetLength, utf8.RuneCountInString(loc.Components.Street))
+	assert.Equal(t, strings.Repeat("Paris", 20), loc.Components.Locality)
+	assert.NoError(t, validateLocation(loc, true))
+}
+
+func TestCreateLocationWithAddressComponentsOnly(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "", cat.ID, models.NewID())
+	loc.Components = models.AddressComponents{
+		Street:      "Hauptstraße",
+		HouseNumber: "5",
+		PostalCode:  "10115",
+		Locality:    "Berlin",
+		CountryCode: "DE",
+	}
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	geocoder.On("Geocode", ctx, "Hauptstraße 5, 10115 Berlin, Germany").Return(nil, nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
 
 	err := usecase.CreateLocation(ctx, loc)
 	assert.NoError(t, err)
+	assert.Equal(t, "Hauptstraße 5, 10115 Berlin, Germany", loc.Address)
+	assert.Equal(t, "Hauptstraße", loc.Components.Street)
+}
+
+func TestReverseGeocodeWithError(t *testing.T) {
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(new(mocks.LocationRepositoryMock), geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	geocoder.On("Reverse", ctx, 48.8606111, 2.3376).Return(nil, errors.New("failed"))
+
+	place, err := usecase.ReverseGeocode(ctx, 48.8606111, 2.3376)
+	assert.Error(t, err)
+	assert.Nil(t, place)
+}
+
+func TestReverseGeocodeWithPlaceNotFound(t *testing.T) {
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(new(mocks.LocationRepositoryMock), geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	geocoder.On("Reverse", ctx, 48.8606111, 2.3376).Return(nil, nil)
+
+	place, err := usecase.ReverseGeocode(ctx, 48.8606111, 2.3376)
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewPlace("48.8606111, 2.3376", 48.8606111, 2.3376), place)
+}
+
+func TestReverseGeocodeWithSuccess(t *testing.T) {
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(new(mocks.LocationRepositoryMock), geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	expected := models.NewPlace("1, Rue de la Poste, Paris, France", 48.8606, 2.3375)
+	geocoder.On("Reverse", ctx, 48.8606111, 2.3376).Return(expected, nil)
+
+	place, err := usecase.ReverseGeocode(ctx, 48.8606111, 2.3376)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, place)
 }
 
 func TestGetLocationsWithRepositoryError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	repo.On("GetLocations", ctx).Return(nil, errors.New("failed"))
 
 	locations, err := usecase.GetLocations(ctx)
@@ -323,9 +842,9 @@ func TestGetLocationsWithRepositoryError(t *testing.T) {
 
 func TestGetLocationsWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	locations := models.Locations{
 		models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID()),
 	}
@@ -338,9 +857,9 @@ func TestGetLocationsWithSuccess(t *testing.T) {
 
 func TestFindLocationByIDWithRepositoryError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	id := models.NewID()
 	repo.On("FindLocationByID", ctx, id).Return(nil, errors.New("failed"))
 
@@ -351,9 +870,9 @@ func TestFindLocationByIDWithRepositoryError(t *testing.T) {
 
 func TestFindLocationByIDWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
 	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
 
@@ -364,73 +883,239 @@ func TestFindLocationByIDWithSuccess(t *testing.T) {
 
 func TestFindLocationsByCategoryWithRepositoryFindCategoryByIDError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
-	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, errors.New("failed"))
-	repo.On("FindLocationsByCategory", ctx, cat).Return(nil, nil)
+	repo.On("FindVisibleCategoryByID", ctx, cat.ID).Return(nil, errors.New("failed"))
+	repo.On("FindLocationsByCategory", ctx, cat, false).Return(nil, nil)
 
-	locations, err := usecase.FindLocationsByCategory(ctx, cat.ID)
+	locations, err := usecase.FindLocationsByCategory(ctx, cat.ID, false)
 	assert.Error(t, err)
 	assert.Nil(t, locations)
 }
 
 func TestFindLocationsByCategoryWithCategoryNotFound(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
-	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, nil)
-	repo.On("FindLocationsByCategory", ctx, cat).Return(nil, nil)
+	repo.On("FindVisibleCategoryByID", ctx, cat.ID).Return(nil, nil)
+	repo.On("FindLocationsByCategory", ctx, cat, false).Return(nil, nil)
 
-	locations, err := usecase.FindLocationsByCategory(ctx, cat.ID)
+	locations, err := usecase.FindLocationsByCategory(ctx, cat.ID, false)
 	assert.Equal(t, err, ErrCategoryNotFound)
 	assert.Nil(t, locations)
 }
 
 func TestFindLocationsByCategoryWithRepositoryFindLocationsByCategoryError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
-	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
-	repo.On("FindLocationsByCategory", ctx, cat).Return(nil, errors.New("failed"))
+	repo.On("FindVisibleCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindLocationsByCategory", ctx, cat, false).Return(nil, errors.New("failed"))
 
-	locations, err := usecase.FindLocationsByCategory(ctx, cat.ID)
+	locations, err := usecase.FindLocationsByCategory(ctx, cat.ID, false)
 	assert.Error(t, err)
 	assert.Nil(t, locations)
 }
 
 func TestFindLocationsByCategoryWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	locs := models.Locations{
 		models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID()),
 	}
-	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
-	repo.On("FindLocationsByCategory", ctx, cat).Return(&locs, nil)
+	repo.On("FindVisibleCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindLocationsByCategory", ctx, cat, false).Return(&locs, nil)
+
+	returnedLocs, err := usecase.FindLocationsByCategory(ctx, cat.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, locs, *returnedLocs)
+}
 
-	returnedLocs, err := usecase.FindLocationsByCategory(ctx, cat.ID)
+func TestFindLocationsByCategorySharedWithUser(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	owner := models.NewID()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(owner)
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, owner)
+	loc.Role = models.ShareRoleViewer
+	locs := models.Locations{loc}
+	repo.On("FindVisibleCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindLocationsByCategory", ctx, cat, false).Return(&locs, nil)
+
+	returnedLocs, err := usecase.FindLocationsByCategory(ctx, cat.ID, false)
 	assert.NoError(t, err)
 	assert.Equal(t, locs, *returnedLocs)
+	repo.AssertNotCalled(t, "FindCategoryByID", ctx, cat.ID)
+}
+
+func TestGetExpandedLocationsWithCategoryNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	catID := models.NewID()
+	repo.On("FindVisibleCategoryByID", ctx, catID).Return(nil, nil)
+
+	locations, err := usecase.GetExpandedLocations(ctx, catID)
+	assert.Equal(t, ErrCategoryNotFound, err)
+	assert.Nil(t, locations)
+}
+
+func TestGetExpandedLocationsWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.ExpandedCategory = cat
+	locs := models.Locations{loc}
+	repo.On("FindExpandedLocations", ctx, (*models.Category)(nil)).Return(&locs, nil)
+
+	returnedLocs, err := usecase.GetExpandedLocations(ctx, models.NilID)
+	assert.NoError(t, err)
+	assert.Equal(t, locs, *returnedLocs)
+}
+
+func TestFindLocationsNearWithCategoryNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	catID := models.NewID()
+	repo.On("FindVisibleCategoryByID", ctx, catID).Return(nil, nil)
+
+	locations, err := usecase.FindLocationsNear(ctx, catID, models.NewPoint(48.8606111, 2.3376), 5000)
+	assert.Equal(t, ErrCategoryNotFound, err)
+	assert.Nil(t, locations)
+}
+
+func TestFindLocationsNearWithRepositoryError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	center := models.NewPoint(48.8606111, 2.3376)
+	repo.On("FindLocationsNear", ctx, (*models.Category)(nil), center, 5000.0).Return(nil, errors.New("failed"))
+
+	locations, err := usecase.FindLocationsNear(ctx, models.NilID, center, 5000)
+	assert.Error(t, err)
+	assert.Nil(t, locations)
+}
+
+func TestFindLocationsNearWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	center := models.NewPoint(48.8606111, 2.3376)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	locs := models.Locations{loc}
+	repo.On("FindVisibleCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("FindLocationsNear", ctx, cat, center, 5000.0).Return(&locs, nil)
+
+	returnedLocs, err := usecase.FindLocationsNear(ctx, cat.ID, center, 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, locs, *returnedLocs)
+}
+
+func TestFindExpandedLocationByIDWithLocationNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	id := models.NewID()
+	repo.On("FindExpandedLocationByID", ctx, id).Return(nil, nil)
+
+	location, err := usecase.FindExpandedLocationByID(ctx, id)
+	assert.Equal(t, ErrLocationNotFound, err)
+	assert.Nil(t, location)
+}
+
+func TestFindExpandedLocationByIDWithRepositoryError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	id := models.NewID()
+	repo.On("FindExpandedLocationByID", ctx, id).Return(nil, errors.New("failed"))
+
+	location, err := usecase.FindExpandedLocationByID(ctx, id)
+	assert.Error(t, err)
+	assert.Nil(t, location)
+}
+
+func TestStreamLocationsWithCategoryNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	catID := models.NewID()
+	repo.On("FindVisibleCategoryByID", ctx, catID).Return(nil, nil)
+
+	err := usecase.StreamLocations(ctx, catID, func(*models.Location) error { return nil })
+	assert.Equal(t, ErrCategoryNotFound, err)
+	repo.AssertNotCalled(t, "StreamLocations", ctx, mock.Anything, mock.Anything)
+}
+
+func TestStreamLocationsWithRepositoryStreamLocationsError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	repo.On("StreamLocations", ctx, (*models.Category)(nil), mock.Anything).Return(errors.New("failed"))
+
+	err := usecase.StreamLocations(ctx, models.NilID, func(*models.Location) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestStreamLocationsWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	repo.On("FindVisibleCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("StreamLocations", ctx, cat, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*models.Location) error)
+		_ = fn(loc)
+	})
+
+	var streamed []*models.Location
+	err := usecase.StreamLocations(ctx, cat.ID, func(l *models.Location) error {
+		streamed = append(streamed, l)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Location{loc}, streamed)
 }
 
 func TestUpdateLocationWithRepositoryFindLocationByIDError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
 	repo.On("FindLocationByID", ctx, loc.ID).Return(nil, errors.New("failed"))
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
 	repo.On("UpdateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.UpdateLocation(ctx, loc)
 	assert.Error(t, err)
@@ -438,14 +1123,16 @@ func TestUpdateLocationWithRepositoryFindLocationByIDError(t *testing.T) {
 
 func TestUpdateLocationWithLocationNotFoundError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
 	repo.On("FindLocationByID", ctx, loc.ID).Return(nil, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
 	repo.On("UpdateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.UpdateLocation(ctx, loc)
 	assert.Equal(t, err, ErrLocationNotFound)
@@ -453,14 +1140,17 @@ func TestUpdateLocationWithLocationNotFoundError(t *testing.T) {
 
 func TestUpdateLocationWithRepositoryUpdateLocationError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.Role = models.ShareRoleOwner
 	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
 	repo.On("UpdateLocation", ctx, loc).Return(errors.New("failed"))
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.UpdateLocation(ctx, loc)
 	assert.Error(t, err)
@@ -468,14 +1158,17 @@ func TestUpdateLocationWithRepositoryUpdateLocationError(t *testing.T) {
 
 func TestUpdateLocationWithRepositoryFindCategoryByIDError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.Role = models.ShareRoleOwner
 	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(nil, errors.New("failed"))
 	repo.On("UpdateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
 
 	err := usecase.UpdateLocation(ctx, loc)
 	assert.Error(t, err)
@@ -483,24 +1176,119 @@ func TestUpdateLocationWithRepositoryFindCategoryByIDError(t *testing.T) {
 
 func TestUpdateLocationWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	cat := models.NewCategory(models.NewID(), "Test Category")
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.Role = models.ShareRoleOwner
 	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
 	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
 	repo.On("UpdateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.NoError(t, err)
+}
+
+func TestUpdateLocationKeepsCoordinatesOfUnchangedAddress(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	existing := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	existing.SetCoordinates(48.8606111, 2.3376)
+	loc := models.NewLocation(existing.ID, "New Name", "", cat.ID, existing.User)
+	existing.Role = models.ShareRoleOwner
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("UpdateLocation", ctx, loc).Return(nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, existing.Latitude, loc.Latitude)
+	assert.Equal(t, existing.Longitude, loc.Longitude)
+	geocoder.AssertNotCalled(t, "Geocode", ctx, existing.Address)
+}
+
+func TestUpdateLocationKeepsComponentsOfUnchangedAddress(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	existing := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	existing.Components = models.AddressComponents{Street: "Rue de la Poste", HouseNumber: "1", Locality: "Paris", CountryCode: "FR"}
+	existing.SetCoordinates(48.8606111, 2.3376)
+	loc := models.NewLocation(existing.ID, "New Name", "", cat.ID, existing.User)
+	existing.Role = models.ShareRoleOwner
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("UpdateLocation", ctx, loc).Return(nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, existing.Components, loc.Components)
+}
+
+func TestUpdateLocationWithChangedAddress(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	existing := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	existing.SetCoordinates(48.8606111, 2.3376)
+	loc := models.NewLocation(existing.ID, "", "2 rue de la Poste, 75001 Paris", cat.ID, existing.User)
+	place := models.NewPlace("2 Rue de la Poste, 75001 Paris, France", 48.8607, 2.3377)
+	existing.Role = models.ShareRoleOwner
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("UpdateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, "2 rue de la Poste, 75001 Paris").Return(place, nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, place.Address, loc.Address)
+	if assert.NotNil(t, loc.Latitude) {
+		assert.Equal(t, place.Latitude, *loc.Latitude)
+	}
+	assert.Equal(t, "2", loc.Components.HouseNumber)
+}
+
+func TestUpdateLocationWithChangedAddressComponents(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	existing := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	existing.SetCoordinates(48.8606111, 2.3376)
+	loc := models.NewLocation(existing.ID, "", "", cat.ID, existing.User)
+	loc.Components = models.AddressComponents{Street: "Rue de la Poste", HouseNumber: "2", PostalCode: "75001", Locality: "Paris", CountryCode: "FR"}
+	existing.Role = models.ShareRoleOwner
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("UpdateLocation", ctx, loc).Return(nil)
+	geocoder.On("Geocode", ctx, "2 Rue de la Poste, 75001 Paris, France").Return(nil, nil)
 
 	err := usecase.UpdateLocation(ctx, loc)
 	assert.NoError(t, err)
+	assert.Equal(t, "2 Rue de la Poste, 75001 Paris, France", loc.Address)
+	geocoder.AssertCalled(t, "Geocode", ctx, "2 Rue de la Poste, 75001 Paris, France")
 }
 
 func TestDeleteLocationWithRepositoryFindLocationByIDError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
 	repo.On("FindLocationByID", ctx, loc.ID).Return(nil, errors.New("failed"))
 	repo.On("DeleteLocation", ctx, loc.ID).Return(nil)
@@ -511,9 +1299,9 @@ func TestDeleteLocationWithRepositoryFindLocationByIDError(t *testing.T) {
 
 func TestDeleteLocationWithLocationNotFoundError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
 	repo.On("FindLocationByID", ctx, loc.ID).Return(nil, nil)
 	repo.On("DeleteLocation", ctx, loc.ID).Return(nil)
@@ -524,10 +1312,11 @@ func TestDeleteLocationWithLocationNotFoundError(t *testing.T) {
 
 func TestDeleteLocationWithRepositoryDeleteLocationError(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Role = models.ShareRoleOwner
 	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
 	repo.On("DeleteLocation", ctx, loc.ID).Return(errors.New("failed"))
 
@@ -537,10 +1326,11 @@ func TestDeleteLocationWithRepositoryDeleteLocationError(t *testing.T) {
 
 func TestDeleteLocationWithSuccess(t *testing.T) {
 	repo := new(mocks.LocationRepositoryMock)
-	usecase := NewLocationUsecase(repo)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
 
-	ctx := context.Background()
+	ctx := newTestContext()
 	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Role = models.ShareRoleOwner
 	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
 	repo.On("DeleteLocation", ctx, loc.ID).Return(nil)
 
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/edebernis/social-life-manager/services/location/internal/models"
 	"github.com/stretchr/testify/mock"
@@ -12,6 +13,11 @@ type LocationRepositoryMock struct {
 	mock.Mock
 }
 
+// WithinTx runs fn right away, as mocked repository has no transactions
+func (r *LocationRepositoryMock) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
 // CreateCategory creates a new category in repository
 func (r *LocationRepositoryMock) CreateCategory(ctx context.Context, cat *models.Category) error {
 	args := r.Called(ctx, cat)
@@ -39,6 +45,16 @@ func (r *LocationRepositoryMock) FindCategoryByID(ctx context.Context, id models
 	return cat.(*models.Category), args.Error(1)
 }
 
+// FindVisibleCategoryByID returns category matching specified ID or nil
+func (r *LocationRepositoryMock) FindVisibleCategoryByID(ctx context.Context, id models.ID) (*models.Category, error) {
+	args := r.Called(ctx, id)
+	cat := args.Get(0)
+	if cat == nil {
+		return nil, args.Error(1)
+	}
+	return cat.(*models.Category), args.Error(1)
+}
+
 // FindCategoryByName returns category matching specified name or nil
 func (r *LocationRepositoryMock) FindCategoryByName(ctx context.Context, name string) (*models.Category, error) {
 	args := r.Called(ctx, name)
@@ -56,8 +72,32 @@ func (r *LocationRepositoryMock) UpdateCategory(ctx context.Context, cat *models
 }
 
 // DeleteCategory deletes category in repository
-func (r *LocationRepositoryMock) DeleteCategory(ctx context.Context, id models.ID) error {
+func (r *LocationRepositoryMock) DeleteCategory(ctx context.Context, id models.ID, deletion models.CategoryDeletion) error {
+	args := r.Called(ctx, id, deletion)
+	return args.Error(0)
+}
+
+// UpdateDefaultCategory updates default category in repository
+func (r *LocationRepositoryMock) UpdateDefaultCategory(ctx context.Context, cat *models.Category) error {
+	args := r.Called(ctx, cat)
+	return args.Error(0)
+}
+
+// DeleteDefaultCategory deletes default category in repository
+func (r *LocationRepositoryMock) DeleteDefaultCategory(ctx context.Context, id models.ID, reassignTo *models.ID) error {
+	args := r.Called(ctx, id, reassignTo)
+	return args.Error(0)
+}
+
+// CountLocationsByCategory returns how many user locations belong to category in repository
+func (r *LocationRepositoryMock) CountLocationsByCategory(ctx context.Context, id models.ID) (int, error) {
 	args := r.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+// MergeCategory merges category into target category in repository
+func (r *LocationRepositoryMock) MergeCategory(ctx context.Context, id models.ID, target models.ID) error {
+	args := r.Called(ctx, id, target)
 	return args.Error(0)
 }
 
@@ -99,7 +139,17 @@ func (r *LocationRepositoryMock) FindLocationByName(ctx context.Context, name st
 }
 
 // FindLocationsByCategory returns all user locations filtered by specified category
-func (r *LocationRepositoryMock) FindLocationsByCategory(ctx context.Context, cat *models.Category) (*models.Locations, error) {
+func (r *LocationRepositoryMock) FindLocationsByCategory(ctx context.Context, cat *models.Category, descendants bool) (*models.Locations, error) {
+	args := r.Called(ctx, cat, descendants)
+	locs := args.Get(0)
+	if locs == nil {
+		return nil, args.Error(1)
+	}
+	return locs.(*models.Locations), args.Error(1)
+}
+
+// FindExpandedLocations returns user locations with their category embedded
+func (r *LocationRepositoryMock) FindExpandedLocations(ctx context.Context, cat *models.Category) (*models.Locations, error) {
 	args := r.Called(ctx, cat)
 	locs := args.Get(0)
 	if locs == nil {
@@ -108,6 +158,42 @@ func (r *LocationRepositoryMock) FindLocationsByCategory(ctx context.Context, ca
 	return locs.(*models.Locations), args.Error(1)
 }
 
+// FindExpandedLocationByID returns the user location matching specified ID with its category embedded
+func (r *LocationRepositoryMock) FindExpandedLocationByID(ctx context.Context, id models.ID) (*models.Location, error) {
+	args := r.Called(ctx, id)
+	loc := args.Get(0)
+	if loc == nil {
+		return nil, args.Error(1)
+	}
+	return loc.(*models.Location), args.Error(1)
+}
+
+// StreamLocations iterates over user locations, optionally filtered by category
+func (r *LocationRepositoryMock) StreamLocations(ctx context.Context, cat *models.Category, fn func(*models.Location) error) error {
+	args := r.Called(ctx, cat, fn)
+	return args.Error(0)
+}
+
+// FindLocationsNear returns user locations near center, optionally filtered by category
+func (r *LocationRepositoryMock) FindLocationsNear(ctx context.Context, cat *models.Category, center models.Point, radius float64) (*models.Locations, error) {
+	args := r.Called(ctx, cat, center, radius)
+	locs := args.Get(0)
+	if locs == nil {
+		return nil, args.Error(1)
+	}
+	return locs.(*models.Locations), args.Error(1)
+}
+
+// FindLocationsInBoundingBox returns user locations inside bbox, optionally filtered by category
+func (r *LocationRepositoryMock) FindLocationsInBoundingBox(ctx context.Context, cat *models.Category, bbox models.BoundingBox) (*models.Locations, error) {
+	args := r.Called(ctx, cat, bbox)
+	locs := args.Get(0)
+	if locs == nil {
+		return nil, args.Error(1)
+	}
+	return locs.(*models.Locations), args.Error(1)
+}
+
 // UpdateLocation updates specified location in repository
 func (r *LocationRepositoryMock) UpdateLocation(ctx context.Context, loc *models.Location) error {
 	args := r.Called(ctx, loc)
@@ -119,3 +205,265 @@ func (r *LocationRepositoryMock) DeleteLocation(ctx context.Context, id models.I
 	args := r.Called(ctx, id)
 	return args.Error(0)
 }
+
+// MoveLocations moves user locations to category in repository
+func (r *LocationRepositoryMock) MoveLocations(ctx context.Context, ids []models.ID, cat *models.Category) ([]models.ID, error) {
+	args := r.Called(ctx, ids, cat)
+	moved := args.Get(0)
+	if moved == nil {
+		return nil, args.Error(1)
+	}
+	return moved.([]models.ID), args.Error(1)
+}
+
+// CreateTag creates a new tag in repository
+func (r *LocationRepositoryMock) CreateTag(ctx context.Context, tag *models.Tag) error {
+	args := r.Called(ctx, tag)
+	return args.Error(0)
+}
+
+// GetTags fetches all user tags in repository
+func (r *LocationRepositoryMock) GetTags(ctx context.Context) (*models.Tags, error) {
+	args := r.Called(ctx)
+	tags := args.Get(0)
+	if tags == nil {
+		return nil, args.Error(1)
+	}
+	return tags.(*models.Tags), args.Error(1)
+}
+
+// FindTagByID returns tag matching specified ID or nil
+func (r *LocationRepositoryMock) FindTagByID(ctx context.Context, id models.ID) (*models.Tag, error) {
+	args := r.Called(ctx, id)
+	tag := args.Get(0)
+	if tag == nil {
+		return nil, args.Error(1)
+	}
+	return tag.(*models.Tag), args.Error(1)
+}
+
+// FindTagByName returns tag matching specified name or nil
+func (r *LocationRepositoryMock) FindTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	args := r.Called(ctx, name)
+	tag := args.Get(0)
+	if tag == nil {
+		return nil, args.Error(1)
+	}
+	return tag.(*models.Tag), args.Error(1)
+}
+
+// UpdateTag updates specified tag in repository
+func (r *LocationRepositoryMock) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	args := r.Called(ctx, tag)
+	return args.Error(0)
+}
+
+// DeleteTag deletes tag in repository
+func (r *LocationRepositoryMock) DeleteTag(ctx context.Context, id models.ID) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+// SetLocationTags replaces the tags of specified location in repository
+func (r *LocationRepositoryMock) SetLocationTags(ctx context.Context, id models.ID, names []string) error {
+	args := r.Called(ctx, id, names)
+	return args.Error(0)
+}
+
+// FindLocationsByTags returns user locations matching tags, optionally filtered by category
+func (r *LocationRepositoryMock) FindLocationsByTags(ctx context.Context, cat *models.Category, names []string, matchAll bool) (*models.Locations, error) {
+	args := r.Called(ctx, cat, names, matchAll)
+	locs := args.Get(0)
+	if locs == nil {
+		return nil, args.Error(1)
+	}
+	return locs.(*models.Locations), args.Error(1)
+}
+
+// GeocoderMock mocks geocoder
+type GeocoderMock struct {
+	mock.Mock
+}
+
+// Geocode resolves specified address into a place
+func (g *GeocoderMock) Geocode(ctx context.Context, address string) (*models.Place, error) {
+	args := g.Called(ctx, address)
+	place := args.Get(0)
+	if place == nil {
+		return nil, args.Error(1)
+	}
+	return place.(*models.Place), args.Error(1)
+}
+
+// Reverse resolves specified coordinates into a place
+func (g *GeocoderMock) Reverse(ctx context.Context, latitude, longitude float64) (*models.Place, error) {
+	args := g.Called(ctx, latitude, longitude)
+	place := args.Get(0)
+	if place == nil {
+		return nil, args.Error(1)
+	}
+	return place.(*models.Place), args.Error(1)
+}
+
+// GetLocationShares returns the shares of a location in repository
+func (r *LocationRepositoryMock) GetLocationShares(ctx context.Context, id models.ID) (*models.Shares, error) {
+	args := r.Called(ctx, id)
+	shares := args.Get(0)
+	if shares == nil {
+		return nil, args.Error(1)
+	}
+	return shares.(*models.Shares), args.Error(1)
+}
+
+// SetLocationShare grants a role on a location in repository
+func (r *LocationRepositoryMock) SetLocationShare(ctx context.Context, id models.ID, share *models.Share) error {
+	args := r.Called(ctx, id, share)
+	return args.Error(0)
+}
+
+// DeleteLocationShare revokes the role granted on a location in repository
+func (r *LocationRepositoryMock) DeleteLocationShare(ctx context.Context, id models.ID, user models.ID) error {
+	args := r.Called(ctx, id, user)
+	return args.Error(0)
+}
+
+// GetCategoryShares returns the shares of a category in repository
+func (r *LocationRepositoryMock) GetCategoryShares(ctx context.Context, id models.ID) (*models.Shares, error) {
+	args := r.Called(ctx, id)
+	shares := args.Get(0)
+	if shares == nil {
+		return nil, args.Error(1)
+	}
+	return shares.(*models.Shares), args.Error(1)
+}
+
+// SetCategoryShare grants a role on a category in repository
+func (r *LocationRepositoryMock) SetCategoryShare(ctx context.Context, id models.ID, share *models.Share) error {
+	args := r.Called(ctx, id, share)
+	return args.Error(0)
+}
+
+// DeleteCategoryShare revokes the role granted on a category in repository
+func (r *LocationRepositoryMock) DeleteCategoryShare(ctx context.Context, id models.ID, user models.ID) error {
+	args := r.Called(ctx, id, user)
+	return args.Error(0)
+}
+
+// CreateGroup creates a new group in repository
+func (r *LocationRepositoryMock) CreateGroup(ctx context.Context, group *models.Group) error {
+	args := r.Called(ctx, group)
+	return args.Error(0)
+}
+
+// GetGroups returns the groups of the user in repository
+func (r *LocationRepositoryMock) GetGroups(ctx context.Context) (*models.Groups, error) {
+	args := r.Called(ctx)
+	groups := args.Get(0)
+	if groups == nil {
+		return nil, args.Error(1)
+	}
+	return groups.(*models.Groups), args.Error(1)
+}
+
+// FindGroupByID returns group matching specified ID or nil
+func (r *LocationRepositoryMock) FindGroupByID(ctx context.Context, id models.ID) (*models.Group, error) {
+	args := r.Called(ctx, id)
+	group := args.Get(0)
+	if group == nil {
+		return nil, args.Error(1)
+	}
+	return group.(*models.Group), args.Error(1)
+}
+
+// UpdateGroup updates group in repository
+func (r *LocationRepositoryMock) UpdateGroup(ctx context.Context, group *models.Group) error {
+	args := r.Called(ctx, group)
+	return args.Error(0)
+}
+
+// DeleteGroup deletes group in repository
+func (r *LocationRepositoryMock) DeleteGroup(ctx context.Context, id models.ID) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+// GetGroupMembers returns the members of a group in repository
+func (r *LocationRepositoryMock) GetGroupMembers(ctx context.Context, id models.ID) (*models.GroupMembers, error) {
+	args := r.Called(ctx, id)
+	members := args.Get(0)
+	if members == nil {
+		return nil, args.Error(1)
+	}
+	return members.(*models.GroupMembers), args.Error(1)
+}
+
+// LockGroupMembers returns the members of a group in repository, locked until the end of the transaction
+func (r *LocationRepositoryMock) LockGroupMembers(ctx context.Context, id models.ID) (*models.GroupMembers, error) {
+	args := r.Called(ctx, id)
+	members := args.Get(0)
+	if members == nil {
+		return nil, args.Error(1)
+	}
+	return members.(*models.GroupMembers), args.Error(1)
+}
+
+// SetGroupMember adds or updates a member of a group in repository
+func (r *LocationRepositoryMock) SetGroupMember(ctx context.Context, id models.ID, member *models.GroupMember) error {
+	args := r.Called(ctx, id, member)
+	return args.Error(0)
+}
+
+// DeleteGroupMember removes a member of a group in repository
+func (r *LocationRepositoryMock) DeleteGroupMember(ctx context.Context, id models.ID, user models.ID) error {
+	args := r.Called(ctx, id, user)
+	return args.Error(0)
+}
+
+// CreateShareLink creates a new share link in repository
+func (r *LocationRepositoryMock) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	args := r.Called(ctx, link)
+	return args.Error(0)
+}
+
+// GetShareLinks returns the share links of a location in repository
+func (r *LocationRepositoryMock) GetShareLinks(ctx context.Context, id models.ID) (*models.ShareLinks, error) {
+	args := r.Called(ctx, id)
+	links := args.Get(0)
+	if links == nil {
+		return nil, args.Error(1)
+	}
+	return links.(*models.ShareLinks), args.Error(1)
+}
+
+// DeleteShareLink deletes a share link in repository
+func (r *LocationRepositoryMock) DeleteShareLink(ctx context.Context, id models.ID) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+// FindPublicLocationByShareLink returns the location shared by a link in repository
+func (r *LocationRepositoryMock) FindPublicLocationByShareLink(ctx context.Context, id models.ID) (*models.PublicLocation, error) {
+	args := r.Called(ctx, id)
+	loc := args.Get(0)
+	if loc == nil {
+		return nil, args.Error(1)
+	}
+	return loc.(*models.PublicLocation), args.Error(1)
+}
+
+// ShareLinkSignerMock mocks share link signer
+type ShareLinkSignerMock struct {
+	mock.Mock
+}
+
+// Sign returns the token of a share link
+func (s *ShareLinkSignerMock) Sign(id models.ID, expiresAt time.Time) string {
+	args := s.Called(id, expiresAt)
+	return args.String(0)
+}
+
+// Verify returns the ID of the share link a token was signed for
+func (s *ShareLinkSignerMock) Verify(token string) (models.ID, error) {
+	args := s.Called(token)
+	return args.Get(0).(models.ID), args.Error(1)
+}
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/pluscodes"
+)
+
+// ResolvePlusCode returns the area covered by a plus code. Short codes are recovered relative to
+// the reference location unless referenceID is NilID, or else to the locality following the code.
+func (u *LocationUsecase) ResolvePlusCode(ctx context.Context, code string, referenceID models.ID) (*pluscodes.CodeArea, error) {
+	if err := u.authorizer.Authorize(ctx, "ResolvePlusCode"); err != nil {
+		return nil, err
+	}
+
+	code, locality := pluscodes.Split(code)
+	if err := pluscodes.CheckValid(code); err != nil {
+		return nil, ErrInvalidPlusCode
+	}
+
+	if pluscodes.IsShort(code) {
+		reference, err := u.findPlusCodeReference(ctx, referenceID, locality)
+		if err != nil {
+			return nil, err
+		}
+
+		code, err = pluscodes.RecoverNearest(code, reference.Latitude, reference.Longitude)
+		if err != nil {
+			return nil, ErrInvalidPlusCode
+		}
+	}
+
+	area, err := pluscodes.Decode(code)
+	if err != nil {
+		return nil, ErrInvalidPlusCode
+	}
+
+	return &area, nil
+}
+
+// findPlusCodeReference returns the point short plus codes are recovered from: coordinates of
+// the reference location if any, or else coordinates of the geocoded locality.
+func (u *LocationUsecase) findPlusCodeReference(ctx context.Context, referenceID models.ID, locality string) (*models.Point, error) {
+	if referenceID != models.NilID {
+		loc, err := u.repo.FindLocationByID(ctx, referenceID)
+		if err != nil {
+			return nil, fmt.Errorf("findPlusCodeReference: failed to find location by id, %s. %w", referenceID, err)
+		}
+		if loc == nil {
+			return nil, ErrLocationNotFound
+		}
+		if loc.Latitude == nil || loc.Longitude == nil {
+			return nil, ErrPlusCodeReferenceRequired
+		}
+
+		point := models.NewPoint(*loc.Latitude, *loc.Longitude)
+		return &point, nil
+	}
+
+	if locality == "" {
+		return nil, ErrPlusCodeReferenceRequired
+	}
+
+	place, err := u.geocoder.Geocode(ctx, locality)
+	if err != nil {
+		return nil, fmt.Errorf("findPlusCodeReference: failed to geocode locality %s. %w", locality, err)
+	}
+	if place == nil {
+		return nil, ErrPlusCodeReferenceRequired
+	}
+
+	point := models.NewPoint(place.Latitude, place.Longitude)
+	return &point, nil
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvePlusCodeWithInvalidCode(t *testing.T) {
+	usecase := NewLocationUsecase(new(mocks.LocationRepositoryMock), new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	area, err := usecase.ResolvePlusCode(newTestContext(), "8FW4V86Q+6", models.NilID)
+	assert.Equal(t, ErrInvalidPlusCode, err)
+	assert.Nil(t, area)
+}
+
+func TestResolvePlusCodeWithFullCode(t *testing.T) {
+	usecase := NewLocationUsecase(new(mocks.LocationRepositoryMock), new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	area, err := usecase.ResolvePlusCode(newTestContext(), "8FW4V86Q+62", models.NilID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, area) {
+		assert.InDelta(t, 48.8606111, area.LatitudeLo, 0.000125)
+		assert.InDelta(t, 2.3376, area.LongitudeLo, 0.000125)
+		assert.Equal(t, 10, area.Length)
+	}
+}
+
+func TestResolvePlusCodeWithShortCodeWithoutReference(t *testing.T) {
+	usecase := NewLocationUsecase(new(mocks.LocationRepositoryMock), new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	area, err := usecase.ResolvePlusCode(newTestContext(), "V86Q+62", models.NilID)
+	assert.Equal(t, ErrPlusCodeReferenceRequired, err)
+	assert.Nil(t, area)
+}
+
+func TestResolvePlusCodeWithUnknownReference(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	id := models.NewID()
+	repo.On("FindLocationByID", ctx, id).Return(nil, nil)
+
+	area, err := usecase.ResolvePlusCode(ctx, "V86Q+62", id)
+	assert.Equal(t, ErrLocationNotFound, err)
+	assert.Nil(t, area)
+}
+
+func TestResolvePlusCodeWithReferenceWithoutCoordinates(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	ref := models.NewLocation(models.NewID(), "Home", "1 Rue de la Poste", models.NewID(), models.NewID())
+	repo.On("FindLocationByID", ctx, ref.ID).Return(ref, nil)
+
+	area, err := usecase.ResolvePlusCode(ctx, "V86Q+62", ref.ID)
+	assert.Equal(t, ErrPlusCodeReferenceRequired, err)
+	assert.Nil(t, area)
+}
+
+func TestResolvePlusCodeWithReferenceLocation(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	ref := newTestLocationAt(48.85, 2.35)
+	repo.On("FindLocationByID", ctx, ref.ID).Return(ref, nil)
+
+	area, err := usecase.ResolvePlusCode(ctx, "v86q+62", ref.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, area) {
+		assert.InDelta(t, 48.8606111, area.LatitudeLo, 0.000125)
+		assert.InDelta(t, 2.3376, area.LongitudeLo, 0.000125)
+	}
+}
+
+func TestResolvePlusCodeWithLocality(t *testing.T) {
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(new(mocks.LocationRepositoryMock), geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	geocoder.On("Geocode", ctx, "Paris").Return(models.NewPlace("Paris, France", 48.8566, 2.3522), nil)
+
+	area, err := usecase.ResolvePlusCode(ctx, "V86Q+62 Paris", models.NilID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, area) {
+		assert.InDelta(t, 48.8606111, area.LatitudeLo, 0.000125)
+		assert.InDelta(t, 2.3376, area.LongitudeLo, 0.000125)
+	}
+}
+
+func TestResolvePlusCodeWithUnknownLocality(t *testing.T) {
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(new(mocks.LocationRepositoryMock), geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	geocoder.On("Geocode", ctx, "Nowhere").Return(nil, nil)
+
+	area, err := usecase.ResolvePlusCode(ctx, "V86Q+62 Nowhere", models.NilID)
+	assert.Equal(t, ErrPlusCodeReferenceRequired, err)
+	assert.Nil(t, area)
+}
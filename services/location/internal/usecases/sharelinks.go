@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// CreateShareLink stores a new share link to a location, signing its token.
+// Only users managing the location, its owner or users it is shared with as owner, may create links.
+func (u *LocationUsecase) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	if err := u.authorizer.Authorize(ctx, "CreateShareLink"); err != nil {
+		return err
+	}
+
+	if err := validateShareLink(link, time.Now()); err != nil {
+		return err
+	}
+
+	if _, err := u.findManagedLocation(ctx, link.Location); err != nil {
+		return err
+	}
+
+	if err := u.repo.CreateShareLink(ctx, link); err != nil {
+		return fmt.Errorf("CreateShareLink: failed to create share link to location %s. %w", link.Location, err)
+	}
+	link.Token = u.signer.Sign(link.ID, link.ExpiresAt)
+
+	return nil
+}
+
+// GetShareLinks returns the share links of a location along with their token, expired ones included.
+// Only users managing the location may list them.
+func (u *LocationUsecase) GetShareLinks(ctx context.Context, id models.ID) (*models.ShareLinks, error) {
+	if err := u.authorizer.Authorize(ctx, "GetShareLinks"); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findManagedLocation(ctx, id); err != nil {
+		return nil, err
+	}
+
+	links, err := u.repo.GetShareLinks(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetShareLinks: failed to get share links of location %s. %w", id, err)
+	}
+	for _, link := range *links {
+		link.Token = u.signer.Sign(link.ID, link.ExpiresAt)
+	}
+
+	return links, nil
+}
+
+// RevokeShareLink deletes a share link of a location, so that its token cannot be used anymore
+func (u *LocationUsecase) RevokeShareLink(ctx context.Context, id models.ID, link models.ID) error {
+	if err := u.authorizer.Authorize(ctx, "RevokeShareLink"); err != nil {
+		return err
+	}
+
+	if _, err := u.findManagedLocation(ctx, id); err != nil {
+		return err
+	}
+
+	links, err := u.repo.GetShareLinks(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RevokeShareLink: failed to get share links of location %s. %w", id, err)
+	}
+	if !hasShareLink(*links, link) {
+		return ErrShareLinkNotFound
+	}
+
+	if err := u.repo.DeleteShareLink(ctx, link); err != nil {
+		return fmt.Errorf("RevokeShareLink: failed to delete share link %s. %w", link, err)
+	}
+
+	return nil
+}
+
+// GetPublicLocation returns the restricted view of the location shared by the link token was signed for,
+// counting the access. It does not need any user, as the token gives access to the location by itself.
+// Invalid, expired and revoked tokens are all reported as ErrShareLinkNotFound.
+func (u *LocationUsecase) GetPublicLocation(ctx context.Context, token string) (*models.PublicLocation, error) {
+	if err := u.authorizer.Authorize(ctx, "GetPublicLocation"); err != nil {
+		return nil, err
+	}
+
+	id, err := u.signer.Verify(token)
+	if err != nil {
+		logger.Debugf("GetPublicLocation: rejected share link token. %v", err)
+		return nil, ErrShareLinkNotFound
+	}
+
+	loc, err := u.repo.FindPublicLocationByShareLink(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetPublicLocation: failed to find location by share link %s. %w", id, err)
+	}
+	if loc == nil {
+		return nil, ErrShareLinkNotFound
+	}
+
+	return loc, nil
+}
+
+func hasShareLink(links models.ShareLinks, id models.ID) bool {
+	for _, link := range links {
+		if link.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateShareLinkWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	signer := new(mocks.ShareLinkSignerMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), signer)
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	link := models.NewShareLink(models.NewID(), loc.ID, time.Now().Add(time.Hour))
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("CreateShareLink", ctx, link).Return(nil)
+	signer.On("Sign", link.ID, link.ExpiresAt).Return("token")
+
+	err := usecase.CreateShareLink(ctx, link)
+	assert.NoError(t, err)
+	assert.Equal(t, "token", link.Token)
+}
+
+func TestCreateShareLinkWithInvalidExpiry(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	for _, expiresAt := range []time.Time{
+		time.Now().Add(-time.Minute),
+		time.Now().Add(models.MaxShareLinkValidity + time.Hour),
+	} {
+		link := models.NewShareLink(models.NewID(), models.NewID(), expiresAt)
+
+		err := usecase.CreateShareLink(ctx, link)
+		assert.True(t, errors.Is(err, ErrInvalidShareLink))
+	}
+}
+
+func TestCreateShareLinkOnLocationSharedAsEditor(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleEditor)
+	link := models.NewShareLink(models.NewID(), loc.ID, time.Now().Add(time.Hour))
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+
+	err := usecase.CreateShareLink(ctx, link)
+	assert.Equal(t, ErrForbidden, err)
+	repo.AssertNotCalled(t, "CreateShareLink", ctx, link)
+}
+
+func TestGetShareLinksWithTokens(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	signer := new(mocks.ShareLinkSignerMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), signer)
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	link := models.NewShareLink(models.NewID(), loc.ID, time.Now().Add(time.Hour))
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("GetShareLinks", ctx, loc.ID).Return(&models.ShareLinks{link}, nil)
+	signer.On("Sign", link.ID, link.ExpiresAt).Return("token")
+
+	links, err := usecase.GetShareLinks(ctx, loc.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, *links, 1) {
+		assert.Equal(t, "token", (*links)[0].Token)
+	}
+}
+
+func TestRevokeShareLinkWithLinkOfAnotherLocation(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	link := models.NewShareLink(models.NewID(), loc.ID, time.Now().Add(time.Hour))
+	other := models.NewID()
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("GetShareLinks", ctx, loc.ID).Return(&models.ShareLinks{link}, nil)
+
+	err := usecase.RevokeShareLink(ctx, loc.ID, other)
+	assert.Equal(t, ErrShareLinkNotFound, err)
+	repo.AssertNotCalled(t, "DeleteShareLink", ctx, other)
+}
+
+func TestRevokeShareLinkWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	link := models.NewShareLink(models.NewID(), loc.ID, time.Now().Add(time.Hour))
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("GetShareLinks", ctx, loc.ID).Return(&models.ShareLinks{link}, nil)
+	repo.On("DeleteShareLink", ctx, link.ID).Return(nil)
+
+	err := usecase.RevokeShareLink(ctx, loc.ID, link.ID)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestGetPublicLocationWithoutUser(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	signer := new(mocks.ShareLinkSignerMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), signer)
+
+	ctx := context.Background()
+	id := models.NewID()
+	loc := &models.PublicLocation{Name: "Home", Address: "1 rue de la Poste, 75001 Paris"}
+	signer.On("Verify", "token").Return(id, nil)
+	repo.On("FindPublicLocationByShareLink", ctx, id).Return(loc, nil)
+
+	returned, err := usecase.GetPublicLocation(ctx, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, loc, returned)
+}
+
+func TestGetPublicLocationWithInvalidToken(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	signer := new(mocks.ShareLinkSignerMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), signer)
+
+	ctx := context.Background()
+	signer.On("Verify", "token").Return(models.NilID, errors.New("expired"))
+
+	_, err := usecase.GetPublicLocation(ctx, "token")
+	assert.Equal(t, ErrShareLinkNotFound, err)
+}
+
+func TestGetPublicLocationWithRevokedLink(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	signer := new(mocks.ShareLinkSignerMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), signer)
+
+	ctx := context.Background()
+	id := models.NewID()
+	signer.On("Verify", "token").Return(id, nil)
+	repo.On("FindPublicLocationByShareLink", ctx, id).Return(nil, nil)
+
+	_, err := usecase.GetPublicLocation(ctx, "token")
+	assert.Equal(t, ErrShareLinkNotFound, err)
+}
@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// GetLocationShares returns the roles granted on a location to other users.
+// Only users managing the location, its owner or users it is shared with as owner, may list them.
+func (u *LocationUsecase) GetLocationShares(ctx context.Context, id models.ID) (*models.Shares, error) {
+	if err := u.authorizer.Authorize(ctx, "GetLocationShares"); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findManagedLocation(ctx, id); err != nil {
+		return nil, err
+	}
+
+	shares, err := u.repo.GetLocationShares(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetLocationShares: failed to get shares of location %s. %w", id, err)
+	}
+
+	return shares, nil
+}
+
+// ShareLocation grants a role on a location to another user, replacing the role already granted to them if any
+func (u *LocationUsecase) ShareLocation(ctx context.Context, id models.ID, share *models.Share) error {
+	if err := u.authorizer.Authorize(ctx, "ShareLocation"); err != nil {
+		return err
+	}
+
+	if err := validateShare(share); err != nil {
+		return err
+	}
+
+	loc, err := u.findManagedLocation(ctx, id)
+	if err != nil {
+		return err
+	}
+	if share.User == loc.User {
+		return ErrSharedWithOwner
+	}
+
+	if err := u.repo.SetLocationShare(ctx, id, share); err != nil {
+		return fmt.Errorf("ShareLocation: failed to share location %s with user %s. %w", id, share.User, err)
+	}
+
+	return nil
+}
+
+// UnshareLocation revokes the role granted on a location to a user
+func (u *LocationUsecase) UnshareLocation(ctx context.Context, id models.ID, user models.ID) error {
+	if err := u.authorizer.Authorize(ctx, "UnshareLocation"); err != nil {
+		return err
+	}
+
+	if _, err := u.findManagedLocation(ctx, id); err != nil {
+		return err
+	}
+
+	shares, err := u.repo.GetLocationShares(ctx, id)
+	if err != nil {
+		return fmt.Errorf("UnshareLocation: failed to get shares of location %s. %w", id, err)
+	}
+	if !hasShare(*shares, user) {
+		return ErrShareNotFound
+	}
+
+	if err := u.repo.DeleteLocationShare(ctx, id, user); err != nil {
+		return fmt.Errorf("UnshareLocation: failed to unshare location %s with user %s. %w", id, user, err)
+	}
+
+	return nil
+}
+
+// GetCategoryShares returns the roles granted on a category of the user to other users
+func (u *LocationUsecase) GetCategoryShares(ctx context.Context, id models.ID) (*models.Shares, error) {
+	if err := u.authorizer.Authorize(ctx, "GetCategoryShares"); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findOwnCategory(ctx, id); err != nil {
+		return nil, err
+	}
+
+	shares, err := u.repo.GetCategoryShares(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetCategoryShares: failed to get shares of category %s. %w", id, err)
+	}
+
+	return shares, nil
+}
+
+// ShareCategory grants a role on all locations of a category of the user to another user,
+// replacing the role already granted to them if any
+func (u *LocationUsecase) ShareCategory(ctx context.Context, id models.ID, share *models.Share) error {
+	if err := u.authorizer.Authorize(ctx, "ShareCategory"); err != nil {
+		return err
+	}
+
+	if err := validateShare(share); err != nil {
+		return err
+	}
+
+	cat, err := u.findOwnCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if share.User == *cat.User {
+		return ErrSharedWithOwner
+	}
+
+	if err := u.repo.SetCategoryShare(ctx, id, share); err != nil {
+		return fmt.Errorf("ShareCategory: failed to share category %s with user %s. %w", id, share.User, err)
+	}
+
+	return nil
+}
+
+// UnshareCategory revokes the role granted on a category of the user to another user
+func (u *LocationUsecase) UnshareCategory(ctx context.Context, id models.ID, user models.ID) error {
+	if err := u.authorizer.Authorize(ctx, "UnshareCategory"); err != nil {
+		return err
+	}
+
+	if _, err := u.findOwnCategory(ctx, id); err != nil {
+		return err
+	}
+
+	shares, err := u.repo.GetCategoryShares(ctx, id)
+	if err != nil {
+		return fmt.Errorf("UnshareCategory: failed to get shares of category %s. %w", id, err)
+	}
+	if !hasShare(*shares, user) {
+		return ErrShareNotFound
+	}
+
+	if err := u.repo.DeleteCategoryShare(ctx, id, user); err != nil {
+		return fmt.Errorf("UnshareCategory: failed to unshare category %s with user %s. %w", id, user, err)
+	}
+
+	return nil
+}
+
+// findManagedLocation returns the location matching id, provided the user may manage it
+func (u *LocationUsecase) findManagedLocation(ctx context.Context, id models.ID) (*models.Location, error) {
+	loc, err := u.repo.FindLocationByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("findManagedLocation: failed to find location by id, %s. %w", id, err)
+	}
+	if loc == nil {
+		return nil, ErrLocationNotFound
+	}
+	if !loc.Role.CanManage() {
+		return nil, ErrForbidden
+	}
+
+	return loc, nil
+}
+
+// findOwnCategory returns the category of the user matching id. Default categories cannot be shared.
+func (u *LocationUsecase) findOwnCategory(ctx context.Context, id models.ID) (*models.Category, error) {
+	cat, err := u.repo.FindCategoryByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("findOwnCategory: failed to find category by id, %s. %w", id, err)
+	}
+	if cat == nil {
+		return nil, ErrCategoryNotFound
+	}
+	if cat.IsDefault() {
+		return nil, ErrCategoryReadOnly
+	}
+
+	return cat, nil
+}
+
+func hasShare(shares models.Shares, user models.ID) bool {
+	for _, share := range shares {
+		if share.User == user {
+			return true
+		}
+	}
+	return false
+}
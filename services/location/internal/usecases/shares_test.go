@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSharedLocation(role models.ShareRole) *models.Location {
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+	loc.Role = role
+	return loc
+}
+
+func TestUpdateLocationSharedAsEditor(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	existing := newSharedLocation(models.ShareRoleEditor)
+	existing.SetCoordinates(48.8606111, 2.3376)
+	loc := models.NewLocation(existing.ID, "New Name", "", models.NilID, user.ID)
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+	repo.On("UpdateLocation", ctx, loc).Return(nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, existing.User, loc.User)
+	assert.Equal(t, existing.Category, loc.Category)
+	repo.AssertNotCalled(t, "FindCategoryByID", ctx, models.NilID)
+}
+
+func TestUpdateLocationSharedAsEditorWithChangedCategory(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	existing := newSharedLocation(models.ShareRoleEditor)
+	loc := models.NewLocation(existing.ID, "New Name", "", models.NewID(), user.ID)
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.True(t, errors.Is(err, ErrForbidden))
+	repo.AssertNotCalled(t, "UpdateLocation", ctx, loc)
+}
+
+func TestUpdateLocationSharedAsViewer(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	existing := newSharedLocation(models.ShareRoleViewer)
+	loc := models.NewLocation(existing.ID, "New Name", "", models.NilID, user.ID)
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.Equal(t, ErrForbidden, err)
+}
+
+func TestDeleteLocationSharedAsEditor(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleEditor)
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+
+	err := usecase.DeleteLocation(ctx, loc.ID)
+	assert.Equal(t, ErrForbidden, err)
+	repo.AssertNotCalled(t, "DeleteLocation", ctx, loc.ID)
+}
+
+func TestDeleteLocationSharedAsOwner(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("DeleteLocation", ctx, loc.ID).Return(nil)
+
+	err := usecase.DeleteLocation(ctx, loc.ID)
+	assert.NoError(t, err)
+}
+
+func TestGetLocationSharesWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	shares := &models.Shares{models.NewShare(models.NewID(), models.ShareRoleViewer)}
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("GetLocationShares", ctx, loc.ID).Return(shares, nil)
+
+	returned, err := usecase.GetLocationShares(ctx, loc.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, shares, returned)
+}
+
+func TestGetLocationSharesSharedAsViewer(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleViewer)
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+
+	_, err := usecase.GetLocationShares(ctx, loc.ID)
+	assert.Equal(t, ErrForbidden, err)
+}
+
+func TestShareLocationWithLocationNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	id := models.NewID()
+	share := models.NewShare(models.NewID(), models.ShareRoleEditor)
+	repo.On("FindLocationByID", ctx, id).Return(nil, nil)
+
+	err := usecase.ShareLocation(ctx, id, share)
+	assert.Equal(t, ErrLocationNotFound, err)
+}
+
+func TestShareLocationWithOwner(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	share := models.NewShare(loc.User, models.ShareRoleEditor)
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+
+	err := usecase.ShareLocation(ctx, loc.ID, share)
+	assert.Equal(t, ErrSharedWithOwner, err)
+}
+
+func TestShareLocationWithUnknownRole(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	share := models.NewShare(models.NewID(), models.ShareRole("admin"))
+
+	err := usecase.ShareLocation(ctx, models.NewID(), share)
+	assertInvalidField(t, err, ErrInvalidShare, "role")
+}
+
+func TestShareLocationWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	share := models.NewShare(models.NewID(), models.ShareRoleEditor)
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("SetLocationShare", ctx, loc.ID, share).Return(nil)
+
+	err := usecase.ShareLocation(ctx, loc.ID, share)
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "SetLocationShare", ctx, loc.ID, share)
+}
+
+func TestUnshareLocationWithShareNotFound(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	user := models.NewID()
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("GetLocationShares", ctx, loc.ID).Return(&models.Shares{}, nil)
+
+	err := usecase.UnshareLocation(ctx, loc.ID, user)
+	assert.Equal(t, ErrShareNotFound, err)
+}
+
+func TestUnshareLocationWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := newSharedLocation(models.ShareRoleOwner)
+	user := models.NewID()
+	repo.On("FindLocationByID", ctx, loc.ID).Return(loc, nil)
+	repo.On("GetLocationShares", ctx, loc.ID).Return(&models.Shares{models.NewShare(user, models.ShareRoleViewer)}, nil)
+	repo.On("DeleteLocationShare", ctx, loc.ID, user).Return(nil)
+
+	err := usecase.UnshareLocation(ctx, loc.ID, user)
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "DeleteLocationShare", ctx, loc.ID, user)
+}
+
+func TestShareCategoryWithDefaultCategory(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	share := models.NewShare(models.NewID(), models.ShareRoleViewer)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+
+	err := usecase.ShareCategory(ctx, cat.ID, share)
+	assert.Equal(t, ErrCategoryReadOnly, err)
+}
+
+func TestShareCategoryWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(user.ID)
+	share := models.NewShare(models.NewID(), models.ShareRoleViewer)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("SetCategoryShare", ctx, cat.ID, share).Return(nil)
+
+	err := usecase.ShareCategory(ctx, cat.ID, share)
+	assert.NoError(t, err)
+	repo.AssertCalled(t, "SetCategoryShare", ctx, cat.ID, share)
+}
+
+func TestUnshareCategoryWithRepositoryDeleteCategoryShareError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	user, _ := models.NewUserFromContext(ctx)
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	cat.SetUser(user.ID)
+	other := models.NewID()
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("GetCategoryShares", ctx, cat.ID).Return(&models.Shares{models.NewShare(other, models.ShareRoleEditor)}, nil)
+	repo.On("DeleteCategoryShare", ctx, cat.ID, other).Return(errors.New("failed"))
+
+	err := usecase.UnshareCategory(ctx, cat.ID, other)
+	assert.Error(t, err)
+}
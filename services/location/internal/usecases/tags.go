@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+)
+
+// CreateTag stores a new user tag in repository
+func (u *LocationUsecase) CreateTag(ctx context.Context, tag *models.Tag) error {
+	if err := u.authorizer.Authorize(ctx, "CreateTag"); err != nil {
+		return err
+	}
+
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	tagWithSameName, err := u.repo.FindTagByName(ctx, tag.Name)
+	if err != nil {
+		return fmt.Errorf("CreateTag: failed to find tag by name in repository : %s. %w", tag.Name, err)
+	}
+	if tagWithSameName != nil {
+		return ErrTagAlreadyExists
+	}
+
+	err = u.repo.CreateTag(ctx, tag)
+	switch {
+	case errors.Is(err, ErrTagAlreadyExists):
+		return ErrTagAlreadyExists
+	case err != nil:
+		return fmt.Errorf("CreateTag: failed to create tag in repository : %v. %w", tag, err)
+	}
+
+	return nil
+}
+
+// GetTags returns all tags of a specific user
+func (u *LocationUsecase) GetTags(ctx context.Context) (*models.Tags, error) {
+	if err := u.authorizer.Authorize(ctx, "GetTags"); err != nil {
+		return nil, err
+	}
+
+	tags, err := u.repo.GetTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetTags: failed to get tags from repository. %w", err)
+	}
+
+	return tags, nil
+}
+
+// FindTagByID returns tag matching specified ID
+func (u *LocationUsecase) FindTagByID(ctx context.Context, id models.ID) (*models.Tag, error) {
+	if err := u.authorizer.Authorize(ctx, "FindTagByID"); err != nil {
+		return nil, err
+	}
+
+	tag, err := u.repo.FindTagByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("FindTagByID: failed to get tag by id, %s. %w", id, err)
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+
+	return tag, nil
+}
+
+// UpdateTag renames specified tag. Locations keep the renamed tag.
+func (u *LocationUsecase) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	if err := u.authorizer.Authorize(ctx, "UpdateTag"); err != nil {
+		return err
+	}
+
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	tagByID, err := u.repo.FindTagByID(ctx, tag.ID)
+	if err != nil {
+		return fmt.Errorf("UpdateTag: failed to find tag by id, %s. %w", tag.ID, err)
+	}
+	if tagByID == nil {
+		return ErrTagNotFound
+	}
+
+	tagWithSameName, err := u.repo.FindTagByName(ctx, tag.Name)
+	if err != nil {
+		return fmt.Errorf("UpdateTag: failed to find tag by name in repository : %s. %w", tag.Name, err)
+	}
+	if tagWithSameName != nil && tagWithSameName.ID != tag.ID {
+		return ErrTagAlreadyExists
+	}
+
+	err = u.repo.UpdateTag(ctx, tag)
+	switch {
+	case errors.Is(err, ErrTagAlreadyExists):
+		return ErrTagAlreadyExists
+	case err != nil:
+		return fmt.Errorf("UpdateTag: failed to update tag, %s. %w", tag.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteTag deletes specified tag, removing it from all locations
+func (u *LocationUsecase) DeleteTag(ctx context.Context, id models.ID) error {
+	if err := u.authorizer.Authorize(ctx, "DeleteTag"); err != nil {
+		return err
+	}
+
+	tag, err := u.repo.FindTagByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteTag: failed to find tag by id, %s. %w", id, err)
+	}
+	if tag == nil {
+		return ErrTagNotFound
+	}
+
+	if err := u.repo.DeleteTag(ctx, id); err != nil {
+		return fmt.Errorf("DeleteTag: failed to delete tag, %s. %w", id, err)
+	}
+
+	return nil
+}
+
+// FindLocationsByTags returns locations visible to the user having all tags named in names when matchAll is true,
+// or any of them otherwise, filtered by category unless catID is NilID
+func (u *LocationUsecase) FindLocationsByTags(ctx context.Context, catID models.ID, names []string, matchAll bool) (*models.Locations, error) {
+	if err := u.authorizer.Authorize(ctx, "FindLocationsByTags"); err != nil {
+		return nil, err
+	}
+
+	var cat *models.Category
+	if catID != models.NilID {
+		var err error
+		cat, err = u.repo.FindVisibleCategoryByID(ctx, catID)
+		if err != nil {
+			return nil, fmt.Errorf("FindLocationsByTags: failed to find category by ID, %s. %w", catID, err)
+		}
+		if cat == nil {
+			return nil, ErrCategoryNotFound
+		}
+	}
+
+	locations, err := u.repo.FindLocationsByTags(ctx, cat, models.NormalizeTagNames(names), matchAll)
+	if err != nil {
+		return nil, fmt.Errorf("FindLocationsByTags: failed to find locations by tags %v. %w", names, err)
+	}
+
+	return locations, nil
+}
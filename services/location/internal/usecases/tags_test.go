@@ -0,0 +1,238 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTagWithNameAlreadyExisting(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	tag := models.NewTag(models.NewID(), "Favorite", models.NewID())
+	repo.On("FindTagByName", ctx, "Favorite").Return(models.NewTag(models.NewID(), "Favorite", tag.User), nil)
+
+	err := usecase.CreateTag(ctx, tag)
+	assert.Equal(t, ErrTagAlreadyExists, err)
+	repo.AssertNotCalled(t, "CreateTag", ctx, tag)
+}
+
+func TestCreateTagWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	tag := models.NewTag(models.NewID(), "Favorite", models.NewID())
+	repo.On("FindTagByName", ctx, "Favorite").Return(nil, nil)
+	repo.On("CreateTag", ctx, tag).Return(nil)
+
+	err := usecase.CreateTag(ctx, tag)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestGetTagsWithRepositoryError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	repo.On("GetTags", ctx).Return(nil, errors.New("failed"))
+
+	_, err := usecase.GetTags(ctx)
+	assert.Error(t, err)
+}
+
+func TestFindTagByIDWithTagNotFoundError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	id := models.NewID()
+	repo.On("FindTagByID", ctx, id).Return(nil, nil)
+
+	_, err := usecase.FindTagByID(ctx, id)
+	assert.Equal(t, ErrTagNotFound, err)
+}
+
+func TestUpdateTagWithTagNotFoundError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	tag := models.NewTag(models.NewID(), "Favorite", models.NewID())
+	repo.On("FindTagByID", ctx, tag.ID).Return(nil, nil)
+
+	err := usecase.UpdateTag(ctx, tag)
+	assert.Equal(t, ErrTagNotFound, err)
+}
+
+func TestUpdateTagWithNameOfAnotherTag(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	tag := models.NewTag(models.NewID(), "Favorite", models.NewID())
+	repo.On("FindTagByID", ctx, tag.ID).Return(models.NewTag(tag.ID, "Old", tag.User), nil)
+	repo.On("FindTagByName", ctx, "Favorite").Return(models.NewTag(models.NewID(), "Favorite", tag.User), nil)
+
+	err := usecase.UpdateTag(ctx, tag)
+	assert.Equal(t, ErrTagAlreadyExists, err)
+	repo.AssertNotCalled(t, "UpdateTag", ctx, tag)
+}
+
+func TestUpdateTagWithSameName(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	tag := models.NewTag(models.NewID(), "Favorite", models.NewID())
+	repo.On("FindTagByID", ctx, tag.ID).Return(tag, nil)
+	repo.On("FindTagByName", ctx, "Favorite").Return(tag, nil)
+	repo.On("UpdateTag", ctx, tag).Return(nil)
+
+	err := usecase.UpdateTag(ctx, tag)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDeleteTagWithTagNotFoundError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	id := models.NewID()
+	repo.On("FindTagByID", ctx, id).Return(nil, nil)
+
+	err := usecase.DeleteTag(ctx, id)
+	assert.Equal(t, ErrTagNotFound, err)
+}
+
+func TestDeleteTagWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	tag := models.NewTag(models.NewID(), "Favorite", models.NewID())
+	repo.On("FindTagByID", ctx, tag.ID).Return(tag, nil)
+	repo.On("DeleteTag", ctx, tag.ID).Return(nil)
+
+	err := usecase.DeleteTag(ctx, tag.ID)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestFindLocationsByTagsWithCategoryNotFoundError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	catID := models.NewID()
+	repo.On("FindVisibleCategoryByID", ctx, catID).Return(nil, nil)
+
+	_, err := usecase.FindLocationsByTags(ctx, catID, []string{"Favorite"}, true)
+	assert.Equal(t, ErrCategoryNotFound, err)
+}
+
+func TestFindLocationsByTagsWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	locs := &models.Locations{models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())}
+	repo.On("FindLocationsByTags", ctx, (*models.Category)(nil), []string{"Favorite", "Vegan"}, false).Return(locs, nil)
+
+	returnedLocs, err := usecase.FindLocationsByTags(ctx, models.NilID, []string{"Vegan", " Favorite", "Vegan"}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, locs, returnedLocs)
+}
+
+func TestCreateLocationWithTags(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.SetTags([]string{"Vegan", "Favorite"})
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+	repo.On("SetLocationTags", ctx, loc.ID, []string{"Favorite", "Vegan"}).Return(nil)
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateLocationWithRepositorySetLocationTagsError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	loc := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	loc.SetTags([]string{"Favorite"})
+	repo.On("FindLocationByName", ctx, "Test Location").Return(nil, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("CreateLocation", ctx, loc).Return(nil)
+	repo.On("SetLocationTags", ctx, loc.ID, loc.Tags).Return(errors.New("failed"))
+	geocoder.On("Geocode", ctx, loc.Address).Return(nil, nil)
+
+	err := usecase.CreateLocation(ctx, loc)
+	assert.Error(t, err)
+}
+
+func TestUpdateLocationKeepsTagsWhenOmitted(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	existing := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	existing.SetCoordinates(48.8606111, 2.3376)
+	existing.SetTags([]string{"Favorite"})
+	loc := models.NewLocation(existing.ID, "New Name", "", cat.ID, existing.User)
+	existing.Role = models.ShareRoleOwner
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("UpdateLocation", ctx, loc).Return(nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Favorite"}, loc.Tags)
+	repo.AssertNotCalled(t, "SetLocationTags", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateLocationRemovesTagsWhenEmpty(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	geocoder := new(mocks.GeocoderMock)
+	usecase := NewLocationUsecase(repo, geocoder, new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	cat := models.NewCategory(models.NewID(), "Test Category")
+	existing := models.NewLocation(models.NewID(), "Test Location", "1 rue de la Poste, 75001 Paris", cat.ID, models.NewID())
+	existing.SetCoordinates(48.8606111, 2.3376)
+	existing.SetTags([]string{"Favorite"})
+	loc := models.NewLocation(existing.ID, "New Name", "", cat.ID, existing.User)
+	loc.SetTags([]string{})
+	existing.Role = models.ShareRoleOwner
+	repo.On("FindLocationByID", ctx, loc.ID).Return(existing, nil)
+	repo.On("FindCategoryByID", ctx, cat.ID).Return(cat, nil)
+	repo.On("UpdateLocation", ctx, loc).Return(nil)
+	repo.On("SetLocationTags", ctx, loc.ID, []string{}).Return(nil)
+
+	err := usecase.UpdateLocation(ctx, loc)
+	assert.NoError(t, err)
+	assert.Empty(t, loc.Tags)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/tiles"
+)
+
+// GetLocationTile renders user locations covered by tile as a Mapbox Vector Tile,
+// with their category ID and name as feature properties. Locations are sorted by ID,
+// so that the same locations always give the same tile.
+func (u *LocationUsecase) GetLocationTile(ctx context.Context, tile tiles.Tile) ([]byte, error) {
+	if err := u.authorizer.Authorize(ctx, "GetLocationTile"); err != nil {
+		return nil, err
+	}
+
+	locations, err := u.repo.FindLocationsInBoundingBox(ctx, nil, tile.BoundingBox())
+	if err != nil {
+		return nil, fmt.Errorf("GetLocationTile: failed to find locations in tile %v. %w", tile, err)
+	}
+
+	categories, err := u.repo.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetLocationTile: failed to get categories. %w", err)
+	}
+
+	categoriesByID := make(map[models.ID]*models.Category, len(*categories))
+	for _, cat := range *categories {
+		categoriesByID[cat.ID] = cat
+	}
+	for _, loc := range *locations {
+		loc.ExpandedCategory = categoriesByID[loc.Category]
+	}
+	// Repository order is not stable, and tiles are compared byte for byte to revalidate them
+	sort.Slice(*locations, func(i, j int) bool {
+		return bytes.Compare((*locations)[i].ID[:], (*locations)[j].ID[:]) < 0
+	})
+
+	return tiles.Encode(tile, *locations), nil
+}
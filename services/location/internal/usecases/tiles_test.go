@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/tiles"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLocationTileWithRepositoryError(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	tile := tiles.Tile{Z: 10, X: 518, Y: 352}
+	repo.On("FindLocationsInBoundingBox", ctx, (*models.Category)(nil), tile.BoundingBox()).Return(nil, errors.New("failed"))
+
+	data, err := usecase.GetLocationTile(ctx, tile)
+	assert.Error(t, err)
+	assert.Nil(t, data)
+}
+
+func TestGetLocationTileWithSuccess(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	tile := tiles.Tile{Z: 10, X: 518, Y: 352}
+	cat := models.NewCategory(models.NewID(), "Museums")
+	loc := newTestLocationAt(48.8606111, 2.3376)
+	loc.Category = cat.ID
+	repo.On("FindLocationsInBoundingBox", ctx, (*models.Category)(nil), tile.BoundingBox()).Return(&models.Locations{loc}, nil)
+	repo.On("GetCategories", ctx).Return(&models.Categories{cat}, nil)
+
+	data, err := usecase.GetLocationTile(ctx, tile)
+	assert.NoError(t, err)
+	assert.Equal(t, cat, loc.ExpandedCategory)
+	assert.Equal(t, tiles.Encode(tile, models.Locations{loc}), data)
+}
+
+func TestGetLocationTileIgnoresRepositoryOrder(t *testing.T) {
+	tile := tiles.Tile{Z: 10, X: 518, Y: 352}
+	locations := models.Locations{
+		newTestLocationAt(48.8606111, 2.3376),
+		newTestLocationAt(48.8584, 2.2945),
+		newTestLocationAt(48.853, 2.3499),
+	}
+
+	var tileData [][]byte
+	for _, order := range [][]int{{0, 1, 2}, {2, 0, 1}, {1, 2, 0}} {
+		repo := new(mocks.LocationRepositoryMock)
+		usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+		ctx := newTestContext()
+		shuffled := make(models.Locations, 0, len(order))
+		for _, i := range order {
+			shuffled = append(shuffled, locations[i])
+		}
+		repo.On("FindLocationsInBoundingBox", ctx, (*models.Category)(nil), tile.BoundingBox()).Return(&shuffled, nil)
+		repo.On("GetCategories", ctx).Return(&models.Categories{}, nil)
+
+		data, err := usecase.GetLocationTile(ctx, tile)
+		assert.NoError(t, err)
+		tileData = append(tileData, data)
+	}
+
+	assert.Equal(t, tileData[0], tileData[1])
+	assert.Equal(t, tileData[0], tileData[2])
+}
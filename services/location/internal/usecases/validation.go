@@ -0,0 +1,207 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/apperrors"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Limits of user input, in characters, matching repository columns
+const (
+	maxNameLength        = 50
+	maxAddressLength     = 500
+	maxStreetLength      = 255
+	maxHouseNumberLength = 20
+	maxPostalCodeLength  = 20
+	maxLocalityLength    = 100
+	maxRegionLength      = 100
+	maxTagsPerLocation   = 20
+)
+
+// textRule tells how a text field of user input is validated
+type textRule struct {
+	required  bool
+	maxLength int
+	// Whether line breaks are allowed, like in addresses
+	multiline bool
+	// Characters that are not allowed, like separators
+	forbidden string
+}
+
+var (
+	nameRule    = textRule{required: true, maxLength: maxNameLength}
+	tagNameRule = textRule{required: true, maxLength: maxNameLength, forbidden: ","}
+)
+
+// fieldValidator normalizes fields of user input and keeps the first error found.
+// Errors are derived from origin, telling which field is invalid.
+type fieldValidator struct {
+	origin *apperrors.Error
+	err    error
+}
+
+func newFieldValidator(origin *apperrors.Error) *fieldValidator {
+	return &fieldValidator{origin: origin}
+}
+
+func (v *fieldValidator) fail(field, format string, args ...interface{}) {
+	if v.err == nil {
+		v.err = v.origin.WithField(field).WithMessage(fmt.Sprintf(format, args...))
+	}
+}
+
+// text trims value and normalizes it to Unicode NFC, so that the same text is always stored
+// the same way, then checks it against rule. Empty values are only checked when required.
+func (v *fieldValidator) text(field string, value *string, rule textRule) {
+	if v.err != nil {
+		return
+	}
+	if !utf8.ValidString(*value) {
+		v.fail(field, "%s must be valid UTF-8", field)
+		return
+	}
+
+	s := norm.NFC.String(strings.TrimSpace(*value))
+	switch {
+	case s == "" && rule.required:
+		v.fail(field, "%s is required", field)
+	case utf8.RuneCountInString(s) > rule.maxLength:
+		v.fail(field, "%s must be at most %d characters", field, rule.maxLength)
+	case hasControlCharacters(s, rule.multiline):
+		v.fail(field, "%s must not contain control characters", field)
+	case rule.forbidden != "" && strings.ContainsAny(s, rule.forbidden):
+		v.fail(field, "%s must not contain any of %q", field, rule.forbidden)
+	}
+
+	*value = s
+}
+
+// countryCode upper cases value, which must be an ISO 3166-1 alpha-2 code when set
+func (v *fieldValidator) countryCode(field string, value *string) {
+	if v.err != nil {
+		return
+	}
+
+	s := strings.ToUpper(strings.TrimSpace(*value))
+	if s != "" && (len(s) != 2 || s[0] < 'A' || s[0] > 'Z' || s[1] < 'A' || s[1] > 'Z') {
+		v.fail(field, "%s must be an ISO 3166-1 alpha-2 code", field)
+	}
+
+	*value = s
+}
+
+// truncate cuts s to at most maxLength characters, trimming spaces left at the cut
+func truncate(s string, maxLength int) string {
+	r := []rune(s)
+	if len(r) <= maxLength {
+		return s
+	}
+	return strings.TrimSpace(string(r[:maxLength]))
+}
+
+func hasControlCharacters(s string, multiline bool) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsControl(r) && !(multiline && r == '\n')
+	}) >= 0
+}
+
+// validateCategory normalizes category fields and checks them
+func validateCategory(cat *models.Category) error {
+	v := newFieldValidator(ErrInvalidCategory)
+	v.text("name", &cat.Name, nameRule)
+	return v.err
+}
+
+// validateTag normalizes tag fields and checks them
+func validateTag(tag *models.Tag) error {
+	v := newFieldValidator(ErrInvalidTag)
+	v.text("name", &tag.Name, tagNameRule)
+	return v.err
+}
+
+// validateShare checks that share grants a known role to a user
+func validateShare(share *models.Share) error {
+	v := newFieldValidator(ErrInvalidShare)
+	if share.User == models.NilID {
+		v.fail("user_id", "user_id is required")
+	}
+	switch share.Role {
+	case models.ShareRoleOwner, models.ShareRoleEditor, models.ShareRoleViewer:
+	default:
+		v.fail("role", "role must be one of owner, editor or viewer")
+	}
+	return v.err
+}
+
+// validateGroup normalizes group fields and checks them
+func validateGroup(group *models.Group) error {
+	v := newFieldValidator(ErrInvalidGroup)
+	v.text("name", &group.Name, nameRule)
+	return v.err
+}
+
+// validateGroupMember checks that member is a user given a known role
+func validateGroupMember(member *models.GroupMember) error {
+	v := newFieldValidator(ErrInvalidGroupMember)
+	if member.User == models.NilID {
+		v.fail("user_id", "user_id is required")
+	}
+	switch member.Role {
+	case models.GroupRoleOwner, models.GroupRoleMember:
+	default:
+		v.fail("role", "role must be one of owner or member")
+	}
+	return v.err
+}
+
+// validateShareLink checks that share link expires within MaxShareLinkValidity from now
+func validateShareLink(link *models.ShareLink, now time.Time) error {
+	v := newFieldValidator(ErrInvalidShareLink)
+	switch {
+	case !link.ExpiresAt.After(now):
+		v.fail("expires_at", "expires_at must be in the future")
+	case link.ExpiresAt.After(now.Add(models.MaxShareLinkValidity)):
+		v.fail("expires_at", "expires_at must be within %s", models.MaxShareLinkValidity)
+	}
+	return v.err
+}
+
+// validateLocation normalizes location fields and checks them. Name and address are only
+// required on creation, as empty fields are left unchanged by updates.
+func validateLocation(loc *models.Location, creating bool) error {
+	v := newFieldValidator(ErrInvalidLocation)
+
+	v.text("name", &loc.Name, textRule{required: creating, maxLength: maxNameLength})
+	v.text("address", &loc.Address, textRule{maxLength: maxAddressLength, multiline: true})
+
+	c := &loc.Components
+	v.text("address_components.street", &c.Street, textRule{maxLength: maxStreetLength})
+	v.text("address_components.house_number", &c.HouseNumber, textRule{maxLength: maxHouseNumberLength})
+	v.text("address_components.postal_code", &c.PostalCode, textRule{maxLength: maxPostalCodeLength})
+	v.text("address_components.locality", &c.Locality, textRule{maxLength: maxLocalityLength})
+	v.text("address_components.region", &c.Region, textRule{maxLength: maxRegionLength})
+	v.countryCode("address_components.country_code", &c.CountryCode)
+
+	if creating && loc.Address == "" && c.IsZero() && (loc.Latitude == nil || loc.Longitude == nil) {
+		v.fail("address", "address is required without address components or coordinates")
+	}
+
+	if loc.Tags != nil {
+		if len(loc.Tags) > maxTagsPerLocation {
+			v.fail("tags", "tags must be at most %d", maxTagsPerLocation)
+		}
+		for i := range loc.Tags {
+			v.text("tags", &loc.Tags[i], tagNameRule)
+		}
+		// Tags may only differ before normalization
+		loc.SetTags(loc.Tags)
+	}
+
+	return v.err
+}
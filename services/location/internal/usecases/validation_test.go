@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/edebernis/social-life-manager/services/location/internal/apperrors"
+	"github.com/edebernis/social-life-manager/services/location/internal/models"
+	"github.com/edebernis/social-life-manager/services/location/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertInvalidField(t *testing.T, err error, origin error, field string) {
+	t.Helper()
+
+	var e *apperrors.Error
+	if assert.True(t, errors.As(err, &e), err) {
+		assert.True(t, errors.Is(err, origin), err)
+		assert.Equal(t, apperrors.KindInvalid, e.Kind)
+		assert.Equal(t, field, e.Field, e.Message)
+	}
+}
+
+func TestValidateCategory(t *testing.T) {
+	cat := models.NewCategory(models.NewID(), "  Cafés ")
+	assert.NoError(t, validateCategory(cat))
+	assert.Equal(t, "Cafés", cat.Name)
+
+	for _, name := range []string{"", "   ", strings.Repeat("a", 51), "Bars\x00", "Bars\nPubs", "\xff"} {
+		err := validateCategory(models.NewCategory(models.NewID(), name))
+		assertInvalidField(t, err, ErrInvalidCategory, "name")
+	}
+}
+
+func TestValidateCategoryCountsCharacters(t *testing.T) {
+	cat := models.NewCategory(models.NewID(), strings.Repeat("é", 50))
+	assert.NoError(t, validateCategory(cat))
+}
+
+func TestValidateTag(t *testing.T) {
+	tag := models.NewTag(models.NewID(), " Vegan ", models.NewID())
+	assert.NoError(t, validateTag(tag))
+	assert.Equal(t, "Vegan", tag.Name)
+
+	err := validateTag(models.NewTag(models.NewID(), "Vegan,Bio", models.NewID()))
+	assertInvalidField(t, err, ErrInvalidTag, "name")
+}
+
+func TestValidateLocation(t *testing.T) {
+	loc := models.NewLocation(models.NewID(), " Home ", "1 rue de la Poste\n75001 Paris ", models.NewID(), models.NewID())
+	loc.Components.CountryCode = "fr"
+	loc.SetTags([]string{"Café", "Café"})
+
+	assert.NoError(t, validateLocation(loc, true))
+	assert.Equal(t, "Home", loc.Name)
+	assert.Equal(t, "1 rue de la Poste\n75001 Paris", loc.Address)
+	assert.Equal(t, "FR", loc.Components.CountryCode)
+	assert.Equal(t, []string{"Café"}, loc.Tags)
+}
+
+func TestValidateLocationWithInvalidFields(t *testing.T) {
+	tests := []struct {
+		update func(*models.Location)
+		field  string
+	}{
+		{func(l *models.Location) { l.Name = strings.Repeat("a", 60) }, "name"},
+		{func(l *models.Location) { l.Name = "" }, "name"},
+		{func(l *models.Location) { l.Address = "1 rue de la Poste\t75001 Paris" }, "address"},
+		{func(l *models.Location) { l.Address = strings.Repeat("a", 501) }, "address"},
+		{func(l *models.Location) { l.Address = "" }, "address"},
+		{func(l *models.Location) { l.Components.PostalCode = strings.Repeat("1", 21) }, "address_components.postal_code"},
+		{func(l *models.Location) { l.Components.CountryCode = "FRA" }, "address_components.country_code"},
+		{func(l *models.Location) { l.Tags = make([]string, 21) }, "tags"},
+		{func(l *models.Location) { l.Tags = []string{"Vegan,Bio"} }, "tags"},
+	}
+
+	for _, test := range tests {
+		loc := models.NewLocation(models.NewID(), "Home", "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+		test.update(loc)
+		assertInvalidField(t, validateLocation(loc, true), ErrInvalidLocation, test.field)
+	}
+}
+
+func TestValidateLocationOnUpdate(t *testing.T) {
+	loc := models.NewLocation(models.NewID(), "", "", models.NewID(), models.NewID())
+	assert.NoError(t, validateLocation(loc, false))
+}
+
+func TestCreateLocationWithNameTooLong(t *testing.T) {
+	repo := new(mocks.LocationRepositoryMock)
+	usecase := NewLocationUsecase(repo, new(mocks.GeocoderMock), new(mocks.ShareLinkSignerMock))
+
+	ctx := newTestContext()
+	loc := models.NewLocation(models.NewID(), strings.Repeat("a", 60), "1 rue de la Poste, 75001 Paris", models.NewID(), models.NewID())
+
+	err := usecase.CreateLocation(ctx, loc)
+	assertInvalidField(t, err, ErrInvalidLocation, "name")
+	repo.AssertNotCalled(t, "FindLocationByName", ctx, loc.Name)
+}
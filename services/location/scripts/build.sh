@@ -38,12 +38,15 @@ protoc                                      \
     --govalidators_out=.                    \
     api/grpc/v1/location.proto
 
-echo "Building app:"
-go install                                  \
-    -installsuffix "static"                 \
-    ./...
-
 echo "Building API docs:"
 swag init                                   \
     -g internal/api/http/v1/server.go       \
     -o ./api/http/v1
+go run ./scripts/openapi                    \
+    -i api/http/v1/swagger.json             \
+    -o api/http/v1
+
+echo "Building app:"
+go install                                  \
+    -installsuffix "static"                 \
+    ./...
@@ -0,0 +1,52 @@
+// Command openapi converts the Swagger 2.0 spec generated by swag into an
+// OpenAPI 3 spec. The JSON file it writes is embedded into the service binary.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+)
+
+func main() {
+	input := flag.String("i", "api/http/v1/swagger.json", "Swagger 2.0 spec to convert")
+	output := flag.String("o", "api/http/v1", "Output directory")
+	flag.Parse()
+
+	if err := convert(*input, *output); err != nil {
+		log.Fatalf("Failed to convert spec to OpenAPI 3. %v", err)
+	}
+}
+
+func convert(input, output string) error {
+	data, err := ioutil.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("failed to read %s. %w", input, err)
+	}
+
+	var doc2 openapi2.T
+	if err := json.Unmarshal(data, &doc2); err != nil {
+		return fmt.Errorf("failed to unmarshal Swagger 2.0 spec. %w", err)
+	}
+
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return fmt.Errorf("failed to convert spec. %w", err)
+	}
+
+	spec, err := json.MarshalIndent(doc3, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal OpenAPI 3 spec. %w", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(output, "openapi.json"), spec, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON spec. %w", err)
+	}
+
+	return nil
+}